@@ -29,6 +29,7 @@ require (
 	github.com/gookit/color v1.5.0
 	github.com/gorilla/mux v1.8.0
 	github.com/gorilla/rpc v1.2.0
+	github.com/gorilla/websocket v1.4.2
 	github.com/iancoleman/strcase v0.2.0
 	github.com/imdario/mergo v0.3.12
 	github.com/jpillora/chisel v1.7.6
@@ -38,6 +39,7 @@ require (
 	github.com/otiai10/copy v1.6.0
 	github.com/pelletier/go-toml v1.9.3
 	github.com/pkg/errors v0.9.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/radovskyb/watcher v1.0.7
 	github.com/rdegges/go-ipify v0.0.0-20150526035502-2d94a6a86c40
 	github.com/rs/cors v1.7.0
@@ -49,10 +51,12 @@ require (
 	github.com/tendermint/spn v0.1.1-0.20211206232052-b887f40714e0
 	github.com/tendermint/tendermint v0.34.14
 	github.com/tendermint/vue v0.1.55
+	github.com/yuin/goldmark v1.4.1 // indirect
 	golang.org/x/mod v0.5.1
+	golang.org/x/net v0.0.0-20211015210444-4f30a5c0130f // indirect
 	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c
 	golang.org/x/term v0.0.0-20201210144234-2321bbc49cbf
-	golang.org/x/tools v0.1.8 // indirect
+	golang.org/x/text v0.3.7 // indirect
 )
 
 replace (
@@ -1,6 +1,7 @@
 package chainconfig
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -8,6 +9,7 @@ import (
 	"path/filepath"
 
 	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/parser"
 	"github.com/imdario/mergo"
 	"github.com/tendermint/starport/starport/pkg/xfilepath"
 )
@@ -29,6 +31,7 @@ var (
 
 // DefaultConf holds default configuration.
 var DefaultConf = Config{
+	Version: LatestVersion,
 	Host: Host{
 		// when in Docker on MacOS, it only works with 0.0.0.0.
 		RPC:     "0.0.0.0:26657",
@@ -55,14 +58,17 @@ var DefaultConf = Config{
 // Config is the user given configuration to do additional setup
 // during serve.
 type Config struct {
-	Accounts  []Account              `yaml:"accounts"`
-	Validator Validator              `yaml:"validator"`
-	Faucet    Faucet                 `yaml:"faucet"`
-	Client    Client                 `yaml:"client"`
-	Build     Build                  `yaml:"build"`
-	Init      Init                   `yaml:"init"`
-	Genesis   map[string]interface{} `yaml:"genesis"`
-	Host      Host                   `yaml:"host"`
+	Version         int                    `yaml:"version"`
+	Accounts        []Account              `yaml:"accounts"`
+	Validator       Validator              `yaml:"validator"`
+	Validators      []Validator            `yaml:"validators"`
+	Faucet          Faucet                 `yaml:"faucet"`
+	Client          Client                 `yaml:"client"`
+	Build           Build                  `yaml:"build"`
+	Init            Init                   `yaml:"init"`
+	Genesis         map[string]interface{} `yaml:"genesis"`
+	GenesisFixtures []GenesisFixture       `yaml:"genesis_fixtures"`
+	Host            Host                   `yaml:"host"`
 }
 
 // AccountByName finds account by name.
@@ -75,6 +81,22 @@ func (c Config) AccountByName(name string) (acc Account, found bool) {
 	return Account{}, false
 }
 
+// ListValidators returns the validators of the chain.
+// The validator defined with the `validator` key is used when no `validators`
+// list is provided.
+func (c Config) ListValidators() []Validator {
+	if len(c.Validators) > 0 {
+		return c.Validators
+	}
+	return []Validator{c.Validator}
+}
+
+// IsTestnet checks if the config describes a local testnet with more than
+// one validator.
+func (c Config) IsTestnet() bool {
+	return len(c.ListValidators()) > 1
+}
+
 // Account holds the options related to setting up Cosmos wallets.
 type Account struct {
 	Name     string   `yaml:"name"`
@@ -93,11 +115,37 @@ type Validator struct {
 	Staked string `yaml:"staked"`
 }
 
+// GenesisFixture is a JSON or YAML file holding the genesis state of a module
+// to seed into the genesis of the chain.
+type GenesisFixture struct {
+	// Module is the name of the module the state belongs to.
+	Module string `yaml:"module"`
+
+	// File is the path of the fixture file, relative paths are relative to the app's directory.
+	File string `yaml:"file"`
+}
+
 // Build holds build configs.
 type Build struct {
 	Main   string `yaml:"main"`
 	Binary string `yaml:"binary"`
 	Proto  Proto  `yaml:"proto"`
+
+	// LDFlags are the additional linker flags passed to the Go compiler, they're
+	// appended after the default ones so they can override the version variables.
+	LDFlags []string `yaml:"ldflags"`
+
+	// Tags are the build tags passed to the Go compiler, e.g. ledger.
+	Tags []string `yaml:"tags"`
+
+	// Env holds the environment variables set while building the app and running its hooks.
+	Env map[string]string `yaml:"env"`
+
+	// PreBuild holds the shell commands run in the app's directory before building it.
+	PreBuild []string `yaml:"pre_build"`
+
+	// PostBuild holds the shell commands run in the app's directory after building it.
+	PostBuild []string `yaml:"post_build"`
 }
 
 // Proto holds proto build configs.
@@ -158,7 +206,26 @@ type Faucet struct {
 	Host string `yaml:"host"`
 
 	// Port number for faucet server to listen at.
+	// Deprecated: the port of older config.yml versions is migrated into Host.
 	Port int `yaml:"port"`
+
+	// InProcess signs and broadcasts the transfers in-process instead of using the chain's binary.
+	InProcess bool `yaml:"in_process"`
+
+	// BatchWindow sets the duration during which the requested transfers are collected
+	// to be paid out together. transfers are not batched when it is not set.
+	BatchWindow string `yaml:"batch_window"`
+
+	// PoWDifficulty is the number of leading zero bits required to solve the proof-of-work
+	// challenge requested before each transfer. proof of work is not required when it is zero.
+	PoWDifficulty uint `yaml:"pow_difficulty"`
+
+	// IPRateLimit is the maximum number of transfer requests allowed per IP within IPRateLimitWindow.
+	// requests are not limited per IP when it is zero.
+	IPRateLimit int `yaml:"ip_rate_limit"`
+
+	// IPRateLimitWindow is the timeframe after which the requests count of an IP is refreshed.
+	IPRateLimitWindow string `yaml:"ip_rate_limit_window"`
 }
 
 // Init overwrites sdk configurations with given values.
@@ -190,10 +257,61 @@ type Host struct {
 }
 
 // Parse parses config.yml into UserConfig.
-func Parse(r io.Reader) (Config, error) {
+// documents of older versions are migrated to the latest version, the selected profile
+// is merged over the base config and the environment variables referenced in values
+// are expanded before decoding.
+func Parse(r io.Reader, options ...ParseOption) (Config, error) {
+	var o parseOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Config{}, err
+	}
+
+	file, err := parser.ParseBytes(data, parser.ParseComments)
+	if err != nil {
+		return Config{}, err
+	}
+
+	// documents of older versions are upgraded before being decoded.
+	from, err := migrateFile(file)
+	if err != nil {
+		return Config{}, err
+	}
+	if from != LatestVersion {
+		data = []byte(file.String())
+	}
+
+	// keys are checked on the parsed document to report the lines of the original file.
+	if err := checkUnknownKeys(file); err != nil {
+		return Config{}, err
+	}
+
+	var doc map[string]interface{}
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		return Config{}, err
+	}
+	if doc == nil {
+		doc = make(map[string]interface{})
+	}
+	if err := applyProfile(doc, o.profile); err != nil {
+		return Config{}, err
+	}
+	if _, err := interpolateEnv(doc); err != nil {
+		return Config{}, err
+	}
+
+	data, err = yaml.Marshal(doc)
+	if err != nil {
+		return Config{}, err
+	}
+
 	var conf Config
-	if err := yaml.NewDecoder(r).Decode(&conf); err != nil {
-		return conf, err
+	if err := yaml.Unmarshal(data, &conf); err != nil {
+		return Config{}, err
 	}
 	if err := mergo.Merge(&conf, DefaultConf); err != nil {
 		return Config{}, err
@@ -202,13 +320,13 @@ func Parse(r io.Reader) (Config, error) {
 }
 
 // ParseFile parses config.yml from the path.
-func ParseFile(path string) (Config, error) {
+func ParseFile(path string, options ...ParseOption) (Config, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return Config{}, nil
 	}
 	defer file.Close()
-	return Parse(file)
+	return Parse(file, options...)
 }
 
 // validate validates user config.
@@ -216,8 +334,38 @@ func validate(conf Config) error {
 	if len(conf.Accounts) == 0 {
 		return &ValidationError{"at least 1 account is needed"}
 	}
-	if conf.Validator.Name == "" {
-		return &ValidationError{"validator is required"}
+	if conf.Validator.Name != "" && len(conf.Validators) > 0 {
+		return &ValidationError{"validator and validators cannot be used together"}
+	}
+
+	names := make(map[string]bool)
+	for _, validator := range conf.ListValidators() {
+		if validator.Name == "" {
+			return &ValidationError{"validator is required"}
+		}
+		if names[validator.Name] {
+			return &ValidationError{fmt.Sprintf("validator %q is defined more than once", validator.Name)}
+		}
+		names[validator.Name] = true
+	}
+
+	for _, fixture := range conf.GenesisFixtures {
+		if fixture.Module == "" || fixture.File == "" {
+			return &ValidationError{"module and file are required for genesis fixtures"}
+		}
+	}
+
+	checks := []func(Config) error{
+		validateAccounts,
+		validateStakes,
+		validateFaucet,
+		validateHost,
+		validateBuild,
+	}
+	for _, check := range checks {
+		if err := check(conf); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -246,8 +394,8 @@ func LocateDefault(root string) (path string, err error) {
 
 // FaucetHost returns the faucet host to use
 func FaucetHost(conf Config) string {
-	// We keep supporting Port option for backward compatibility
-	// TODO: drop this option in the future
+	// Port is migrated into Host when config.yml is parsed, it is still
+	// supported for configs that are not parsed from a file.
 	host := conf.Faucet.Host
 	if conf.Faucet.Port != 0 {
 		host = fmt.Sprintf(":%d", conf.Faucet.Port)
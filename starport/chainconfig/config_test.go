@@ -139,3 +139,107 @@ faucet:
 	require.NoError(t, err)
 	require.Equal(t, ":4700", FaucetHost(conf))
 }
+
+func TestParseValidators(t *testing.T) {
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+  - name: bob
+    coins: ["100000000stake"]
+validators:
+  - name: alice
+    staked: "50000000stake"
+  - name: bob
+    staked: "40000000stake"
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+
+	require.NoError(t, err)
+	require.True(t, conf.IsTestnet())
+	require.Equal(t, []Validator{
+		{Name: "alice", Staked: "50000000stake"},
+		{Name: "bob", Staked: "40000000stake"},
+	}, conf.ListValidators())
+}
+
+func TestParseValidatorsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		confyml string
+		err     error
+	}{
+		{
+			name: "both validator and validators",
+			confyml: `
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validator:
+  name: alice
+  staked: "50000000stake"
+validators:
+  - name: alice
+    staked: "50000000stake"
+`,
+			err: &ValidationError{"validator and validators cannot be used together"},
+		},
+		{
+			name: "duplicated validator",
+			confyml: `
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validators:
+  - name: alice
+    staked: "50000000stake"
+  - name: alice
+    staked: "50000000stake"
+`,
+			err: &ValidationError{`validator "alice" is defined more than once`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.confyml))
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestParseGenesisFixtures(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: me
+  staked: "100000000stake"
+genesis_fixtures:
+  - module: blog
+    file: fixtures/posts.json
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+
+	require.NoError(t, err)
+	require.Equal(t, []GenesisFixture{
+		{Module: "blog", File: "fixtures/posts.json"},
+	}, conf.GenesisFixtures)
+
+	confyml = `
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: me
+  staked: "100000000stake"
+genesis_fixtures:
+  - module: blog
+`
+
+	_, err = Parse(strings.NewReader(confyml))
+	require.Equal(t, &ValidationError{"module and file are required for genesis fixtures"}, err)
+}
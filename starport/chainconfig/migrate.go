@@ -0,0 +1,220 @@
+package chainconfig
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// LatestVersion is the latest version of the config.yml schema.
+const LatestVersion = 1
+
+// versionKey is the key of config.yml that holds the version of its schema.
+const versionKey = "version"
+
+// migration upgrades the root mapping of a config document by one version.
+type migration func(root ast.Node) error
+
+// migrations holds the migrations of the config.yml schema. the migration at
+// index i upgrades a document of version i to version i+1.
+var migrations = []migration{
+	migrateV0,
+}
+
+// Migrate upgrades the config document in data to the latest version step by step.
+// comments and formatting of the document are preserved, data is returned as it is
+// when the document is already at the latest version.
+func Migrate(data []byte) (migrated []byte, from int, err error) {
+	file, err := parser.ParseBytes(data, parser.ParseComments)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	from, err = migrateFile(file)
+	if err != nil {
+		return nil, 0, err
+	}
+	if from == LatestVersion {
+		return data, from, nil
+	}
+
+	return []byte(file.String() + "\n"), from, nil
+}
+
+// migrateFile upgrades the parsed config document in place and returns its original version.
+func migrateFile(file *ast.File) (from int, err error) {
+	if len(file.Docs) == 0 || file.Docs[0].Body == nil {
+		return LatestVersion, nil
+	}
+
+	doc := file.Docs[0]
+
+	from, err = documentVersion(doc.Body)
+	if err != nil {
+		return 0, err
+	}
+	if from > LatestVersion {
+		return 0, &ValidationError{fmt.Sprintf(
+			"config version %d is not supported, the latest supported version is %d, please upgrade Starport",
+			from,
+			LatestVersion,
+		)}
+	}
+	if from == LatestVersion {
+		return from, nil
+	}
+
+	for _, migrate := range migrations[from:] {
+		if err := migrate(doc.Body); err != nil {
+			return 0, err
+		}
+	}
+
+	if doc.Body, err = setVersion(doc.Body, LatestVersion); err != nil {
+		return 0, err
+	}
+
+	return from, nil
+}
+
+// migrateV0 moves the legacy faucet.port into faucet.host.
+func migrateV0(root ast.Node) error {
+	configs := []ast.Node{root}
+
+	// profiles are partial configs and need to be migrated too.
+	if profiles := findKey(root, profilesKey); profiles != nil {
+		for _, profile := range mappingValues(profiles.Value) {
+			configs = append(configs, profile.Value)
+		}
+	}
+
+	for _, config := range configs {
+		faucet := findKey(config, "faucet")
+		if faucet == nil {
+			continue
+		}
+		port := findKey(faucet.Value, "port")
+		if port == nil {
+			continue
+		}
+
+		// a zero or empty port was ignored by FaucetHost, it doesn't replace the host.
+		switch port.Value.GetToken().Value {
+		case "", "0", "null", "~":
+			if m, ok := faucet.Value.(*ast.MappingNode); ok {
+				removeKey(m, "port")
+			}
+			continue
+		}
+
+		// the port has priority over the host, see FaucetHost.
+		host, err := yaml.ValueToNode(fmt.Sprintf(":%s", port.Value.GetToken().Value))
+		if err != nil {
+			return err
+		}
+		host.SetComment(port.Value.GetComment())
+
+		if existing := findKey(faucet.Value, "host"); existing != nil {
+			existing.Value = host
+			if m, ok := faucet.Value.(*ast.MappingNode); ok {
+				removeKey(m, "port")
+			}
+			continue
+		}
+
+		renameKey(port, "host")
+		port.Value = host
+	}
+
+	return nil
+}
+
+// documentVersion returns the version of a config document, documents without
+// a version are at version 0.
+func documentVersion(root ast.Node) (int, error) {
+	v := findKey(root, versionKey)
+	if v == nil {
+		return 0, nil
+	}
+
+	version, err := strconv.Atoi(v.Value.GetToken().Value)
+	if err != nil || version < 0 {
+		return 0, &ValidationError{fmt.Sprintf("invalid config version %s", v.Value.GetToken().Value)}
+	}
+
+	return version, nil
+}
+
+// setVersion sets the version of a config document, the version key is added at the top
+// of the document when it doesn't exist.
+func setVersion(root ast.Node, version int) (ast.Node, error) {
+	value, err := yaml.ValueToNode(version)
+	if err != nil {
+		return nil, err
+	}
+
+	if v := findKey(root, versionKey); v != nil {
+		v.Value = value
+		return root, nil
+	}
+
+	node, err := yaml.ValueToNode(map[string]interface{}{versionKey: version})
+	if err != nil {
+		return nil, err
+	}
+	versionValue := mappingValues(node)[0]
+
+	switch n := root.(type) {
+	case *ast.MappingNode:
+		n.Values = append([]*ast.MappingValueNode{versionValue}, n.Values...)
+		return n, nil
+	case *ast.MappingValueNode:
+		return ast.Mapping(n.GetToken(), false, versionValue, n), nil
+	}
+
+	return nil, &ValidationError{"config must be a map"}
+}
+
+// findKey returns the key-value pair of key in the mapping node.
+func findKey(node ast.Node, key string) *ast.MappingValueNode {
+	for _, v := range mappingValues(node) {
+		if v.Key.String() == key {
+			return v
+		}
+	}
+	return nil
+}
+
+// removeKey removes key from the mapping node.
+func removeKey(node *ast.MappingNode, key string) {
+	for i, v := range node.Values {
+		if v.Key.String() == key {
+			node.Values = append(node.Values[:i], node.Values[i+1:]...)
+			return
+		}
+	}
+}
+
+// renameKey renames the key of a key-value pair in place.
+func renameKey(v *ast.MappingValueNode, key string) {
+	if s, ok := v.Key.(*ast.StringNode); ok {
+		s.Value = key
+		s.Token.Value = key
+		s.Token.Origin = key
+	}
+}
+
+// mappingValues returns the key-value pairs of a mapping node, a mapping with a single
+// pair is parsed as a key-value pair node.
+func mappingValues(node ast.Node) []*ast.MappingValueNode {
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		return n.Values
+	case *ast.MappingValueNode:
+		return []*ast.MappingValueNode{n}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package chainconfig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name     string
+		confyml  string
+		expected string
+		from     int
+		err      error
+	}{
+		{
+			name: "faucet port",
+			confyml: `# accounts of the chain
+accounts:
+  - name: me # the validator
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: me
+  staked: "100000000stake"
+faucet:
+  name: me
+  port: 4700 # faucet port
+`,
+			expected: `# accounts of the chain
+version: 1
+accounts:
+  - name: me # the validator
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: me
+  staked: "100000000stake"
+faucet:
+  name: me
+  host: ":4700" # faucet port
+`,
+		},
+		{
+			name: "faucet port and host",
+			confyml: `accounts:
+  - name: me
+faucet:
+  host: 0.0.0.0:4600
+  port: 4700
+profiles:
+  ci:
+    faucet:
+      port: 4800
+`,
+			expected: `version: 1
+accounts:
+  - name: me
+faucet:
+  host: ":4700"
+profiles:
+  ci:
+    faucet:
+      host: ":4800"
+`,
+		},
+		{
+			name: "zero faucet port",
+			confyml: `accounts:
+  - name: me
+faucet:
+  name: me
+  host: ":4600"
+  port: 0
+`,
+			expected: `version: 1
+accounts:
+  - name: me
+faucet:
+  name: me
+  host: ":4600"
+`,
+		},
+		{
+			name: "latest version",
+			confyml: `version: 1
+accounts:
+  - name: me
+`,
+			expected: `version: 1
+accounts:
+  - name: me
+`,
+			from: 1,
+		},
+		{
+			name: "unsupported version",
+			confyml: `version: 2
+accounts:
+  - name: me
+`,
+			err: &ValidationError{"config version 2 is not supported, the latest supported version is 1, please upgrade Starport"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrated, from, err := Migrate([]byte(tt.confyml))
+			if tt.err != nil {
+				require.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.from, from)
+			require.Equal(t, tt.expected, string(migrated))
+		})
+	}
+}
+
+func TestParseMigratedZeroFaucetPort(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: me
+  staked: "100000000stake"
+faucet:
+  host: ":4600"
+  port: 0
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, ":4600", FaucetHost(conf))
+}
+
+func TestParseMigrated(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: me
+  staked: "100000000stake"
+faucet:
+  port: 4700
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, LatestVersion, conf.Version)
+	require.Equal(t, ":4700", conf.Faucet.Host)
+	require.Equal(t, ":4700", FaucetHost(conf))
+}
@@ -0,0 +1,151 @@
+package chainconfig
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// profilesKey is the key of config.yml that holds the profiles.
+const profilesKey = "profiles"
+
+// envVarRe matches environment variable references in the form of ${NAME} or ${NAME:-default}.
+// references escaped as $${NAME} are kept as they are without the leading $.
+var envVarRe = regexp.MustCompile(`\$?\$\{([a-zA-Z_][a-zA-Z0-9_]*)(:-([^}]*))?\}`)
+
+// ParseOption configures the parsing of config.yml.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	profile string
+}
+
+// Profile selects the named profile of config.yml to merge over the base config.
+func Profile(name string) ParseOption {
+	return func(o *parseOptions) {
+		o.profile = name
+	}
+}
+
+// applyProfile removes the profiles from the config document and deep merges
+// the selected one over the rest of the document.
+func applyProfile(doc map[string]interface{}, name string) error {
+	profiles, err := toMap(doc[profilesKey])
+	if err != nil {
+		return &ValidationError{fmt.Sprintf("%s must be a map of profile names to configs", profilesKey)}
+	}
+	delete(doc, profilesKey)
+
+	if name == "" {
+		return nil
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		return &ValidationError{fmt.Sprintf("profile %q is not defined", name)}
+	}
+
+	overrides, err := toMap(profile)
+	if err != nil {
+		return &ValidationError{fmt.Sprintf("profile %q must be a map", name)}
+	}
+
+	mergeMaps(doc, overrides)
+	return nil
+}
+
+// mergeMaps deep merges src into dst. maps are merged key by key, other values
+// including lists are replaced.
+func mergeMaps(dst, src map[string]interface{}) {
+	for key, srcValue := range src {
+		srcMap, srcIsMap := srcValue.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeMaps(dstMap, srcMap)
+			continue
+		}
+		dst[key] = srcValue
+	}
+}
+
+// interpolateEnv replaces the environment variable references inside the string
+// values of the config document.
+func interpolateEnv(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		expanded, err := expandEnv(v)
+		if err != nil {
+			return nil, err
+		}
+
+		// a value made of a single reference takes the type of the variable's value, e.g. a number.
+		if expanded != v && !strings.HasPrefix(v, "$$") && envVarRe.FindString(v) == v {
+			var typed interface{}
+			if err := yaml.Unmarshal([]byte(expanded), &typed); err == nil {
+				switch typed.(type) {
+				case map[string]interface{}, []interface{}, nil:
+				default:
+					return typed, nil
+				}
+			}
+		}
+		return expanded, nil
+	case map[string]interface{}:
+		for key, elem := range v {
+			expanded, err := interpolateEnv(elem)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = expanded
+		}
+	case []interface{}:
+		for i, elem := range v {
+			expanded, err := interpolateEnv(elem)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = expanded
+		}
+	}
+	return value, nil
+}
+
+// expandEnv expands the environment variable references in s. a reference to an unset
+// variable without a default value is an error.
+func expandEnv(s string) (string, error) {
+	var err error
+
+	expanded := envVarRe.ReplaceAllStringFunc(s, func(ref string) string {
+		if strings.HasPrefix(ref, "$$") {
+			return ref[1:]
+		}
+
+		match := envVarRe.FindStringSubmatch(ref)
+		if value, ok := os.LookupEnv(match[1]); ok {
+			return value
+		}
+		if match[2] != "" {
+			return match[3]
+		}
+
+		if err == nil {
+			err = &ValidationError{fmt.Sprintf("environment variable %s is not set", match[1])}
+		}
+		return ref
+	})
+
+	return expanded, err
+}
+
+func toMap(value interface{}) (map[string]interface{}, error) {
+	switch v := value.(type) {
+	case nil:
+		return map[string]interface{}{}, nil
+	case map[string]interface{}:
+		return v, nil
+	}
+	return nil, fmt.Errorf("%v is not a map", value)
+}
@@ -0,0 +1,116 @@
+package chainconfig
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const profilesConfyml = `
+accounts:
+  - name: alice
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: alice
+  staked: "100000000stake"
+faucet:
+  name: alice
+  coins: ["5token"]
+  coins_max: ["100token"]
+init:
+  app:
+    minimum-gas-prices: "0stake"
+profiles:
+  ci:
+    accounts:
+      - name: bob
+        coins: ["1token", "100000000stake"]
+    validator:
+      name: bob
+    faucet:
+      coins_max: ["10token"]
+    init:
+      home: "/tmp/ci"
+`
+
+func TestParseProfile(t *testing.T) {
+	conf, err := Parse(strings.NewReader(profilesConfyml))
+	require.NoError(t, err)
+	require.Equal(t, "alice", conf.Validator.Name)
+	require.Equal(t, []string{"100token"}, conf.Faucet.CoinsMax)
+
+	conf, err = Parse(strings.NewReader(profilesConfyml), Profile("ci"))
+	require.NoError(t, err)
+	require.Equal(t, []Account{{Name: "bob", Coins: []string{"1token", "100000000stake"}}}, conf.Accounts)
+	require.Equal(t, Validator{Name: "bob", Staked: "100000000stake"}, conf.Validator)
+	require.Equal(t, []string{"5token"}, conf.Faucet.Coins)
+	require.Equal(t, []string{"10token"}, conf.Faucet.CoinsMax)
+	require.Equal(t, "/tmp/ci", conf.Init.Home)
+	require.Equal(t, "0stake", conf.Init.App["minimum-gas-prices"])
+
+	_, err = Parse(strings.NewReader(profilesConfyml), Profile("demo"))
+	require.Equal(t, &ValidationError{`profile "demo" is not defined`}, err)
+}
+
+func TestParseEnvInterpolation(t *testing.T) {
+	os.Setenv("STARPORT_TEST_MNEMONIC", "ozone unfold device")
+	os.Setenv("STARPORT_TEST_IP_RATE_LIMIT", "4700")
+	defer os.Unsetenv("STARPORT_TEST_MNEMONIC")
+	defer os.Unsetenv("STARPORT_TEST_IP_RATE_LIMIT")
+
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["${STARPORT_TEST_AMOUNT:-1000}token", "100stake"]
+    mnemonic: ${STARPORT_TEST_MNEMONIC}
+validator:
+  name: alice
+  staked: "100stake"
+init:
+  home: "$${NOT_EXPANDED}/chain"
+faucet:
+  ip_rate_limit: ${STARPORT_TEST_IP_RATE_LIMIT}
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, []string{"1000token", "100stake"}, conf.Accounts[0].Coins)
+	require.Equal(t, "ozone unfold device", conf.Accounts[0].Mnemonic)
+	require.Equal(t, "${NOT_EXPANDED}/chain", conf.Init.Home)
+	require.Equal(t, 4700, conf.Faucet.IPRateLimit)
+
+	// a single interpolated coin is valid and the interpolated faucet port is migrated into the faucet host.
+	os.Setenv("STARPORT_TEST_FAUCET_PORT", "4700")
+	defer os.Unsetenv("STARPORT_TEST_FAUCET_PORT")
+
+	confyml = `
+accounts:
+  - name: alice
+    coins: ["${STARPORT_TEST_AMOUNT:-1000}token"]
+    mnemonic: ${STARPORT_TEST_MNEMONIC}
+validator:
+  name: alice
+  staked: "100token"
+faucet:
+  port: ${STARPORT_TEST_FAUCET_PORT}
+`
+
+	conf, err = Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, []string{"1000token"}, conf.Accounts[0].Coins)
+	require.Equal(t, "ozone unfold device", conf.Accounts[0].Mnemonic)
+	require.Equal(t, ":4700", conf.Faucet.Host)
+
+	confyml = `
+accounts:
+  - name: alice
+    coins: ["${STARPORT_TEST_UNSET}"]
+validator:
+  name: alice
+`
+
+	_, err = Parse(strings.NewReader(confyml))
+	require.Equal(t, &ValidationError{"environment variable STARPORT_TEST_UNSET is not set"}, err)
+}
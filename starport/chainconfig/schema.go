@@ -0,0 +1,272 @@
+package chainconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+)
+
+// configType is the type that describes the keys of config.yml.
+var configType = reflect.TypeOf(Config{})
+
+// UnknownKey is a key of config.yml that is not part of the schema.
+type UnknownKey struct {
+	// Path of the key, e.g. init.keyring_backend.
+	Path string
+
+	// Line and Column where the key is defined.
+	Line, Column int
+
+	// Suggestion is a known key with a similar name, if any.
+	Suggestion string
+}
+
+func (k UnknownKey) String() string {
+	s := fmt.Sprintf("unknown key %q at line %d, column %d", k.Path, k.Line, k.Column)
+	if k.Suggestion != "" {
+		s += fmt.Sprintf(", did you mean %q?", k.Suggestion)
+	}
+	return s
+}
+
+// UnknownKeysError is returned when config.yml contains keys that are not part of the schema.
+type UnknownKeysError struct {
+	Keys []UnknownKey
+}
+
+func (e *UnknownKeysError) Error() string {
+	lines := make([]string, len(e.Keys))
+	for i, key := range e.Keys {
+		lines[i] = key.String()
+	}
+	return fmt.Sprintf("config is not valid:\n%s", strings.Join(lines, "\n"))
+}
+
+// checkUnknownKeys checks that the keys of the parsed config document are all part of the schema.
+func checkUnknownKeys(file *ast.File) error {
+	if len(file.Docs) == 0 || file.Docs[0].Body == nil {
+		return nil
+	}
+
+	var keys []UnknownKey
+
+	root := file.Docs[0].Body
+	for _, v := range mappingValues(root) {
+		if v.Key.GetToken().Value != profilesKey {
+			continue
+		}
+		// profiles are partial configs.
+		for _, profile := range mappingValues(v.Value) {
+			path := fmt.Sprintf("%s.%s", profilesKey, profile.Key.GetToken().Value)
+			keys = append(keys, unknownKeys(profile.Value, configType, path)...)
+		}
+	}
+	keys = append(keys, unknownKeys(root, configType, "")...)
+
+	if len(keys) > 0 {
+		sort.Slice(keys, func(i, j int) bool { return keys[i].Line < keys[j].Line })
+		return &UnknownKeysError{keys}
+	}
+	return nil
+}
+
+// unknownKeys returns the keys of node at path that are not part of typ.
+func unknownKeys(node ast.Node, typ reflect.Type, path string) (keys []UnknownKey) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Slice:
+		if seq, ok := node.(*ast.SequenceNode); ok {
+			for i, elem := range seq.Values {
+				keys = append(keys, unknownKeys(elem, typ.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+
+	case reflect.Struct:
+		fields := yamlFields(typ)
+		for _, v := range mappingValues(node) {
+			var (
+				tk      = v.Key.GetToken()
+				keyPath = joinPath(path, tk.Value)
+			)
+
+			// profiles are checked separately at the root.
+			if path == "" && typ == configType && tk.Value == profilesKey {
+				continue
+			}
+
+			field, ok := fields[tk.Value]
+			if !ok {
+				keys = append(keys, UnknownKey{
+					Path:       keyPath,
+					Line:       tk.Position.Line,
+					Column:     tk.Position.Column,
+					Suggestion: suggestKey(tk.Value, fields),
+				})
+				continue
+			}
+
+			keys = append(keys, unknownKeys(v.Value, field.Type, keyPath)...)
+		}
+	}
+
+	// maps are free form, e.g. the overwrites of init.app.
+	return keys
+}
+
+// yamlFields returns the fields of a struct type by their YAML key.
+func yamlFields(typ reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+// maxSuggestionDistance is the maximum edit distance between an unknown key
+// and a known key to suggest it.
+const maxSuggestionDistance = 2
+
+// suggestKey returns the known key that is the closest to key.
+func suggestKey(key string, fields map[string]reflect.StructField) string {
+	normalize := func(s string) string {
+		return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(s))
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var (
+		suggestion string
+		best       = maxSuggestionDistance + 1
+	)
+	for _, name := range names {
+		if normalize(name) == normalize(key) {
+			return name
+		}
+		if d := levenshtein(name, key); d < best {
+			suggestion, best = name, d
+		}
+	}
+	return suggestion
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return fmt.Sprintf("%s.%s", path, key)
+}
+
+// JSONSchema returns the JSON Schema of config.yml. editors can use it to validate
+// and autocomplete config.yml.
+func JSONSchema() ([]byte, error) {
+	config := typeSchema(configType)
+	properties := config["properties"].(map[string]interface{})
+	properties["version"] = map[string]interface{}{
+		"type":    "integer",
+		"minimum": 0,
+		"maximum": LatestVersion,
+	}
+
+	// a profile can set any key of the config, except the profiles.
+	profile := typeSchema(configType)
+
+	properties[profilesKey] = map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": map[string]interface{}{"$ref": "#/definitions/profile"},
+	}
+
+	schema := map[string]interface{}{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"title":       "Starport config.yml",
+		"definitions": map[string]interface{}{"profile": profile},
+		"required":    []string{"accounts"},
+	}
+	for key, value := range config {
+		schema[key] = value
+	}
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// typeSchema returns the JSON Schema of the values of typ.
+func typeSchema(typ reflect.Type) map[string]interface{} {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": typeSchema(typ.Elem())}
+	case reflect.Map:
+		// maps are free form.
+		return map[string]interface{}{"type": "object"}
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		for name, field := range yamlFields(typ) {
+			properties[name] = typeSchema(field.Type)
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+	}
+	return map[string]interface{}{}
+}
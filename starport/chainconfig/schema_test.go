@@ -0,0 +1,62 @@
+package chainconfig
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUnknownKeys(t *testing.T) {
+	confyml := `version: 1
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+    mnemnoic: "ozone unfold device"
+validator:
+  name: me
+  staked: "100000000stake"
+init:
+  keyring_backend: "test"
+  app:
+    any-key: "allowed"
+profiles:
+  ci:
+    faucets:
+      name: me
+`
+
+	_, err := Parse(strings.NewReader(confyml))
+	require.Equal(t, &UnknownKeysError{[]UnknownKey{
+		{Path: "accounts[0].mnemnoic", Line: 5, Column: 5, Suggestion: "mnemonic"},
+		{Path: "init.keyring_backend", Line: 10, Column: 3, Suggestion: "keyring-backend"},
+		{Path: "profiles.ci.faucets", Line: 15, Column: 5, Suggestion: "faucet"},
+	}}, err)
+	require.Contains(t, err.Error(), `unknown key "init.keyring_backend" at line 10, column 3, did you mean "keyring-backend"?`)
+}
+
+func TestJSONSchema(t *testing.T) {
+	data, err := JSONSchema()
+	require.NoError(t, err)
+
+	var schema struct {
+		Properties map[string]struct {
+			Type                 string      `json:"type"`
+			Properties           interface{} `json:"properties"`
+			AdditionalProperties interface{} `json:"additionalProperties"`
+		} `json:"properties"`
+		AdditionalProperties bool     `json:"additionalProperties"`
+		Required             []string `json:"required"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	require.False(t, schema.AdditionalProperties)
+	require.Equal(t, []string{"accounts"}, schema.Required)
+	require.Equal(t, "array", schema.Properties["accounts"].Type)
+	require.Equal(t, "integer", schema.Properties["version"].Type)
+	require.Equal(t, "object", schema.Properties["profiles"].Type)
+	require.Equal(t, "object", schema.Properties["genesis"].Type)
+	require.Nil(t, schema.Properties["genesis"].Properties)
+	require.Equal(t, false, schema.Properties["faucet"].AdditionalProperties)
+}
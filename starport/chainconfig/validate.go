@@ -0,0 +1,174 @@
+package chainconfig
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tendermint/starport/starport/pkg/cosmoscoin"
+)
+
+// validateAccounts checks that the accounts have unique names and valid coins.
+func validateAccounts(conf Config) error {
+	names := make(map[string]bool)
+	for i, account := range conf.Accounts {
+		if account.Name == "" {
+			return &ValidationError{fmt.Sprintf("accounts[%d]: name is required", i)}
+		}
+		if names[account.Name] {
+			return &ValidationError{fmt.Sprintf("account %q is defined more than once", account.Name)}
+		}
+		names[account.Name] = true
+
+		if err := validateCoins(fmt.Sprintf("accounts[%d].coins", i), account.Coins); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateStakes checks that the validators stake valid coins that their accounts can afford.
+func validateStakes(conf Config) error {
+	for _, validator := range conf.ListValidators() {
+		if validator.Staked == "" {
+			continue
+		}
+
+		staked, denom, err := cosmoscoin.Parse(validator.Staked)
+		if err != nil {
+			return &ValidationError{fmt.Sprintf("validator %q: invalid staked coin %q", validator.Name, validator.Staked)}
+		}
+
+		account, ok := conf.AccountByName(validator.Name)
+		if !ok || account.Address != "" {
+			// the balance of the validator is not known.
+			continue
+		}
+
+		var balance uint64
+		for _, coin := range account.Coins {
+			amount, coinDenom, _ := cosmoscoin.Parse(coin)
+			if coinDenom == denom {
+				balance += amount
+			}
+		}
+		if staked > balance {
+			return &ValidationError{fmt.Sprintf(
+				"validator %q stakes %s but its account only has %d%s",
+				validator.Name,
+				validator.Staked,
+				balance,
+				denom,
+			)}
+		}
+	}
+	return nil
+}
+
+// validateFaucet checks the coins and the durations of the faucet.
+func validateFaucet(conf Config) error {
+	if err := validateCoins("faucet.coins", conf.Faucet.Coins); err != nil {
+		return err
+	}
+	if err := validateCoins("faucet.coins_max", conf.Faucet.CoinsMax); err != nil {
+		return err
+	}
+
+	durations := []struct {
+		key, value string
+	}{
+		{"faucet.rate_limit_window", conf.Faucet.RateLimitWindow},
+		{"faucet.batch_window", conf.Faucet.BatchWindow},
+		{"faucet.ip_rate_limit_window", conf.Faucet.IPRateLimitWindow},
+	}
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(d.value); err != nil {
+			return &ValidationError{fmt.Sprintf("%s: invalid duration %q, use a duration like 1h or 30s", d.key, d.value)}
+		}
+	}
+
+	if conf.Faucet.Host != "" {
+		return validateAddress("faucet.host", conf.Faucet.Host)
+	}
+	return nil
+}
+
+// validateHost checks that the addresses of the servers are valid.
+func validateHost(conf Config) error {
+	addresses := []struct {
+		key, value string
+	}{
+		{"host.rpc", conf.Host.RPC},
+		{"host.p2p", conf.Host.P2P},
+		{"host.prof", conf.Host.Prof},
+		{"host.grpc", conf.Host.GRPC},
+		{"host.grpc-web", conf.Host.GRPCWeb},
+		{"host.api", conf.Host.API},
+	}
+	for _, a := range addresses {
+		if a.value == "" {
+			continue
+		}
+		if err := validateAddress(a.key, a.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateBuild checks the build tags and the names of the build environment variables.
+func validateBuild(conf Config) error {
+	for i, tag := range conf.Build.Tags {
+		if tag == "" || strings.ContainsAny(tag, ", \t") {
+			return &ValidationError{fmt.Sprintf("build.tags[%d]: invalid build tag %q, use one tag per item", i, tag)}
+		}
+	}
+	for name := range conf.Build.Env {
+		if name == "" || strings.ContainsAny(name, "= \t") {
+			return &ValidationError{fmt.Sprintf("build.env: invalid environment variable name %q", name)}
+		}
+	}
+	for i, hook := range conf.Build.PreBuild {
+		if strings.TrimSpace(hook) == "" {
+			return &ValidationError{fmt.Sprintf("build.pre_build[%d]: command is empty", i)}
+		}
+	}
+	for i, hook := range conf.Build.PostBuild {
+		if strings.TrimSpace(hook) == "" {
+			return &ValidationError{fmt.Sprintf("build.post_build[%d]: command is empty", i)}
+		}
+	}
+	return nil
+}
+
+// validateCoins checks that coins are valid coin strings like 100token.
+func validateCoins(key string, coins []string) error {
+	for i, coin := range coins {
+		if _, _, err := cosmoscoin.Parse(coin); err != nil {
+			return &ValidationError{fmt.Sprintf("%s[%d]: invalid coin %q, use an amount followed by a denom like 100token", key, i, coin)}
+		}
+	}
+	return nil
+}
+
+// validateAddress checks that address is a host:port address, optionally prefixed with a scheme.
+func validateAddress(key, address string) error {
+	hostPort := address
+	if i := strings.Index(hostPort, "://"); i >= 0 {
+		hostPort = hostPort[i+3:]
+	}
+
+	_, port, err := net.SplitHostPort(hostPort)
+	if err == nil {
+		_, err = strconv.ParseUint(port, 10, 16)
+	}
+	if err != nil {
+		return &ValidationError{fmt.Sprintf("%s: invalid address %q, use an address like 0.0.0.0:26657", key, address)}
+	}
+	return nil
+}
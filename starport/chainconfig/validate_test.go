@@ -0,0 +1,151 @@
+package chainconfig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSemanticValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		confyml string
+		err     error
+	}{
+		{
+			name: "invalid account coin",
+			confyml: `
+accounts:
+  - name: me
+    coins: ["token1000", "100stake"]
+validator:
+  name: me
+  staked: "100stake"
+`,
+			err: &ValidationError{`accounts[0].coins[0]: invalid coin "token1000", use an amount followed by a denom like 100token`},
+		},
+		{
+			name: "duplicated account",
+			confyml: `
+accounts:
+  - name: me
+  - name: me
+validator:
+  name: me
+`,
+			err: &ValidationError{`account "me" is defined more than once`},
+		},
+		{
+			name: "stake over balance",
+			confyml: `
+accounts:
+  - name: me
+    coins: ["1000token", "100stake"]
+validator:
+  name: me
+  staked: "200stake"
+`,
+			err: &ValidationError{`validator "me" stakes 200stake but its account only has 100stake`},
+		},
+		{
+			name: "invalid faucet coin",
+			confyml: `
+accounts:
+  - name: me
+validator:
+  name: me
+faucet:
+  coins_max: ["token"]
+`,
+			err: &ValidationError{`faucet.coins_max[0]: invalid coin "token", use an amount followed by a denom like 100token`},
+		},
+		{
+			name: "invalid faucet window",
+			confyml: `
+accounts:
+  - name: me
+validator:
+  name: me
+faucet:
+  batch_window: "2"
+`,
+			err: &ValidationError{`faucet.batch_window: invalid duration "2", use a duration like 1h or 30s`},
+		},
+		{
+			name: "invalid host address",
+			confyml: `
+accounts:
+  - name: me
+validator:
+  name: me
+host:
+  rpc: "localhost"
+`,
+			err: &ValidationError{`host.rpc: invalid address "localhost", use an address like 0.0.0.0:26657`},
+		},
+		{
+			name: "invalid build tag",
+			confyml: `
+accounts:
+  - name: me
+validator:
+  name: me
+build:
+  tags: ["ledger cleveldb"]
+`,
+			err: &ValidationError{`build.tags[0]: invalid build tag "ledger cleveldb", use one tag per item`},
+		},
+		{
+			name: "empty build hook",
+			confyml: `
+accounts:
+  - name: me
+validator:
+  name: me
+build:
+  post_build:
+    - make docs
+    - ""
+`,
+			err: &ValidationError{`build.post_build[1]: command is empty`},
+		},
+		{
+			name: "valid",
+			confyml: `
+accounts:
+  - name: me
+    coins: ["1000token", "100stake"]
+  - name: you
+    address: cosmos1uzv4v9g9xln2qx2vtqhz99yxum33calja5vruz
+validator:
+  name: me
+  staked: "100stake"
+faucet:
+  name: me
+  coins: ["5token"]
+  rate_limit_window: "1h"
+host:
+  rpc: "tcp://0.0.0.0:26657"
+  api: ":1317"
+build:
+  ldflags: ["-X main.Version=1.0.0"]
+  tags: ["ledger", "cleveldb"]
+  env:
+    CGO_ENABLED: 1
+  pre_build: ["make proto"]
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.confyml))
+			if tt.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -38,12 +38,27 @@ Starport uses accounts to interact with the Starport Network blockchain, use an
 	return c
 }
 
+// accountResult is the JSON output of an account.
+type accountResult struct {
+	Name      string `json:"name"`
+	Address   string `json:"address"`
+	PublicKey string `json:"public_key"`
+}
+
 func printAccounts(cmd *cobra.Command, accounts ...cosmosaccount.Account) error {
-	var accEntries [][]string
+	var (
+		accEntries [][]string
+		result     = make([]accountResult, 0, len(accounts))
+	)
 	for _, acc := range accounts {
-		accEntries = append(accEntries, []string{acc.Name, acc.Address(getAddressPrefix(cmd)), acc.PubKey()})
+		address := acc.Address(getAddressPrefix(cmd))
+		accEntries = append(accEntries, []string{acc.Name, address, acc.PubKey()})
+		result = append(result, accountResult{acc.Name, address, acc.PubKey()})
 	}
-	return entrywriter.MustWrite(os.Stdout, []string{"name", "address", "public key"}, accEntries...)
+
+	return printResult(cmd, result, func() error {
+		return entrywriter.MustWrite(os.Stdout, []string{"name", "address", "public key"}, accEntries...)
+	})
 }
 
 func flagSetKeyringBackend() *flag.FlagSet {
@@ -15,7 +15,7 @@ func NewAccountList() *cobra.Command {
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
 	c.Flags().AddFlagSet(flagSetAccountPrefixes())
 
-	return c
+	return withJSONOutput(c)
 }
 
 func accountListHandler(cmd *cobra.Command, args []string) error {
@@ -16,7 +16,7 @@ func NewAccountShow() *cobra.Command {
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
 	c.Flags().AddFlagSet(flagSetAccountPrefixes())
 
-	return c
+	return withJSONOutput(c)
 }
 
 func accountShowHandler(cmd *cobra.Command, args []string) error {
@@ -18,6 +18,8 @@ func NewChain() *cobra.Command {
 	c.AddCommand(NewChainBuild())
 	c.AddCommand(NewChainInit())
 	c.AddCommand(NewChainFaucet())
+	c.AddCommand(NewChainSnapshot())
+	c.AddCommand(NewChainConfig())
 
 	return c
 }
@@ -2,6 +2,7 @@ package starportcmd
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
@@ -10,10 +11,12 @@ import (
 )
 
 const (
-	flagOutput         = "output"
+	flagOutputDir      = "output-dir"
 	flagRelease        = "release"
 	flagReleaseTargets = "release.targets"
 	flagReleasePrefix  = "release.prefix"
+	flagReleaseSignKey = "release.signing-key"
+	flagDocker         = "docker"
 )
 
 // NewChainBuild returns a new build command to build a blockchain app.
@@ -29,11 +32,26 @@ for one or more specified release targets are built in a release/ dir under the
 source. Specify the release targets with GOOS:GOARCH build tags.
 If the optional --release.targets is not specified, a binary is created for your current environment.
 
+Release builds are reproducible. The release dir contains a manifest.json that lists the targets,
+the Go version and the dependencies of the app, and a checksum.txt of the release files. To sign
+checksum.txt, provide an ed25519 private key in PEM format with --release.signing-key.
+Set SOURCE_DATE_EPOCH to choose the modification time of the files inside the tarballs.
+
+The binary output path is set with --output-dir, --output is still accepted for a path but
+it's deprecated since it's the output format of all the commands.
+
+To run nodes in containers, use --docker to also create an OCI image tarball for each
+linux target. Images are assembled without a Docker daemon, load them with a tool like podman or skopeo.
+
 Sample usages:
 	- starport chain build
-	- starport chain build --release -t linux:amd64 -t darwin:amd64 -t darwin:arm64`,
+	- starport chain build --release -t linux:amd64 -t darwin:amd64 -t darwin:arm64
+	- starport chain build --release --release.signing-key release.pem
+	- starport chain build --release --docker -t linux:amd64 -t linux:arm64`,
 		Args: cobra.ExactArgs(0),
 		RunE: chainBuildHandler,
+		// --output was the output path before being the output format.
+		Annotations: map[string]string{annotationOutputPath: flagOutputDir, annotationJSONOutput: outputFormatJSON},
 	}
 
 	c.Flags().AddFlagSet(flagSetHome())
@@ -41,7 +59,9 @@ Sample usages:
 	c.Flags().Bool(flagRelease, false, "build for a release")
 	c.Flags().StringSliceP(flagReleaseTargets, "t", []string{}, "release targets. Available only with --release flag")
 	c.Flags().String(flagReleasePrefix, "", "tarball prefix for each release target. Available only with --release flag")
-	c.Flags().StringP(flagOutput, "o", "", "binary output path")
+	c.Flags().String(flagReleaseSignKey, "", "path to an ed25519 private key in PEM format to sign the checksum file. Available only with --release flag")
+	c.Flags().Bool(flagDocker, false, "build an OCI image for each linux target. Available only with --release flag")
+	c.Flags().StringP(flagOutputDir, "o", "", "binary output path")
 	c.Flags().BoolP("verbose", "v", false, "Verbose output")
 
 	return c
@@ -52,14 +72,30 @@ func chainBuildHandler(cmd *cobra.Command, args []string) error {
 		isRelease, _      = cmd.Flags().GetBool(flagRelease)
 		releaseTargets, _ = cmd.Flags().GetStringSlice(flagReleaseTargets)
 		releasePrefix, _  = cmd.Flags().GetString(flagReleasePrefix)
-		output, _         = cmd.Flags().GetString(flagOutput)
+		signingKey, _     = cmd.Flags().GetString(flagReleaseSignKey)
+		dockerImage, _    = cmd.Flags().GetBool(flagDocker)
+		output, _         = cmd.Flags().GetString(flagOutputDir)
 	)
 
+	out := newCLIOutput(cmd)
+	defer out.Stop()
+
+	if path := flagGetDeprecatedOutputPath(cmd); path != "" {
+		fmt.Fprintf(os.Stderr, "Flag --output has been deprecated for paths, use --%s instead\n", flagOutputDir)
+		if output == "" {
+			output = path
+		}
+	}
+
 	chainOption := []chain.Option{
 		chain.LogLevel(logLevel(cmd)),
 		chain.KeyringBackend(chaincmd.KeyringBackendTest),
 	}
 
+	if out.json {
+		chainOption = append(chainOption, chain.CollectEvents(out.Events()))
+	}
+
 	if flagGetProto3rdParty(cmd) {
 		chainOption = append(chainOption, chain.EnableThirdPartyModuleCodegen())
 	}
@@ -70,14 +106,23 @@ func chainBuildHandler(cmd *cobra.Command, args []string) error {
 	}
 
 	if isRelease {
-		releasePath, err := c.BuildRelease(cmd.Context(), output, releasePrefix, releaseTargets...)
+		var releaseOptions []chain.ReleaseOption
+		if signingKey != "" {
+			releaseOptions = append(releaseOptions, chain.SigningKey(signingKey))
+		}
+		if dockerImage {
+			releaseOptions = append(releaseOptions, chain.DockerImage())
+		}
+
+		releasePath, err := c.BuildRelease(cmd.Context(), output, releasePrefix, releaseTargets, releaseOptions...)
 		if err != nil {
 			return err
 		}
 
-		fmt.Printf("🗃  Release created: %s\n", infoColor(releasePath))
-
-		return nil
+		return out.Result(chainBuildResult{Release: releasePath}, func() error {
+			fmt.Printf("🗃  Release created: %s\n", infoColor(releasePath))
+			return nil
+		})
 	}
 
 	binaryName, err := c.Build(cmd.Context(), output)
@@ -85,12 +130,29 @@ func chainBuildHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if output == "" {
-		fmt.Printf("🗃  Installed. Use with: %s\n", infoColor(binaryName))
-	} else {
-		binaryPath := filepath.Join(output, binaryName)
-		fmt.Printf("🗃  Binary built at the path: %s\n", infoColor(binaryPath))
+	result := chainBuildResult{Binary: binaryName}
+	if output != "" {
+		result.Path = filepath.Join(output, binaryName)
 	}
 
-	return nil
+	return out.Result(result, func() error {
+		if output == "" {
+			fmt.Printf("🗃  Installed. Use with: %s\n", infoColor(binaryName))
+		} else {
+			fmt.Printf("🗃  Binary built at the path: %s\n", infoColor(result.Path))
+		}
+		return nil
+	})
+}
+
+// chainBuildResult is the JSON output of the build command.
+type chainBuildResult struct {
+	// Binary is the name of the installed binary.
+	Binary string `json:"binary,omitempty"`
+
+	// Path is the path of the binary when it's built in an output dir.
+	Path string `json:"path,omitempty"`
+
+	// Release is the path of the release dir.
+	Release string `json:"release,omitempty"`
 }
@@ -0,0 +1,17 @@
+package starportcmd
+
+import "github.com/spf13/cobra"
+
+// NewChainConfig creates a new config command that groups sub commands to manage config.yml.
+func NewChainConfig() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "config [command]",
+		Short: "Manage the config file of the blockchain",
+		Args:  cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(NewChainConfigMigrate())
+	c.AddCommand(NewChainConfigSchema())
+
+	return c
+}
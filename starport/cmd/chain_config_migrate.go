@@ -0,0 +1,71 @@
+package starportcmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/tendermint/starport/starport/chainconfig"
+)
+
+// NewChainConfigMigrate creates a new command to migrate config.yml to the latest version.
+func NewChainConfigMigrate() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the config file to the latest version",
+		Long: `Migrate the config file to the latest version.
+
+The config file is upgraded step by step from its version to the latest one and rewritten in place.
+Comments and formatting of the file are preserved.`,
+		Args: cobra.NoArgs,
+		RunE: chainConfigMigrateHandler,
+	}
+
+	c.Flags().StringP(flagConfig, "c", "", "Starport config file (default: ./config.yml)")
+
+	return c
+}
+
+func chainConfigMigrateHandler(cmd *cobra.Command, args []string) error {
+	path, err := cmd.Flags().GetString(flagConfig)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		appPath, err := filepath.Abs(flagGetPath(cmd))
+		if err != nil {
+			return err
+		}
+		if path, err = chainconfig.LocateDefault(appPath); err != nil {
+			return err
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	migrated, from, err := chainconfig.Migrate(data)
+	if err != nil {
+		return err
+	}
+
+	if from == chainconfig.LatestVersion {
+		fmt.Printf("%s is already at the latest version (v%d).\n", path, chainconfig.LatestVersion)
+		return nil
+	}
+
+	if err := os.WriteFile(path, migrated, info.Mode()); err != nil {
+		return err
+	}
+
+	fmt.Printf("📝 %s migrated from v%d to v%d.\n", path, from, chainconfig.LatestVersion)
+	return nil
+}
@@ -0,0 +1,56 @@
+package starportcmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tendermint/starport/starport/chainconfig"
+)
+
+const flagOutputFile = "output-file"
+
+// NewChainConfigSchema creates a new command to generate the JSON Schema of config.yml.
+func NewChainConfigSchema() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "schema",
+		Short: "Generate the JSON Schema of the config file",
+		Long: `Generate the JSON Schema of the config file.
+
+Editors can use the schema to validate and autocomplete config.yml. For example, with the YAML extension
+of VS Code, save the schema to a file and add the following comment at the top of config.yml:
+
+  # yaml-language-server: $schema=./config.schema.json`,
+		Args: cobra.NoArgs,
+		RunE: chainConfigSchemaHandler,
+	}
+
+	c.Flags().StringP(flagOutputFile, "o", "", "File to write the schema to (default: stdout)")
+
+	return c
+}
+
+func chainConfigSchemaHandler(cmd *cobra.Command, args []string) error {
+	output, err := cmd.Flags().GetString(flagOutputFile)
+	if err != nil {
+		return err
+	}
+
+	schema, err := chainconfig.JSONSchema()
+	if err != nil {
+		return err
+	}
+	schema = append(schema, '\n')
+
+	if output == "" {
+		_, err := os.Stdout.Write(schema)
+		return err
+	}
+
+	if err := os.WriteFile(output, schema, 0644); err != nil {
+		return err
+	}
+
+	fmt.Printf("📝 JSON Schema of the config file written to %s.\n", output)
+	return nil
+}
@@ -6,9 +6,12 @@ import (
 )
 
 const (
-	flagForceReset = "force-reset"
-	flagResetOnce  = "reset-once"
-	flagConfig     = "config"
+	flagForceReset    = "force-reset"
+	flagResetOnce     = "reset-once"
+	flagConfig        = "config"
+	flagProfile       = "profile"
+	flagEventsHost    = "events-host"
+	flagDashboardHost = "dashboard-host"
 )
 
 // NewChainServe creates a new serve command to serve a blockchain.
@@ -27,6 +30,9 @@ func NewChainServe() *cobra.Command {
 	c.Flags().BoolP(flagForceReset, "f", false, "Force reset of the app state on start and every source change")
 	c.Flags().BoolP(flagResetOnce, "r", false, "Reset of the app state on first start")
 	c.Flags().StringP(flagConfig, "c", "", "Starport config file (default: ./config.yml)")
+	c.Flags().String(flagProfile, "", "Profile of the config file to merge over the base config")
+	c.Flags().String(flagDashboardHost, "", "Host to serve the dashboard of the chain at (e.g. localhost:4502)")
+	c.Flags().String(flagEventsHost, "", "Host to stream the lifecycle events of the chain as server-sent events (e.g. localhost:4501)")
 
 	return c
 }
@@ -48,6 +54,13 @@ func chainServeHandler(cmd *cobra.Command, args []string) error {
 	if config != "" {
 		chainOption = append(chainOption, chain.ConfigFile(config))
 	}
+	profile, err := cmd.Flags().GetString(flagProfile)
+	if err != nil {
+		return err
+	}
+	if profile != "" {
+		chainOption = append(chainOption, chain.ConfigProfile(profile))
+	}
 
 	// create the chain
 	c, err := newChainWithHomeFlags(cmd, chainOption...)
@@ -72,5 +85,21 @@ func chainServeHandler(cmd *cobra.Command, args []string) error {
 		serveOptions = append(serveOptions, chain.ServeResetOnce())
 	}
 
+	eventsHost, err := cmd.Flags().GetString(flagEventsHost)
+	if err != nil {
+		return err
+	}
+	if eventsHost != "" {
+		serveOptions = append(serveOptions, chain.ServeEvents(eventsHost))
+	}
+
+	dashboardHost, err := cmd.Flags().GetString(flagDashboardHost)
+	if err != nil {
+		return err
+	}
+	if dashboardHost != "" {
+		serveOptions = append(serveOptions, chain.ServeDashboard(dashboardHost))
+	}
+
 	return c.Serve(cmd.Context(), serveOptions...)
 }
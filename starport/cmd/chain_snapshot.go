@@ -0,0 +1,61 @@
+package starportcmd
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/tendermint/starport/starport/services/chain"
+)
+
+// NewChainSnapshot creates a new snapshot command that groups sub commands to manage
+// named snapshots of the chain state.
+func NewChainSnapshot() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "snapshot [command]",
+		Short: "Save and restore named snapshots of the chain state",
+		Long: `Save and restore named snapshots of the chain state.
+
+A snapshot is a copy of the exported genesis of the chain along with the checksum of the config
+it was created with. Snapshots are saved in the chain's directory under ~/.starport/local-chains.
+The chain must not be running while saving or restoring a snapshot.`,
+		Args: cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(NewChainSnapshotSave())
+	c.AddCommand(NewChainSnapshotList())
+	c.AddCommand(NewChainSnapshotRestore())
+	c.AddCommand(NewChainSnapshotDelete())
+
+	return c
+}
+
+func flagSetSnapshotChain() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.AddFlagSet(flagSetHome())
+	fs.StringP(flagConfig, "c", "", "Starport config file (default: ./config.yml)")
+	fs.String(flagProfile, "", "Profile of the config file to merge over the base config")
+	return fs
+}
+
+// newSnapshotChain creates the chain managed by the snapshot commands.
+func newSnapshotChain(cmd *cobra.Command) (*chain.Chain, error) {
+	chainOption := []chain.Option{
+		chain.LogLevel(logLevel(cmd)),
+	}
+
+	config, err := cmd.Flags().GetString(flagConfig)
+	if err != nil {
+		return nil, err
+	}
+	if config != "" {
+		chainOption = append(chainOption, chain.ConfigFile(config))
+	}
+	profile, err := cmd.Flags().GetString(flagProfile)
+	if err != nil {
+		return nil, err
+	}
+	if profile != "" {
+		chainOption = append(chainOption, chain.ConfigProfile(profile))
+	}
+
+	return newChainWithHomeFlags(cmd, chainOption...)
+}
@@ -0,0 +1,37 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// NewChainSnapshotDelete creates a new command to delete a named snapshot of the chain.
+func NewChainSnapshotDelete() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "delete [name]",
+		Short: "Delete a snapshot by name",
+		Args:  cobra.ExactArgs(1),
+		RunE:  chainSnapshotDeleteHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetSnapshotChain())
+
+	return c
+}
+
+func chainSnapshotDeleteHandler(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	c, err := newSnapshotChain(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := c.DeleteSnapshot(name); err != nil {
+		return err
+	}
+
+	fmt.Printf("Snapshot %s deleted.\n", name)
+	return nil
+}
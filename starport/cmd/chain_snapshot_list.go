@@ -0,0 +1,42 @@
+package starportcmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tendermint/starport/starport/pkg/entrywriter"
+)
+
+// NewChainSnapshotList creates a new command to list the snapshots of the chain.
+func NewChainSnapshotList() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "list",
+		Short: "Show a list of all snapshots of the chain",
+		Args:  cobra.NoArgs,
+		RunE:  chainSnapshotListHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetSnapshotChain())
+
+	return c
+}
+
+func chainSnapshotListHandler(cmd *cobra.Command, args []string) error {
+	c, err := newSnapshotChain(cmd)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := c.Snapshots()
+	if err != nil {
+		return err
+	}
+
+	var entries [][]string
+	for _, s := range snapshots {
+		entries = append(entries, []string{s.Name, s.CreatedAt.Format(time.RFC3339), s.Version})
+	}
+
+	return entrywriter.MustWrite(os.Stdout, []string{"name", "created at", "version"}, entries...)
+}
@@ -0,0 +1,50 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const flagForce = "force"
+
+// NewChainSnapshotRestore creates a new command to restore the chain state from a named snapshot.
+func NewChainSnapshotRestore() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "restore [name]",
+		Short: "Restore the chain state from a named snapshot",
+		Long: `Restore the chain state from a named snapshot.
+
+The node database is reset and the state saved in the snapshot is imported, the next serve starts from it.
+The snapshot is not restored when config.yml changed since the snapshot was saved unless --force is used.`,
+		Args: cobra.ExactArgs(1),
+		RunE: chainSnapshotRestoreHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetSnapshotChain())
+	c.Flags().Bool(flagForce, false, "Restore the snapshot even if the config changed since it was saved")
+	c.Flags().BoolP("verbose", "v", false, "Verbose output")
+
+	return c
+}
+
+func chainSnapshotRestoreHandler(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	force, err := cmd.Flags().GetBool(flagForce)
+	if err != nil {
+		return err
+	}
+
+	c, err := newSnapshotChain(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := c.RestoreSnapshot(cmd.Context(), name, force); err != nil {
+		return err
+	}
+
+	fmt.Printf("🔄 Snapshot %s restored.\n", name)
+	return nil
+}
@@ -0,0 +1,46 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const flagOverwrite = "overwrite"
+
+// NewChainSnapshotSave creates a new command to save the chain state as a named snapshot.
+func NewChainSnapshotSave() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "save [name]",
+		Short: "Save the current chain state as a named snapshot",
+		Args:  cobra.ExactArgs(1),
+		RunE:  chainSnapshotSaveHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetSnapshotChain())
+	c.Flags().Bool(flagOverwrite, false, "Overwrite the snapshot if it already exists")
+	c.Flags().BoolP("verbose", "v", false, "Verbose output")
+
+	return c
+}
+
+func chainSnapshotSaveHandler(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	overwrite, err := cmd.Flags().GetBool(flagOverwrite)
+	if err != nil {
+		return err
+	}
+
+	c, err := newSnapshotChain(cmd)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.SaveSnapshot(cmd.Context(), name, overwrite); err != nil {
+		return err
+	}
+
+	fmt.Printf("📸 Snapshot %s saved.\n", name)
+	return nil
+}
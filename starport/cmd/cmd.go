@@ -40,8 +40,6 @@ var infoColor = color.New(color.FgYellow).SprintFunc()
 func New(ctx context.Context) *cobra.Command {
 	cobra.EnableCommandSorting = false
 
-	checkNewVersion(ctx)
-
 	c := &cobra.Command{
 		Use:   "starport",
 		Short: "Starport offers everything you need to scaffold, test, build, and launch your blockchain",
@@ -55,10 +53,21 @@ starport scaffold chain github.com/cosmonaut/mars`,
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkOutputFormat(cmd); err != nil {
+				return err
+			}
+
+			// the notice would break the JSON output.
+			if !isJSONOutput(cmd) {
+				checkNewVersion(ctx)
+			}
+
 			return goenv.ConfigurePath()
 		},
 	}
 
+	c.PersistentFlags().AddFlagSet(flagSetOutputFormat())
+
 	c.AddCommand(NewScaffold())
 	c.AddCommand(NewChain())
 	c.AddCommand(NewGenerate())
@@ -74,6 +83,11 @@ starport scaffold chain github.com/cosmonaut/mars`,
 }
 
 func logLevel(cmd *cobra.Command) chain.LogLvl {
+	// the logs of the commands would be mixed with the JSON output.
+	if isJSONOutput(cmd) {
+		return chain.LogRegular
+	}
+
 	verbose, _ := cmd.Flags().GetBool("verbose")
 	if verbose {
 		return chain.LogVerbose
@@ -167,8 +181,10 @@ func newChainWithHomeFlags(cmd *cobra.Command, chainOption ...chain.Option) (*ch
 var (
 	modifyPrefix = color.New(color.FgMagenta).SprintFunc()("modify ")
 	createPrefix = color.New(color.FgGreen).SprintFunc()("create ")
+	deletePrefix = color.New(color.FgRed).SprintFunc()("delete ")
 	removePrefix = func(s string) string {
-		return strings.TrimPrefix(strings.TrimPrefix(s, modifyPrefix), createPrefix)
+		s = strings.TrimPrefix(strings.TrimPrefix(s, modifyPrefix), createPrefix)
+		return strings.TrimPrefix(s, deletePrefix)
 	}
 )
 
@@ -191,6 +207,14 @@ func sourceModificationToString(sm xgenny.SourceModification) (string, error) {
 		}
 		files = append(files, createPrefix+relativePath)
 	}
+	for _, deleted := range sm.DeletedFiles() {
+		// get the relative app path from the current directory
+		relativePath, err := relativePath(deleted)
+		if err != nil {
+			return "", err
+		}
+		files = append(files, deletePrefix+relativePath)
+	}
 
 	// sort filenames without prefix
 	sort.Slice(files, func(i, j int) bool {
@@ -203,6 +227,50 @@ func sourceModificationToString(sm xgenny.SourceModification) (string, error) {
 	return "\n" + strings.Join(files, "\n"), nil
 }
 
+// scaffoldResult is the JSON output of the scaffold commands.
+type scaffoldResult struct {
+	CreatedFiles  []string `json:"created_files"`
+	ModifiedFiles []string `json:"modified_files"`
+	DeletedFiles  []string `json:"deleted_files,omitempty"`
+
+	// Diff is the unified diff of the files with --dry-run.
+	Diff string `json:"diff,omitempty"`
+}
+
+// newScaffoldResult returns the files modified by a scaffold command relative to the current directory.
+func newScaffoldResult(sm xgenny.SourceModification) (scaffoldResult, error) {
+	result := scaffoldResult{
+		CreatedFiles:  []string{},
+		ModifiedFiles: []string{},
+	}
+	for _, created := range sm.CreatedFiles() {
+		path, err := relativePath(created)
+		if err != nil {
+			return scaffoldResult{}, err
+		}
+		result.CreatedFiles = append(result.CreatedFiles, path)
+	}
+	for _, modified := range sm.ModifiedFiles() {
+		path, err := relativePath(modified)
+		if err != nil {
+			return scaffoldResult{}, err
+		}
+		result.ModifiedFiles = append(result.ModifiedFiles, path)
+	}
+	for _, deleted := range sm.DeletedFiles() {
+		path, err := relativePath(deleted)
+		if err != nil {
+			return scaffoldResult{}, err
+		}
+		result.DeletedFiles = append(result.DeletedFiles, path)
+	}
+	sort.Strings(result.CreatedFiles)
+	sort.Strings(result.ModifiedFiles)
+	sort.Strings(result.DeletedFiles)
+
+	return result, nil
+}
+
 func deprecated() []*cobra.Command {
 	return []*cobra.Command{
 		{
@@ -260,9 +328,14 @@ func checkNewVersion(ctx context.Context) {
 `, next)
 }
 
-// newApp create a new scaffold app
-func newApp(appPath string) (scaffolder.Scaffolder, error) {
-	sc, err := scaffolder.App(appPath)
+// newApp create a new scaffold app for the scaffold command cmd
+func newApp(cmd *cobra.Command, appPath string) (scaffolder.Scaffolder, error) {
+	options := []scaffolder.Option{scaffolder.WithCommand(scaffoldCommand(cmd))}
+	if flagGetDryRun(cmd) {
+		options = append(options, scaffolder.WithDryRun())
+	}
+
+	sc, err := scaffolder.App(appPath, options...)
 	if err != nil {
 		return sc, err
 	}
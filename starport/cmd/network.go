@@ -81,14 +81,18 @@ func newNetworkBuilder(cmd *cobra.Command) (NetworkBuilder, error) {
 	var err error
 
 	n := NetworkBuilder{
-		Spinner: clispinner.New(),
+		Spinner: newSpinner(cmd),
 		ev:      events.NewBus(),
 		wg:      &sync.WaitGroup{},
 		cmd:     cmd,
 	}
 
 	n.wg.Add(1)
-	go printEvents(n.wg, n.ev, n.Spinner)
+	if isJSONOutput(cmd) {
+		go printJSONEvents(n.wg, n.ev)
+	} else {
+		go printEvents(n.wg, n.ev, n.Spinner)
+	}
 
 	if n.cc, err = getNetworkCosmosClient(cmd); err != nil {
 		n.Cleanup()
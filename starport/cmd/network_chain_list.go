@@ -33,7 +33,7 @@ func NewNetworkChainList() *cobra.Command {
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
 	c.Flags().AddFlagSet(flagSetHome())
 
-	return c
+	return withJSONOutput(c)
 }
 
 func networkChainListHandler(cmd *cobra.Command, args []string) error {
@@ -53,7 +53,28 @@ func networkChainListHandler(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	return renderLaunchSummaries(chainLaunches, os.Stdout)
+
+	result := make([]launchSummaryResult, 0, len(chainLaunches))
+	for _, c := range chainLaunches {
+		result = append(result, launchSummaryResult{
+			LaunchID:   c.ID,
+			ChainID:    c.ChainID,
+			Source:     c.SourceURL,
+			CampaignID: c.CampaignID,
+		})
+	}
+
+	return printResult(cmd, result, func() error {
+		return renderLaunchSummaries(chainLaunches, os.Stdout)
+	})
+}
+
+// launchSummaryResult is the JSON output of a chain launch.
+type launchSummaryResult struct {
+	LaunchID   uint64 `json:"launch_id"`
+	ChainID    string `json:"chain_id"`
+	Source     string `json:"source"`
+	CampaignID uint64 `json:"campaign_id"`
 }
 
 // renderLaunchSummaries writes into the provided out, the list of summarized launches
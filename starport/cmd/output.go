@@ -0,0 +1,204 @@
+package starportcmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/tendermint/starport/starport/pkg/clispinner"
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/pkg/validation"
+)
+
+const (
+	flagOutputFormat = "output"
+
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+// jsonMessage is a line of the JSON output, it holds either an event, the result
+// or the error of a command.
+type jsonMessage struct {
+	Type   string        `json:"type"`
+	Event  *events.Event `json:"event,omitempty"`
+	Result interface{}   `json:"result,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+func flagSetOutputFormat() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.String(flagOutputFormat, outputFormatText, "Output format (text|json)")
+	return fs
+}
+
+// annotationOutputPath annotates the commands that had an --output flag for a path before
+// it became the output format, an unknown format is the path of the deprecated flag. The
+// value is the flag replacing it.
+const annotationOutputPath = "output-path"
+
+// annotationJSONOutput annotates the commands that print their output as JSON with --output json.
+const annotationJSONOutput = "json-output"
+
+// withJSONOutput marks c as a command supporting the JSON output.
+func withJSONOutput(c *cobra.Command) *cobra.Command {
+	if c.Annotations == nil {
+		c.Annotations = make(map[string]string)
+	}
+	c.Annotations[annotationJSONOutput] = outputFormatJSON
+	return c
+}
+
+// checkOutputFormat checks if the output format is a known one supported by cmd.
+func checkOutputFormat(cmd *cobra.Command) error {
+	format, _ := cmd.Flags().GetString(flagOutputFormat)
+	switch format {
+	case outputFormatText:
+		return nil
+	case outputFormatJSON:
+		if _, ok := cmd.Annotations[annotationJSONOutput]; !ok {
+			return fmt.Errorf("%q doesn't support the %s output format", cmd.CommandPath(), outputFormatJSON)
+		}
+		return nil
+	default:
+		if _, ok := cmd.Annotations[annotationOutputPath]; ok {
+			return nil
+		}
+		return fmt.Errorf("unknown output format %q, use %s or %s", format, outputFormatText, outputFormatJSON)
+	}
+}
+
+// flagGetDeprecatedOutputPath returns the path given to cmd with the deprecated --output
+// flag, if the output flag isn't an output format.
+func flagGetDeprecatedOutputPath(cmd *cobra.Command) string {
+	format, _ := cmd.Flags().GetString(flagOutputFormat)
+	switch format {
+	case outputFormatText, outputFormatJSON:
+		return ""
+	default:
+		return format
+	}
+}
+
+func isJSONOutput(cmd *cobra.Command) bool {
+	format, _ := cmd.Flags().GetString(flagOutputFormat)
+	return format == outputFormatJSON
+}
+
+// newSpinner creates a new spinner for cmd, the spinner is hidden with the JSON output.
+func newSpinner(cmd *cobra.Command) *clispinner.Spinner {
+	if isJSONOutput(cmd) {
+		return clispinner.New(clispinner.WithWriter(io.Discard))
+	}
+	return clispinner.New()
+}
+
+// printJSON writes a message of the JSON output to stdout.
+func printJSON(m jsonMessage) {
+	// messages only hold JSON encodable values.
+	data, _ := json.Marshal(m)
+	fmt.Println(string(data))
+}
+
+// printJSONEvents prints the events of bus as JSON until the bus is shut down.
+func printJSONEvents(wg *sync.WaitGroup, bus events.Bus) {
+	defer wg.Done()
+
+	for event := range bus {
+		event := event
+		printJSON(jsonMessage{Type: "event", Event: &event})
+	}
+}
+
+// cliOutput prints the progress and the result of a command as human readable text
+// or as newline delimited JSON when the command is run with --output json.
+type cliOutput struct {
+	json    bool
+	ev      events.Bus
+	wg      *sync.WaitGroup
+	spinner *clispinner.Spinner
+	once    *sync.Once
+}
+
+// newCLIOutput creates a new output for cmd and starts printing the events sent to it.
+// Stop must be called once the command is done.
+func newCLIOutput(cmd *cobra.Command) cliOutput {
+	o := cliOutput{
+		json: isJSONOutput(cmd),
+		ev:   events.NewBus(),
+		wg:   &sync.WaitGroup{},
+		once: &sync.Once{},
+	}
+
+	// the spinner only spins while an operation is ongoing.
+	o.spinner = newSpinner(cmd).Stop()
+
+	o.wg.Add(1)
+	if o.json {
+		go printJSONEvents(o.wg, o.ev)
+	} else {
+		go printEvents(o.wg, o.ev, o.spinner)
+	}
+
+	return o
+}
+
+// Events returns the bus to send the progress of the command to.
+func (o cliOutput) Events() events.Bus {
+	return o.ev
+}
+
+// Progress reports an ongoing operation of the command.
+func (o cliOutput) Progress(description string) {
+	o.ev.Send(events.New(events.StatusOngoing, description))
+}
+
+// Stop stops printing the progress of the command.
+func (o cliOutput) Stop() {
+	o.once.Do(func() {
+		o.ev.Shutdown()
+		o.wg.Wait()
+		o.spinner.Stop()
+	})
+}
+
+// Result stops printing the progress and prints the result of the command, as JSON
+// or with printText.
+func (o cliOutput) Result(result interface{}, printText func() error) error {
+	o.Stop()
+
+	if o.json {
+		printJSON(jsonMessage{Type: "result", Result: result})
+		return nil
+	}
+	return printText()
+}
+
+// printResult prints the result of a command that doesn't report its progress, as JSON
+// or with printText.
+func printResult(cmd *cobra.Command, result interface{}, printText func() error) error {
+	if isJSONOutput(cmd) {
+		printJSON(jsonMessage{Type: "result", Result: result})
+		return nil
+	}
+	return printText()
+}
+
+// PrintError prints the error returned by the root command c.
+func PrintError(c *cobra.Command, err error) {
+	if format, _ := c.PersistentFlags().GetString(flagOutputFormat); format == outputFormatJSON {
+		printJSON(jsonMessage{Type: "error", Error: err.Error()})
+		return
+	}
+
+	var validationErr validation.Error
+	if errors.As(err, &validationErr) {
+		fmt.Println(validationErr.ValidationInfo())
+	} else {
+		fmt.Println(err)
+	}
+}
@@ -2,11 +2,13 @@ package starportcmd
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
-	"github.com/tendermint/starport/starport/pkg/clispinner"
 	"github.com/tendermint/starport/starport/pkg/placeholder"
+	"github.com/tendermint/starport/starport/pkg/xgenny"
 	"github.com/tendermint/starport/starport/services/scaffolder"
 )
 
@@ -16,6 +18,7 @@ const (
 	flagNoMessage   = "no-message"
 	flagResponse    = "response"
 	flagDescription = "desc"
+	flagDryRun      = "dry-run"
 )
 
 // NewScaffold returns a command that groups scaffolding related sub commands.
@@ -30,6 +33,8 @@ CRUD stands for "create, read, update, delete".`,
 		Args:    cobra.ExactArgs(1),
 	}
 
+	c.PersistentFlags().Bool(flagDryRun, false, "Print the diff of the files to create and modify without modifying them")
+
 	c.AddCommand(NewScaffoldChain())
 	c.AddCommand(NewScaffoldModule())
 	c.AddCommand(NewScaffoldList())
@@ -42,8 +47,14 @@ CRUD stands for "create, read, update, delete".`,
 	c.AddCommand(NewScaffoldBandchain())
 	c.AddCommand(NewScaffoldVue())
 	c.AddCommand(NewScaffoldFlutter())
+	c.AddCommand(NewScaffoldRemove())
 	// c.AddCommand(NewScaffoldWasm())
 
+	// the scaffold commands report their progress and result with the CLI output.
+	for _, sc := range c.Commands() {
+		withJSONOutput(sc)
+	}
+
 	return c
 }
 
@@ -75,10 +86,12 @@ func scaffoldType(
 		options = append(options, scaffolder.TypeWithSigner(signer))
 	}
 
-	s := clispinner.New().SetText("Scaffolding...")
-	defer s.Stop()
+	out := newCLIOutput(cmd)
+	defer out.Stop()
 
-	sc, err := newApp(appPath)
+	out.Progress("Scaffolding")
+
+	sc, err := newApp(cmd, appPath)
 	if err != nil {
 		return err
 	}
@@ -88,17 +101,77 @@ func scaffoldType(
 		return err
 	}
 
-	s.Stop()
+	return printScaffoldResult(cmd, out, sm, fmt.Sprintf("\n🎉 %s added. \n\n", typeName))
+}
+
+// printScaffoldResult prints the files modified by a scaffold command followed by message,
+// or the diff of the files with --dry-run.
+func printScaffoldResult(cmd *cobra.Command, out cliOutput, sm xgenny.SourceModification, message string) error {
+	if flagGetDryRun(cmd) {
+		return printDryRunResult(out, sm)
+	}
+
+	result, err := newScaffoldResult(sm)
+	if err != nil {
+		return err
+	}
+
+	return out.Result(result, func() error {
+		modificationsStr, err := sourceModificationToString(sm)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(modificationsStr)
+		fmt.Print(message)
+		return nil
+	})
+}
 
-	modificationsStr, err := sourceModificationToString(sm)
+// printDryRunResult prints the unified diff of the files a scaffold command would create
+// and modify.
+func printDryRunResult(out cliOutput, sm xgenny.SourceModification) error {
+	result, err := newScaffoldResult(sm)
+	if err != nil {
+		return err
+	}
+	pwd, err := os.Getwd()
 	if err != nil {
 		return err
 	}
+	if result.Diff, err = sm.UnifiedDiff(pwd); err != nil {
+		return err
+	}
 
-	fmt.Println(modificationsStr)
-	fmt.Printf("\n🎉 %s added. \n\n", typeName)
+	return out.Result(result, func() error {
+		fmt.Print(result.Diff)
+		fmt.Print("\n🔍 Dry run, no file has been modified.\n\n")
+		return nil
+	})
+}
+
+// scaffoldCommand returns the scaffold command run with cmd as recorded in the journal of
+// the app, the flags depending on the environment like --path are omitted.
+func scaffoldCommand(cmd *cobra.Command) []string {
+	command := strings.Fields(cmd.CommandPath())[1:]
+	command = append(command, cmd.Flags().Args()...)
+	cmd.Flags().Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case flagPath, flagDryRun, flagOutputFormat:
+			return
+		}
+		value := f.Value.String()
+		if slice, ok := f.Value.(flag.SliceValue); ok {
+			value = strings.Join(slice.GetSlice(), ",")
+		}
+		command = append(command, fmt.Sprintf("--%s=%s", f.Name, value))
+	})
+	return command
+}
 
-	return nil
+func flagGetDryRun(cmd *cobra.Command) bool {
+	dryRun, _ := cmd.Flags().GetBool(flagDryRun)
+	return dryRun
 }
 
 func flagSetScaffoldType() *flag.FlagSet {
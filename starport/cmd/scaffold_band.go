@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"github.com/tendermint/starport/starport/pkg/clispinner"
 	"github.com/tendermint/starport/starport/pkg/placeholder"
 	"github.com/tendermint/starport/starport/services/scaffolder"
 )
@@ -34,8 +33,10 @@ func createBandchainHandler(cmd *cobra.Command, args []string) error {
 		signer  = flagGetSigner(cmd)
 	)
 
-	s := clispinner.New().SetText("Scaffolding...")
-	defer s.Stop()
+	out := newCLIOutput(cmd)
+	defer out.Stop()
+
+	out.Progress("Scaffolding")
 
 	module, err := cmd.Flags().GetString(flagModule)
 	if err != nil {
@@ -50,7 +51,7 @@ func createBandchainHandler(cmd *cobra.Command, args []string) error {
 		options = append(options, scaffolder.OracleWithSigner(signer))
 	}
 
-	sc, err := newApp(appPath)
+	sc, err := newApp(cmd, appPath)
 	if err != nil {
 		return err
 	}
@@ -60,16 +61,7 @@ func createBandchainHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	s.Stop()
-
-	modificationsStr, err := sourceModificationToString(sm)
-	if err != nil {
-		return err
-	}
-
-	fmt.Println(modificationsStr)
-
-	fmt.Printf(`
+	return printScaffoldResult(cmd, out, sm, fmt.Sprintf(`
 🎉 Created a Band oracle query "%[1]v".
 
 Note: BandChain module uses version "bandchain-1".
@@ -78,7 +70,5 @@ Make sure to update the keys.go file accordingly.
 // x/%[2]v/types/keys.go
 const Version = "bandchain-1"
 
-`, oracle, module)
-
-	return nil
+`, oracle, module))
 }
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"github.com/tendermint/starport/starport/pkg/clispinner"
 	"github.com/tendermint/starport/starport/pkg/placeholder"
 	"github.com/tendermint/starport/starport/services/scaffolder"
 )
@@ -31,8 +30,10 @@ func NewScaffoldChain() *cobra.Command {
 }
 
 func scaffoldChainHandler(cmd *cobra.Command, args []string) error {
-	s := clispinner.New().SetText("Scaffolding...")
-	defer s.Stop()
+	out := newCLIOutput(cmd)
+	defer out.Stop()
+
+	out.Progress("Scaffolding")
 
 	var (
 		name               = args[0]
@@ -41,12 +42,13 @@ func scaffoldChainHandler(cmd *cobra.Command, args []string) error {
 		appPath            = flagGetPath(cmd)
 	)
 
-	appdir, err := scaffolder.Init(placeholder.New(), appPath, name, addressPrefix, noDefaultModule)
+	appdir, sm, err := scaffolder.Init(placeholder.New(), appPath, name, addressPrefix, noDefaultModule, flagGetDryRun(cmd))
 	if err != nil {
 		return err
 	}
-
-	s.Stop()
+	if flagGetDryRun(cmd) {
+		return printDryRunResult(out, sm)
+	}
 
 	path, err := relativePath(appdir)
 	if err != nil {
@@ -62,7 +64,14 @@ func scaffoldChainHandler(cmd *cobra.Command, args []string) error {
 
 Documentation: https://docs.starport.network
 `
-	fmt.Printf(message, path)
+	return out.Result(scaffoldChainResult{Path: path}, func() error {
+		fmt.Printf(message, path)
+		return nil
+	})
+}
 
-	return nil
+// scaffoldChainResult is the JSON output of the scaffold chain command.
+type scaffoldChainResult struct {
+	// Path of the created chain relative to the current directory.
+	Path string `json:"path"`
 }
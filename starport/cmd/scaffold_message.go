@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"github.com/tendermint/starport/starport/pkg/clispinner"
 	"github.com/tendermint/starport/starport/pkg/placeholder"
 	"github.com/tendermint/starport/starport/services/scaffolder"
 )
@@ -38,8 +37,10 @@ func messageHandler(cmd *cobra.Command, args []string) error {
 		appPath      = flagGetPath(cmd)
 	)
 
-	s := clispinner.New().SetText("Scaffolding...")
-	defer s.Stop()
+	out := newCLIOutput(cmd)
+	defer out.Stop()
+
+	out.Progress("Scaffolding")
 
 	var options []scaffolder.MessageOption
 
@@ -53,7 +54,7 @@ func messageHandler(cmd *cobra.Command, args []string) error {
 		options = append(options, scaffolder.WithSigner(signer))
 	}
 
-	sc, err := newApp(appPath)
+	sc, err := newApp(cmd, appPath)
 	if err != nil {
 		return err
 	}
@@ -63,15 +64,5 @@ func messageHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	s.Stop()
-
-	modificationsStr, err := sourceModificationToString(sm)
-	if err != nil {
-		return err
-	}
-
-	fmt.Println(modificationsStr)
-	fmt.Printf("\n🎉 Created a message `%[1]v`.\n\n", args[0])
-
-	return nil
+	return printScaffoldResult(cmd, out, sm, fmt.Sprintf("\n🎉 Created a message `%[1]v`.\n\n", args[0]))
 }
@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/tendermint/starport/starport/pkg/clispinner"
 	"github.com/tendermint/starport/starport/pkg/placeholder"
 	"github.com/tendermint/starport/starport/pkg/validation"
 	"github.com/tendermint/starport/starport/services/scaffolder"
@@ -48,8 +47,10 @@ func scaffoldModuleHandler(cmd *cobra.Command, args []string) error {
 		name    = args[0]
 		appPath = flagGetPath(cmd)
 	)
-	s := clispinner.New().SetText("Scaffolding...")
-	defer s.Stop()
+	out := newCLIOutput(cmd)
+	defer out.Stop()
+
+	out.Progress("Scaffolding")
 
 	ibcModule, err := cmd.Flags().GetBool(flagIBC)
 	if err != nil {
@@ -108,13 +109,13 @@ func scaffoldModuleHandler(cmd *cobra.Command, args []string) error {
 	var msg bytes.Buffer
 	fmt.Fprintf(&msg, "\n🎉 Module created %s.\n\n", name)
 
-	sc, err := newApp(appPath)
+	sc, err := newApp(cmd, appPath)
 	if err != nil {
 		return err
 	}
 
 	sm, err := sc.CreateModule(placeholder.New(), name, options...)
-	s.Stop()
+	registered := err == nil
 	if err != nil {
 		var validationErr validation.Error
 		if !requireRegistration && errors.As(err, &validationErr) {
@@ -123,21 +124,34 @@ func scaffoldModuleHandler(cmd *cobra.Command, args []string) error {
 		} else {
 			return err
 		}
-	} else {
-		modificationsStr, err := sourceModificationToString(sm)
-		if err != nil {
-			return err
-		}
+	}
 
-		fmt.Println(modificationsStr)
+	if flagGetDryRun(cmd) {
+		return printDryRunResult(out, sm)
 	}
 
-	if len(dependencies) > 0 {
-		dependencyWarning(dependencies)
+	result, err := newScaffoldResult(sm)
+	if err != nil {
+		return err
 	}
 
-	io.Copy(cmd.OutOrStdout(), &msg)
-	return nil
+	return out.Result(result, func() error {
+		if registered {
+			modificationsStr, err := sourceModificationToString(sm)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(modificationsStr)
+		}
+
+		if len(dependencies) > 0 {
+			dependencyWarning(dependencies)
+		}
+
+		_, err := io.Copy(cmd.OutOrStdout(), &msg)
+		return err
+	})
 }
 
 // in previously scaffolded apps gov keeper is defined below the scaffolded module keeper definition
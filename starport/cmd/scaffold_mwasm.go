@@ -1,10 +1,7 @@
 package starportcmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
-	"github.com/tendermint/starport/starport/pkg/clispinner"
 	"github.com/tendermint/starport/starport/pkg/placeholder"
 )
 
@@ -25,28 +22,20 @@ func NewScaffoldWasm() *cobra.Command {
 func scaffoldWasmHandler(cmd *cobra.Command, args []string) error {
 	appPath := flagGetPath(cmd)
 
-	s := clispinner.New().SetText("Scaffolding...")
-	defer s.Stop()
+	out := newCLIOutput(cmd)
+	defer out.Stop()
 
-	sc, err := newApp(appPath)
-	if err != nil {
-		return err
-	}
+	out.Progress("Scaffolding")
 
-	sm, err := sc.ImportModule(placeholder.New(), "wasm")
+	sc, err := newApp(cmd, appPath)
 	if err != nil {
 		return err
 	}
 
-	s.Stop()
-
-	modificationsStr, err := sourceModificationToString(sm)
+	sm, err := sc.ImportModule(placeholder.New(), "wasm")
 	if err != nil {
 		return err
 	}
 
-	fmt.Println(modificationsStr)
-	fmt.Printf("\n🎉 Imported wasm.\n\n")
-
-	return nil
+	return printScaffoldResult(cmd, out, sm, "\n🎉 Imported wasm.\n\n")
 }
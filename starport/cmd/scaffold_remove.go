@@ -0,0 +1,73 @@
+package starportcmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tendermint/starport/starport/pkg/placeholder"
+	"github.com/tendermint/starport/starport/services/scaffolder"
+)
+
+// NewScaffoldRemove returns a command that groups the sub commands removing scaffolded components.
+func NewScaffoldRemove() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "remove [command]",
+		Short: "Remove a list, map, single, message, query or packet previously scaffolded",
+		Long: `Remove commands reverse a previous scaffold command: the files created for the component
+are deleted and the code added to the existing files is removed.
+
+Only the components scaffolded with this version of Starport can be removed.`,
+		Aliases: []string{"rm"},
+		Args:    cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(newScaffoldRemoveComponent(scaffolder.ComponentList, "Remove a list"))
+	c.AddCommand(newScaffoldRemoveComponent(scaffolder.ComponentMap, "Remove a map"))
+	c.AddCommand(newScaffoldRemoveComponent(scaffolder.ComponentSingleton, "Remove a single"))
+	c.AddCommand(newScaffoldRemoveComponent(scaffolder.ComponentMessage, "Remove a message"))
+	c.AddCommand(newScaffoldRemoveComponent(scaffolder.ComponentQuery, "Remove a query"))
+	c.AddCommand(newScaffoldRemoveComponent(scaffolder.ComponentPacket, "Remove a packet"))
+
+	return c
+}
+
+func newScaffoldRemoveComponent(kind scaffolder.ComponentKind, short string) *cobra.Command {
+	c := &cobra.Command{
+		Use:   fmt.Sprintf("%s NAME", kind),
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return scaffoldRemoveHandler(cmd, args, kind)
+		},
+	}
+
+	flagSetPath(c)
+	c.Flags().String(flagModule, "", "Module to remove from. Default is app's main module")
+
+	return withJSONOutput(c)
+}
+
+func scaffoldRemoveHandler(cmd *cobra.Command, args []string, kind scaffolder.ComponentKind) error {
+	var (
+		name       = args[0]
+		moduleName = flagGetModule(cmd)
+		appPath    = flagGetPath(cmd)
+	)
+
+	out := newCLIOutput(cmd)
+	defer out.Stop()
+
+	out.Progress("Removing")
+
+	sc, err := newApp(cmd, appPath)
+	if err != nil {
+		return err
+	}
+
+	sm, err := sc.RemoveComponent(cmd.Context(), placeholder.New(), kind, moduleName, name)
+	if err != nil {
+		return err
+	}
+
+	return printScaffoldResult(cmd, out, sm, fmt.Sprintf("\n🗑  %s %s removed. \n\n", kind, name))
+}
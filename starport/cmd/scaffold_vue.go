@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"github.com/tendermint/starport/starport/pkg/clispinner"
 	"github.com/tendermint/starport/starport/services/scaffolder"
 )
 
@@ -23,16 +22,22 @@ func NewScaffoldVue() *cobra.Command {
 }
 
 func scaffoldVueHandler(cmd *cobra.Command, args []string) error {
-	s := clispinner.New().SetText("Scaffolding...")
-	defer s.Stop()
+	out := newCLIOutput(cmd)
+	defer out.Stop()
+
+	out.Progress("Scaffolding")
 
 	path := flagGetPath(cmd)
-	if err := scaffolder.Vue(path); err != nil {
+	sm, err := scaffolder.Vue(path, flagGetDryRun(cmd))
+	if err != nil {
 		return err
 	}
+	if flagGetDryRun(cmd) {
+		return printDryRunResult(out, sm)
+	}
 
-	s.Stop()
-	fmt.Printf("\n🎉 Scaffold a Vue.js app.\n\n")
-
-	return nil
+	return out.Result(struct{}{}, func() error {
+		fmt.Printf("\n🎉 Scaffold a Vue.js app.\n\n")
+		return nil
+	})
 }
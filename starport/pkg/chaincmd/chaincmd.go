@@ -16,11 +16,10 @@ const (
 	commandCollectGentxs     = "collect-gentxs"
 	commandValidateGenesis   = "validate-genesis"
 	commandShowNodeID        = "show-node-id"
-	commandStatus            = "status"
 	commandTx                = "tx"
-	commandQuery             = "query"
 	commandUnsafeReset       = "unsafe-reset-all"
 	commandExport            = "export"
+	commandGenesis           = "genesis"
 
 	optionHome                             = "--home"
 	optionNode                             = "--node"
@@ -29,7 +28,6 @@ const (
 	optionOutput                           = "--output"
 	optionRecover                          = "--recover"
 	optionAddress                          = "--address"
-	optionAmount                           = "--amount"
 	optionValidatorMoniker                 = "--moniker"
 	optionValidatorCommissionRate          = "--commission-rate"
 	optionValidatorCommissionMaxRate       = "--commission-max-rate"
@@ -41,7 +39,6 @@ const (
 	optionValidatorWebsite                 = "--website"
 	optionValidatorSecurityContact         = "--security-contact"
 	optionYes                              = "--yes"
-	optionHomeClient                       = "--home-client"
 	optionCoinType                         = "--coin-type"
 	optionVestingAmount                    = "--vesting-amount"
 	optionVestingEndTime                   = "--vesting-end-time"
@@ -69,8 +66,6 @@ type ChainCmd struct {
 	homeDir         string
 	keyringBackend  KeyringBackend
 	keyringPassword string
-	cliCmd          string
-	cliHome         string
 	nodeAddress     string
 	legacySend      bool
 
@@ -158,23 +153,7 @@ func WithNodeAddress(addr string) Option {
 	}
 }
 
-// WithLaunchpadCLI provides the CLI application name for the blockchain
-// this is necessary for Launchpad applications since it has two different binaries but
-// not needed by Stargate applications
-func WithLaunchpadCLI(cliCmd string) Option {
-	return func(c *ChainCmd) {
-		c.cliCmd = cliCmd
-	}
-}
-
-// WithLaunchpadCLIHome replaces the default home used by the Launchpad chain CLI
-func WithLaunchpadCLIHome(cliHome string) Option {
-	return func(c *ChainCmd) {
-		c.cliHome = cliHome
-	}
-}
-
-// WithLegacySendCommand will make the command use the legacy tx send syntax from launchpad
+// WithLegacySendCommand will make the command use the legacy tx send syntax
 // on stargate chains. e.g.: CosmWasm
 func WithLegacySendCommand() Option {
 	return func(c *ChainCmd) {
@@ -214,7 +193,7 @@ func (c ChainCmd) AddKeyCommand(accountName, coinType string) step.Option {
 	}
 	command = c.attachKeyringBackend(command)
 
-	return c.cliCommand(command)
+	return c.daemonCommand(command)
 }
 
 // RecoverKeyCommand returns the command to recover a key into the chain keyring from a mnemonic
@@ -230,7 +209,7 @@ func (c ChainCmd) RecoverKeyCommand(accountName, coinType string) step.Option {
 	}
 	command = c.attachKeyringBackend(command)
 
-	return c.cliCommand(command)
+	return c.daemonCommand(command)
 }
 
 // ImportKeyCommand returns the command to import a key into the chain keyring from a key file
@@ -243,7 +222,7 @@ func (c ChainCmd) ImportKeyCommand(accountName, keyFile string) step.Option {
 	}
 	command = c.attachKeyringBackend(command)
 
-	return c.cliCommand(command)
+	return c.daemonCommand(command)
 }
 
 // ShowKeyAddressCommand returns the command to print the address of a key in the chain keyring
@@ -256,7 +235,7 @@ func (c ChainCmd) ShowKeyAddressCommand(accountName string) step.Option {
 	}
 	command = c.attachKeyringBackend(command)
 
-	return c.cliCommand(command)
+	return c.daemonCommand(command)
 }
 
 // ListKeysCommand returns the command to print the list of a keys in the chain keyring
@@ -269,7 +248,7 @@ func (c ChainCmd) ListKeysCommand() step.Option {
 	}
 	command = c.attachKeyringBackend(command)
 
-	return c.cliCommand(command)
+	return c.daemonCommand(command)
 }
 
 // AddGenesisAccountCommand returns the command to add a new account in the genesis file of the chain
@@ -280,7 +259,7 @@ func (c ChainCmd) AddGenesisAccountCommand(address, coins string) step.Option {
 		coins,
 	}
 
-	return c.daemonCommand(command)
+	return c.genesisCommand(command)
 }
 
 // AddVestingAccountCommand returns the command to add a delayed vesting account in the genesis file of the chain
@@ -295,7 +274,7 @@ func (c ChainCmd) AddVestingAccountCommand(address, originalCoins, vestingCoins
 		fmt.Sprintf("%d", vestingEndTime),
 	}
 
-	return c.daemonCommand(command)
+	return c.genesisCommand(command)
 }
 
 // GentxOption for the GentxCommand
@@ -417,32 +396,8 @@ func (c ChainCmd) GentxCommand(
 ) step.Option {
 	command := []string{
 		commandGentx,
-	}
-
-	switch {
-	case c.sdkVersion.LT(cosmosver.StargateFortyVersion):
-		command = append(command,
-			validatorName,
-			optionAmount,
-			selfDelegation,
-		)
-	case c.sdkVersion.GTE(cosmosver.StargateFortyVersion):
-		command = append(command,
-			validatorName,
-			selfDelegation,
-		)
-	case c.sdkVersion.LTE(cosmosver.MaxLaunchpadVersion):
-		command = append(command,
-			optionName,
-			validatorName,
-			optionAmount,
-			selfDelegation,
-		)
-
-		// Attach home client option
-		if c.cliHome != "" {
-			command = append(command, []string{optionHomeClient, c.cliHome}...)
-		}
+		validatorName,
+		selfDelegation,
 	}
 
 	// Apply the options provided by the user
@@ -450,14 +405,10 @@ func (c ChainCmd) GentxCommand(
 		command = applyOption(command)
 	}
 
-	// Add necessary flags
-	if c.sdkVersion.IsFamily(cosmosver.Stargate) {
-		command = c.attachChainID(command)
-	}
-
+	command = c.attachChainID(command)
 	command = c.attachKeyringBackend(command)
 
-	return c.daemonCommand(command)
+	return c.genesisCommand(command)
 }
 
 // CollectGentxsCommand returns the command to gather the gentxs in /gentx dir into the genesis file of the chain
@@ -465,7 +416,7 @@ func (c ChainCmd) CollectGentxsCommand() step.Option {
 	command := []string{
 		commandCollectGentxs,
 	}
-	return c.daemonCommand(command)
+	return c.genesisCommand(command)
 }
 
 // ValidateGenesisCommand returns the command to check the validity of the chain genesis
@@ -473,7 +424,7 @@ func (c ChainCmd) ValidateGenesisCommand() step.Option {
 	command := []string{
 		commandValidateGenesis,
 	}
-	return c.daemonCommand(command)
+	return c.genesisCommand(command)
 }
 
 // ShowNodeIDCommand returns the command to print the node ID of the node for the chain
@@ -490,6 +441,11 @@ func (c ChainCmd) UnsafeResetCommand() step.Option {
 	command := []string{
 		commandUnsafeReset,
 	}
+
+	// the command is grouped under tendermint on Cosmos-SDK v0.47.0
+	if c.sdkVersion.Release().GTE(cosmosver.StargateFortySevenVersion) {
+		command = append([]string{constTendermint}, command...)
+	}
 	return c.daemonCommand(command)
 }
 
@@ -507,7 +463,7 @@ func (c ChainCmd) BankSendCommand(fromAddress, toAddress, amount string) step.Op
 		commandTx,
 	}
 
-	if c.sdkVersion.IsFamily(cosmosver.Stargate) && !c.legacySend {
+	if !c.legacySend {
 		command = append(command,
 			"bank",
 		)
@@ -527,60 +483,7 @@ func (c ChainCmd) BankSendCommand(fromAddress, toAddress, amount string) step.Op
 	command = c.attachKeyringBackend(command)
 	command = c.attachNode(command)
 
-	if c.sdkVersion.IsFamily(cosmosver.Launchpad) {
-		command = append(command, optionOutput, constJSON)
-	}
-
-	return c.cliCommand(command)
-}
-
-// QueryTxEventsCommand returns the command to query events.
-func (c ChainCmd) QueryTxEventsCommand(query string) step.Option {
-	command := []string{
-		commandQuery,
-		"txs",
-		"--events",
-		query,
-		"--page", "1",
-		"--limit", "1000",
-	}
-
-	if c.sdkVersion.IsFamily(cosmosver.Launchpad) {
-		command = append(command,
-			"--trust-node",
-		)
-	}
-
-	command = c.attachNode(command)
-	return c.cliCommand(command)
-}
-
-// LaunchpadSetConfigCommand returns the command to set config value
-func (c ChainCmd) LaunchpadSetConfigCommand(name, value string) step.Option {
-	// Check version
-	if c.isStargate() {
-		panic("config command doesn't exist for Stargate")
-	}
-	return c.launchpadSetConfigCommand(name, value)
-}
-
-// LaunchpadRestServerCommand returns the command to start the CLI REST server
-func (c ChainCmd) LaunchpadRestServerCommand(apiAddress, rpcAddress string) step.Option {
-	// Check version
-	if c.isStargate() {
-		panic("rest-server command doesn't exist for Stargate")
-	}
-	return c.launchpadRestServerCommand(apiAddress, rpcAddress)
-}
-
-// StatusCommand returns the command that fetches node's status.
-func (c ChainCmd) StatusCommand() step.Option {
-	command := []string{
-		commandStatus,
-	}
-
-	command = c.attachNode(command)
-	return c.cliCommand(command)
+	return c.daemonCommand(command)
 }
 
 // KeyringBackend returns the underlying keyring backend.
@@ -588,6 +491,12 @@ func (c ChainCmd) KeyringBackend() KeyringBackend {
 	return c.keyringBackend
 }
 
+// NodeAddress returns the address of the node used by the commands, it's empty when
+// the commands use the default node.
+func (c ChainCmd) NodeAddress() string {
+	return c.nodeAddress
+}
+
 // KeyringPassword returns the underlying keyring password.
 func (c ChainCmd) KeyringPassword() string {
 	return c.keyringPassword
@@ -625,24 +534,18 @@ func (c ChainCmd) attachNode(command []string) []string {
 	return command
 }
 
-// isStargate checks if the version for commands is Stargate
-func (c ChainCmd) isStargate() bool {
-	return c.sdkVersion.Family == cosmosver.Stargate
-}
-
 // daemonCommand returns the daemon command from the provided command
 func (c ChainCmd) daemonCommand(command []string) step.Option {
 	return step.Exec(c.appCmd, c.attachHome(command)...)
 }
 
-// cliCommand returns the cli command from the provided command
-// cli is the daemon for Stargate
-func (c ChainCmd) cliCommand(command []string) step.Option {
-	// Check version
-	if c.isStargate() {
-		return step.Exec(c.appCmd, c.attachHome(command)...)
+// genesisCommand returns the daemon command from the provided genesis command,
+// genesis commands are grouped under the genesis command from Cosmos-SDK v0.47.0
+func (c ChainCmd) genesisCommand(command []string) step.Option {
+	if c.sdkVersion.Release().GTE(cosmosver.StargateFortySevenVersion) {
+		command = append([]string{commandGenesis}, command...)
 	}
-	return step.Exec(c.cliCmd, c.attachCLIHome(command)...)
+	return c.daemonCommand(command)
 }
 
 // KeyringBackendFromString returns the keyring backend from its string
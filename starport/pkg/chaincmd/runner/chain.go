@@ -13,7 +13,16 @@ import (
 
 	"github.com/tendermint/starport/starport/pkg/chaincmd"
 	"github.com/tendermint/starport/starport/pkg/cmdrunner/step"
-	"github.com/tendermint/starport/starport/pkg/cosmosver"
+	"github.com/tendermint/starport/starport/pkg/tendermintrpc"
+	"github.com/tendermint/starport/starport/pkg/xurl"
+)
+
+const (
+	// defaultNodeAddress is the address of the node used by the commands by default.
+	defaultNodeAddress = "localhost:26657"
+
+	// queryTxEventsLimit is the maximum number of txs to query events from.
+	queryTxEventsLimit = 1000
 )
 
 // Start starts the blockchain.
@@ -25,45 +34,11 @@ func (r Runner) Start(ctx context.Context, args ...string) error {
 	)
 }
 
-// LaunchpadStartRestServer start launchpad rest server.
-func (r Runner) LaunchpadStartRestServer(ctx context.Context, apiAddress, rpcAddress string) error {
-	return r.run(
-		ctx,
-		runOptions{wrappedStdErrMaxLen: 50000},
-		r.chainCmd.LaunchpadRestServerCommand(apiAddress, rpcAddress),
-	)
-}
-
 // Init inits the blockchain.
 func (r Runner) Init(ctx context.Context, moniker string) error {
 	return r.run(ctx, runOptions{}, r.chainCmd.InitCommand(moniker))
 }
 
-// KV holds a key, value pair.
-type KV struct {
-	key   string
-	value string
-}
-
-// NewKV returns a new key, value pair.
-func NewKV(key, value string) KV {
-	return KV{key, value}
-}
-
-// LaunchpadSetConfigs updates configurations for a launchpad app.
-func (r Runner) LaunchpadSetConfigs(ctx context.Context, kvs ...KV) error {
-	for _, kv := range kvs {
-		if err := r.run(
-			ctx,
-			runOptions{},
-			r.chainCmd.LaunchpadSetConfigCommand(kv.key, kv.value),
-		); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
 var gentxRe = regexp.MustCompile(`(?m)"(.+?)"`)
 
 // Gentx generates a genesis tx carrying a self delegation.
@@ -116,49 +91,13 @@ type NodeStatus struct {
 
 // Status returns the node's status.
 func (r Runner) Status(ctx context.Context) (NodeStatus, error) {
-	b := newBuffer()
-
-	if err := r.run(ctx, runOptions{stdout: b, stderr: b}, r.chainCmd.StatusCommand()); err != nil {
-		return NodeStatus{}, err
-	}
-
-	var chainID string
-
-	data, err := b.JSONEnsuredBytes()
+	info, err := r.rpcClient().Status(ctx)
 	if err != nil {
 		return NodeStatus{}, err
 	}
 
-	version := r.chainCmd.SDKVersion()
-	switch {
-	case version.GTE(cosmosver.StargateFortyVersion):
-		out := struct {
-			NodeInfo struct {
-				Network string `json:"network"`
-			} `json:"NodeInfo"`
-		}{}
-
-		if err := json.Unmarshal(data, &out); err != nil {
-			return NodeStatus{}, err
-		}
-
-		chainID = out.NodeInfo.Network
-	default:
-		out := struct {
-			NodeInfo struct {
-				Network string `json:"network"`
-			} `json:"node_info"`
-		}{}
-
-		if err := json.Unmarshal(data, &out); err != nil {
-			return NodeStatus{}, err
-		}
-
-		chainID = out.NodeInfo.Network
-	}
-
 	return NodeStatus{
-		ChainID: chainID,
+		ChainID: info.Network,
 	}, nil
 }
 
@@ -257,73 +196,70 @@ func (r Runner) QueryTxEvents(
 	selector EventSelector,
 	moreSelectors ...EventSelector,
 ) ([]Event, error) {
-	// prepare the slector.
-	var list []string
+	// prepare the query.
+	var conditions []string
 
 	eventsSelectors := append([]EventSelector{selector}, moreSelectors...)
 
 	for _, event := range eventsSelectors {
-		list = append(list, fmt.Sprintf("%s.%s=%s", event.typ, event.attr, event.value))
+		// the query values are quoted and cannot contain quotes.
+		if strings.Contains(event.value, "'") {
+			return nil, fmt.Errorf("event %s.%s: value %q cannot contain a single quote", event.typ, event.attr, event.value)
+		}
+		conditions = append(conditions, fmt.Sprintf("%s.%s='%s'", event.typ, event.attr, event.value))
 	}
 
-	query := strings.Join(list, "&")
+	query := strings.Join(conditions, " AND ")
 
-	// execute the commnd and parse the output.
-	b := newBuffer()
-
-	if err := r.run(ctx, runOptions{stdout: b}, r.chainCmd.QueryTxEventsCommand(query)); err != nil {
-		return nil, err
-	}
-
-	out := struct {
-		Txs []struct {
-			Logs []struct {
-				Events []struct {
-					Type  string `json:"type"`
-					Attrs []struct {
-						Key   string `json:"key"`
-						Value string `json:"value"`
-					} `json:"attributes"`
-				} `json:"events"`
-			} `json:"logs"`
-			TimeStamp string `json:"timestamp"`
-		} `json:"txs"`
-	}{}
+	client := r.rpcClient()
 
-	data, err := b.JSONEnsuredBytes()
+	txs, err := client.TxSearch(ctx, query, tendermintrpc.TxSearchLimit(queryTxEventsLimit))
 	if err != nil {
 		return nil, err
 	}
-	if err := json.Unmarshal(data, &out); err != nil {
-		return nil, err
-	}
+
+	// the time of a tx is the time of its block.
+	blockTimes := make(map[int64]time.Time)
 
 	var events []Event
 
-	for _, tx := range out.Txs {
-		for _, log := range tx.Logs {
-			for _, e := range log.Events {
-				var attrs []EventAttribute
-				for _, attr := range e.Attrs {
-					attrs = append(attrs, EventAttribute{
-						Key:   attr.Key,
-						Value: attr.Value,
-					})
-				}
-
-				txTime, err := time.Parse(time.RFC3339, tx.TimeStamp)
-				if err != nil {
-					return nil, err
-				}
-
-				events = append(events, Event{
-					Type:       e.Type,
-					Attributes: attrs,
-					Time:       txTime,
+	for _, tx := range txs {
+		txTime, ok := blockTimes[tx.Height]
+		if !ok {
+			block, err := client.Block(ctx, tx.Height)
+			if err != nil {
+				return nil, err
+			}
+			txTime = block.Time
+			blockTimes[tx.Height] = txTime
+		}
+
+		for _, e := range tx.Result.Events {
+			var attrs []EventAttribute
+			for _, attr := range e.Attributes {
+				attrs = append(attrs, EventAttribute{
+					Key:   attr.Key,
+					Value: attr.Value,
 				})
 			}
+
+			events = append(events, Event{
+				Type:       e.Type,
+				Attributes: attrs,
+				Time:       txTime,
+			})
 		}
 	}
 
 	return events, nil
 }
+
+// rpcClient returns a client of the RPC of the node used by the commands.
+func (r Runner) rpcClient() tendermintrpc.Client {
+	addr := r.chainCmd.NodeAddress()
+	if addr == "" {
+		addr = defaultNodeAddress
+	}
+
+	return tendermintrpc.New(xurl.HTTP(strings.TrimPrefix(addr, "tcp://")))
+}
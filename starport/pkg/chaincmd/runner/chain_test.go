@@ -0,0 +1,105 @@
+package chaincmdrunner_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/starport/starport/pkg/chaincmd"
+	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
+	"github.com/tendermint/starport/starport/pkg/tendermintrpc/tendermintrpctest"
+)
+
+func newRunner(t *testing.T, server *tendermintrpctest.Server) chaincmdrunner.Runner {
+	// the node address is given like to the chain's binary.
+	address := "tcp://" + strings.TrimPrefix(server.URL, "http://")
+
+	runner, err := chaincmdrunner.New(context.Background(), chaincmd.New(
+		"marsd",
+		chaincmd.WithNodeAddress(address),
+		chaincmd.WithAutoChainIDDetection(),
+	))
+	require.NoError(t, err)
+	return runner
+}
+
+func TestStatus(t *testing.T) {
+	server := tendermintrpctest.NewServer()
+	defer server.Close()
+
+	// the chain id is detected from the status of the node.
+	runner := newRunner(t, server)
+	require.Equal(t, "status", server.Calls()[0].Method)
+
+	status, err := runner.Status(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, chaincmdrunner.NodeStatus{ChainID: "test"}, status)
+}
+
+func TestQueryTxEvents(t *testing.T) {
+	server := tendermintrpctest.NewServer()
+	defer server.Close()
+
+	var query string
+	server.Handle("tx_search", func(params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Query string `json:"query"`
+		}
+		err := json.Unmarshal(params, &p)
+		query = p.Query
+
+		return map[string]interface{}{
+			"txs": []interface{}{
+				map[string]interface{}{
+					"hash":   "A1",
+					"height": "5",
+					"tx_result": map[string]interface{}{
+						"events": []interface{}{
+							map[string]interface{}{
+								"type": "transfer",
+								"attributes": []interface{}{
+									map[string]interface{}{"key": "cmVjaXBpZW50", "value": "Y29zbW9zMWI="},
+								},
+							},
+						},
+					},
+				},
+			},
+			"total_count": "1",
+		}, err
+	})
+	server.HandleResult("block", map[string]interface{}{
+		"block": map[string]interface{}{
+			"header": map[string]interface{}{"height": "5", "time": "2021-11-02T10:00:00Z"},
+		},
+	})
+
+	events, err := newRunner(t, server).QueryTxEvents(
+		context.Background(),
+		chaincmdrunner.NewEventSelector("message", "sender", "cosmos1a"),
+		chaincmdrunner.NewEventSelector("transfer", "recipient", "cosmos1b"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "message.sender='cosmos1a' AND transfer.recipient='cosmos1b'", query)
+	require.Equal(t, []chaincmdrunner.Event{
+		{
+			Type:       "transfer",
+			Attributes: []chaincmdrunner.EventAttribute{{Key: "recipient", Value: "cosmos1b"}},
+			Time:       time.Date(2021, 11, 2, 10, 0, 0, 0, time.UTC),
+		},
+	}, events)
+}
+
+func TestQueryTxEventsQuotedValue(t *testing.T) {
+	server := tendermintrpctest.NewServer()
+	defer server.Close()
+
+	_, err := newRunner(t, server).QueryTxEvents(
+		context.Background(),
+		chaincmdrunner.NewEventSelector("message", "memo", "it's"),
+	)
+	require.EqualError(t, err, `event message.memo: value "it's" cannot contain a single quote`)
+}
@@ -0,0 +1,72 @@
+package checksum
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// SignatureExt is the extension of detached signature files.
+const SignatureExt = ".sig"
+
+// ErrInvalidSignature is returned when a signature doesn't match the signed file.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// ParsePrivateKeyFile parses the PEM encoded PKCS #8 ed25519 private key at path,
+// e.g. a key generated with `openssl genpkey -algorithm ed25519`.
+func ParsePrivateKeyFile(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s is not a PEM encoded key", path)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	privKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an ed25519 private key", path)
+	}
+
+	return privKey, nil
+}
+
+// Sign signs the file at path with key and writes the raw detached signature next to it
+// with the SignatureExt extension.
+func Sign(path string, key ed25519.PrivateKey) (sigPath string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	sigPath = path + SignatureExt
+	return sigPath, os.WriteFile(sigPath, ed25519.Sign(key, data), 0666)
+}
+
+// Verify verifies the detached signature at sigPath of the file at path with key.
+func Verify(path, sigPath string, key ed25519.PublicKey) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	sig, err := os.ReadFile(sigPath)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(key, data, sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package checksum
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	dir := t.TempDir()
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(privKey)
+	require.NoError(t, err)
+	keyPath := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+
+	key, err := ParsePrivateKeyFile(keyPath)
+	require.NoError(t, err)
+	require.Equal(t, privKey, key)
+
+	path := filepath.Join(dir, "checksum.txt")
+	require.NoError(t, os.WriteFile(path, []byte("abc release.tar.gz\n"), 0644))
+
+	sigPath, err := Sign(path, key)
+	require.NoError(t, err)
+	require.Equal(t, path+SignatureExt, sigPath)
+	require.NoError(t, Verify(path, sigPath, pubKey))
+
+	require.NoError(t, os.WriteFile(path, []byte("def release.tar.gz\n"), 0644))
+	require.Equal(t, ErrInvalidSignature, Verify(path, sigPath, pubKey))
+}
+
+func TestParsePrivateKeyFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, []byte("not a key"), 0600))
+
+	_, err := ParsePrivateKeyFile(path)
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package clispinner
 
 import (
+	"io"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -16,9 +17,22 @@ type Spinner struct {
 	sp *spinner.Spinner
 }
 
-// New creates a new spinner.
-func New() *Spinner {
+// Option configures a spinner.
+type Option func(*spinner.Spinner)
+
+// WithWriter renders the spinner to w instead of stdout.
+func WithWriter(w io.Writer) Option {
+	return func(sp *spinner.Spinner) {
+		spinner.WithWriter(w)(sp)
+	}
+}
+
+// New creates a new spinner, it starts spinning right away.
+func New(options ...Option) *Spinner {
 	sp := spinner.New(charset, refreshRate)
+	for _, apply := range options {
+		apply(sp)
+	}
 	sp.Color(spinnerColor)
 	s := &Spinner{
 		sp: sp,
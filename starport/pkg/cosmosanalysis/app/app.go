@@ -6,6 +6,9 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
 
 	"github.com/tendermint/starport/starport/pkg/cosmosanalysis"
 )
@@ -69,3 +72,94 @@ func CheckKeeper(path, keeperName string) error {
 	}
 	return nil
 }
+
+// storeKeyConstructors are the functions of the Cosmos SDK that create the store keys of an app
+// mapped to the kind of the keys they create.
+var storeKeyConstructors = map[string]string{
+	"NewKVStoreKeys":        "kv",
+	"NewTransientStoreKeys": "transient",
+	"NewMemoryStoreKeys":    "memory",
+}
+
+// StoreKeys returns the store keys created by the app in the package at path, e.g. kv:banktypes.StoreKey.
+// keys are identified by their kind and the expression that defines them in the source code.
+func StoreKeys(path string) ([]string, error) {
+	fileSet := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fileSet, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+
+				var name string
+				switch fun := call.Fun.(type) {
+				case *ast.SelectorExpr:
+					name = fun.Sel.Name
+				case *ast.Ident:
+					name = fun.Name
+				}
+
+				kind, ok := storeKeyConstructors[name]
+				if !ok {
+					return true
+				}
+				for _, arg := range call.Args {
+					keys = append(keys, fmt.Sprintf("%s:%s", kind, types.ExprString(arg)))
+				}
+				return false
+			})
+		}
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// addressPrefixName is the name of the constant defining the account address prefix of an app.
+const addressPrefixName = "AccountAddressPrefix"
+
+// AddressPrefix returns the account address prefix defined by the app in the package at path,
+// an empty string is returned when the app doesn't define it.
+func AddressPrefix(path string) (string, error) {
+	fileSet := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fileSet, path, nil, 0)
+	if err != nil {
+		return "", err
+	}
+
+	var prefix string
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				spec, ok := n.(*ast.ValueSpec)
+				if !ok {
+					return prefix == ""
+				}
+
+				for i, name := range spec.Names {
+					if name.Name != addressPrefixName || i >= len(spec.Values) {
+						continue
+					}
+					lit, ok := spec.Values[i].(*ast.BasicLit)
+					if !ok || lit.Kind != token.STRING {
+						continue
+					}
+					if prefix, err = strconv.Unquote(lit.Value); err != nil {
+						return false
+					}
+				}
+				return false
+			})
+		}
+	}
+
+	return prefix, err
+}
@@ -51,6 +51,34 @@ func (f Bar) RegisterTendermintService() {}
 `)
 )
 
+func TestStoreKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(tmpDir, "app.go"), []byte(`
+package foo
+
+func New() {
+	keys := sdk.NewKVStoreKeys(
+		banktypes.StoreKey, authtypes.StoreKey,
+		foomoduletypes.StoreKey,
+	)
+	tkeys := sdk.NewTransientStoreKeys(paramstypes.TStoreKey)
+	memKeys := sdk.NewMemoryStoreKeys(capabilitytypes.MemStoreKey)
+}
+`), 0644)
+	require.NoError(t, err)
+
+	keys, err := app.StoreKeys(tmpDir)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"kv:authtypes.StoreKey",
+		"kv:banktypes.StoreKey",
+		"kv:foomoduletypes.StoreKey",
+		"memory:capabilitytypes.MemStoreKey",
+		"transient:paramstypes.TStoreKey",
+	}, keys)
+}
+
 func TestCheckKeeper(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "app_test")
 	require.NoError(t, err)
@@ -86,3 +114,28 @@ func TestCheckKeeper(t *testing.T) {
 	err = app.CheckKeeper(tmpDirTwoApp, "FooKeeper")
 	require.Error(t, err)
 }
+
+func TestAddressPrefix(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(tmpDir, "app.go"), []byte(`
+package foo
+
+const (
+	AccountAddressPrefix = "mars"
+	Name                 = "foo"
+)
+`), 0644)
+	require.NoError(t, err)
+
+	prefix, err := app.AddressPrefix(tmpDir)
+	require.NoError(t, err)
+	require.Equal(t, "mars", prefix)
+
+	// the prefix is empty when it is not defined.
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app.go"), NoAppFile, 0644))
+
+	prefix, err = app.AddressPrefix(tmpDir)
+	require.NoError(t, err)
+	require.Equal(t, "", prefix)
+}
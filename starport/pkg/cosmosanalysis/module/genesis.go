@@ -0,0 +1,210 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/tendermint/starport/starport/pkg/protoanalysis"
+)
+
+// genesisStateMessage is the name of the proto message defining the genesis state of a module.
+const genesisStateMessage = "GenesisState"
+
+// GenesisError is returned when a genesis state doesn't match with the GenesisState
+// proto message of a module.
+type GenesisError struct {
+	// Module is the name of the module.
+	Module string
+
+	// Path of the mismatching value in the genesis state, e.g. postList[2].title.
+	Path string
+
+	// Reason of the mismatch.
+	Reason string
+}
+
+func (e *GenesisError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("genesis state of module %q: %s", e.Module, e.Reason)
+	}
+	return fmt.Sprintf("genesis state of module %q: %s: %s", e.Module, e.Path, e.Reason)
+}
+
+// ValidateGenesis checks that genesis, decoded from JSON, matches with the GenesisState
+// proto message of the module. fields can be named either as in the proto file or
+// with their JSON names. values of messages defined outside of the module are not checked.
+func (m Module) ValidateGenesis(ctx context.Context, genesis map[string]interface{}) error {
+	messages, err := protoanalysis.ParseMessageFields(ctx, m.Pkg.Path)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := messages[genesisStateMessage]; !ok {
+		return &GenesisError{
+			Module: m.Name,
+			Reason: fmt.Sprintf("no %s proto message is defined", genesisStateMessage),
+		}
+	}
+
+	v := genesisValidator{
+		module:   m.Name,
+		pkg:      m.Pkg.Name,
+		messages: messages,
+	}
+
+	return v.validateMessage(genesisStateMessage, genesis, "")
+}
+
+type genesisValidator struct {
+	module   string
+	pkg      string
+	messages protoanalysis.MessageFields
+}
+
+func (v genesisValidator) errorf(path, format string, args ...interface{}) error {
+	return &GenesisError{
+		Module: v.module,
+		Path:   path,
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+// validateMessage validates an object against the fields of the named message.
+func (v genesisValidator) validateMessage(name string, value map[string]interface{}, path string) error {
+	fields := v.messages[name]
+
+	// sort the keys to report mismatches deterministically.
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fieldPath := key
+		if path != "" {
+			fieldPath = fmt.Sprintf("%s.%s", path, key)
+		}
+
+		field, ok := findField(fields, key)
+		if !ok {
+			return v.errorf(fieldPath, "unknown field, %s has no such field", name)
+		}
+
+		if err := v.validateField(name, field, value[key], fieldPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateField validates the value of a field of the message named in.
+func (v genesisValidator) validateField(in string, field protoanalysis.Field, value interface{}, path string) error {
+	if value == nil {
+		return nil
+	}
+
+	switch {
+	case field.Repeated:
+		list, ok := value.([]interface{})
+		if !ok {
+			return v.errorf(path, "expected a list of %s, got %s", field.Type, typeName(value))
+		}
+		for i, elem := range list {
+			if err := v.validateType(in, field.Type, elem, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case field.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return v.errorf(path, "expected a map of %s, got %s", field.Type, typeName(value))
+		}
+		for key, elem := range object {
+			if err := v.validateType(in, field.Type, elem, fmt.Sprintf("%s.%s", path, key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return v.validateType(in, field.Type, value, path)
+}
+
+// validateType validates a single value of type typ referenced from the message named in.
+func (v genesisValidator) validateType(in, typ string, value interface{}, path string) error {
+	if value == nil {
+		return nil
+	}
+
+	switch typ {
+	case "string", "bytes":
+		if _, ok := value.(string); !ok {
+			return v.errorf(path, "expected a string, got %s", typeName(value))
+		}
+		return nil
+
+	case "bool":
+		if _, ok := value.(bool); !ok {
+			return v.errorf(path, "expected a bool, got %s", typeName(value))
+		}
+		return nil
+
+	case "double", "float", "int32", "int64", "uint32", "uint64", "sint32", "sint64",
+		"fixed32", "fixed64", "sfixed32", "sfixed64":
+		// the JSON encoding allows numbers to be quoted, 64-bit integers are always quoted.
+		switch n := value.(type) {
+		case float64, int, int64, uint64:
+			return nil
+		case string:
+			if _, err := strconv.ParseFloat(n, 64); err == nil {
+				return nil
+			}
+		}
+		return v.errorf(path, "expected a number (%s), got %s", typ, typeName(value))
+	}
+
+	name, ok := v.messages.Lookup(v.pkg, in, typ)
+	if !ok {
+		// enums and messages defined outside of the module are not checked.
+		return nil
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return v.errorf(path, "expected an object (%s), got %s", typ, typeName(value))
+	}
+
+	return v.validateMessage(name, object, path)
+}
+
+// findField finds the field named key either by its proto name or by its JSON name.
+func findField(fields []protoanalysis.Field, key string) (protoanalysis.Field, bool) {
+	for _, field := range fields {
+		if field.Name == key || field.JSONName() == key {
+			return field, true
+		}
+	}
+	return protoanalysis.Field{}, false
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "a string"
+	case bool:
+		return "a bool"
+	case float64, int, int64, uint64:
+		return "a number"
+	case []interface{}:
+		return "a list"
+	case map[string]interface{}:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,91 @@
+package module
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/starport/starport/pkg/protoanalysis"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	m := Module{
+		Name: "blog",
+		Pkg:  protoanalysis.Package{Name: "blog.blog", Path: "testdata/genesis"},
+	}
+
+	tests := []struct {
+		name    string
+		genesis map[string]interface{}
+		err     error
+	}{
+		{
+			name: "valid",
+			genesis: map[string]interface{}{
+				"postList": []interface{}{
+					map[string]interface{}{
+						"creator":   "cosmos1abc",
+						"id":        "1",
+						"title":     "hello",
+						"published": true,
+						"meta":      map[string]interface{}{"tags": []interface{}{"news"}},
+						"tip":       map[string]interface{}{"denom": "token", "amount": "10"},
+					},
+				},
+				"postCount": float64(1),
+				"params": map[string]interface{}{
+					"featured": map[string]interface{}{
+						"first": map[string]interface{}{"id": float64(1)},
+					},
+				},
+			},
+		},
+		{
+			name: "unknown field",
+			genesis: map[string]interface{}{
+				"posts": []interface{}{},
+			},
+			err: &GenesisError{"blog", "posts", "unknown field, GenesisState has no such field"},
+		},
+		{
+			name: "list expected",
+			genesis: map[string]interface{}{
+				"postList": map[string]interface{}{},
+			},
+			err: &GenesisError{"blog", "postList", "expected a list of Post, got an object"},
+		},
+		{
+			name: "invalid nested value",
+			genesis: map[string]interface{}{
+				"postList": []interface{}{
+					map[string]interface{}{"title": "hello"},
+					map[string]interface{}{"title": float64(1)},
+				},
+			},
+			err: &GenesisError{"blog", "postList[1].title", "expected a string, got a number"},
+		},
+		{
+			name: "invalid nested message value",
+			genesis: map[string]interface{}{
+				"postList": []interface{}{
+					map[string]interface{}{"meta": map[string]interface{}{"tags": "news"}},
+				},
+			},
+			err: &GenesisError{"blog", "postList[0].meta.tags", "expected a list of string, got a string"},
+		},
+		{
+			name: "invalid number",
+			genesis: map[string]interface{}{
+				"postCount": "many",
+			},
+			err: &GenesisError{"blog", "postCount", "expected a number (uint64), got a string"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.ValidateGenesis(context.Background(), tt.genesis)
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
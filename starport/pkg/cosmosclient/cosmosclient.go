@@ -79,6 +79,9 @@ type Client struct {
 	homePath           string
 	keyringServiceName string
 	keyringBackend     cosmosaccount.KeyringBackend
+
+	// sequences keeps track of the sequences of the accounts broadcasting txs.
+	sequences *sequenceTracker
 }
 
 // Option configures your client.
@@ -145,6 +148,7 @@ func New(ctx context.Context, options ...Option) (Client, error) {
 		faucetDenom:     defaultFaucetDenom,
 		faucetMinAmount: defaultFaucetMinAmount,
 		out:             io.Discard,
+		sequences:       newSequenceTracker(),
 	}
 
 	var err error
@@ -248,6 +252,48 @@ func (c Client) BroadcastTx(accountName string, msgs ...sdktypes.Msg) (Response,
 	return broadcast()
 }
 
+// BankSend creates and broadcasts a tx that sends amount from account to toAddress.
+func (c Client) BankSend(accountName, toAddress string, amount sdktypes.Coins) (Response, error) {
+	// TODO find a better way if possible.
+	mconf.Lock()
+	config := sdktypes.GetConfig()
+	config.SetBech32PrefixForAccount(c.addressPrefix, c.addressPrefix+"pub")
+	fromAddress, err := c.Address(accountName)
+	mconf.Unlock()
+	if err != nil {
+		return Response{}, err
+	}
+
+	return c.BroadcastTx(accountName, &banktypes.MsgSend{
+		FromAddress: fromAddress.String(),
+		ToAddress:   toAddress,
+		Amount:      amount,
+	})
+}
+
+// BankMultiSend creates and broadcasts a tx that sends coins from account to all outputs at once.
+func (c Client) BankMultiSend(accountName string, outputs []banktypes.Output) (Response, error) {
+	// TODO find a better way if possible.
+	mconf.Lock()
+	config := sdktypes.GetConfig()
+	config.SetBech32PrefixForAccount(c.addressPrefix, c.addressPrefix+"pub")
+	fromAddress, err := c.Address(accountName)
+	mconf.Unlock()
+	if err != nil {
+		return Response{}, err
+	}
+
+	var total sdktypes.Coins
+	for _, output := range outputs {
+		total = total.Add(output.Coins...)
+	}
+
+	return c.BroadcastTx(accountName, &banktypes.MsgMultiSend{
+		Inputs:  []banktypes.Input{banktypes.NewInput(fromAddress, total)},
+		Outputs: outputs,
+	})
+}
+
 // protects sdktypes.Config.
 var mconf sync.Mutex
 
@@ -286,22 +332,33 @@ func (c Client) BroadcastTxWithProvision(accountName string, msgs ...sdktypes.Ms
 	gas += 10000
 	txf = txf.WithGas(gas)
 
+	// reserve the sequence after the simulation since simulation requires the sequence known by the chain.
+	sequence := c.sequences.reserve(accountAddress.String(), txf.Sequence())
+	txf = txf.WithSequence(sequence)
+
 	// Return the provision function
 	return gas, func() (Response, error) {
 		txUnsigned, err := tx.BuildUnsignedTx(txf, msgs...)
 		if err != nil {
+			c.sequences.reset(accountAddress.String())
 			return Response{}, err
 		}
 		if err := tx.Sign(txf, accountName, txUnsigned, true); err != nil {
+			c.sequences.reset(accountAddress.String())
 			return Response{}, err
 		}
 
 		txBytes, err := context.TxConfig.TxEncoder()(txUnsigned.GetTx())
 		if err != nil {
+			c.sequences.reset(accountAddress.String())
 			return Response{}, err
 		}
 
 		resp, err := context.BroadcastTx(txBytes)
+		if err != nil || resp.Code > 0 {
+			// the reserved sequence may not be consumed, fetch it from the chain for the next tx.
+			c.sequences.reset(accountAddress.String())
+		}
 		return Response{
 			codec:      context.Codec,
 			TxResponse: resp,
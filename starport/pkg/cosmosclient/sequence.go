@@ -0,0 +1,47 @@
+package cosmosclient
+
+import "sync"
+
+// sequenceTracker keeps the next sequence numbers of the accounts broadcasting txs through
+// the client, so txs broadcasted concurrently by the same account don't reuse a sequence.
+type sequenceTracker struct {
+	mu   sync.Mutex
+	next map[string]uint64
+}
+
+func newSequenceTracker() *sequenceTracker {
+	return &sequenceTracker{
+		next: make(map[string]uint64),
+	}
+}
+
+// reserve returns the sequence to use for the next tx of address and reserves it.
+// chainSequence is the sequence of the account known by the chain.
+func (t *sequenceTracker) reserve(address string, chainSequence uint64) uint64 {
+	if t == nil {
+		return chainSequence
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sequence := chainSequence
+	if next, ok := t.next[address]; ok && next > sequence {
+		sequence = next
+	}
+	t.next[address] = sequence + 1
+
+	return sequence
+}
+
+// reset forgets the sequence of address so it is fetched from the chain for the next tx.
+func (t *sequenceTracker) reset(address string) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.next, address)
+}
@@ -0,0 +1,27 @@
+package cosmosclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceTracker(t *testing.T) {
+	tracker := newSequenceTracker()
+
+	// sequences are reserved one after the other even if the chain is not updated yet.
+	require.Equal(t, uint64(3), tracker.reserve("cosmos1a", 3))
+	require.Equal(t, uint64(4), tracker.reserve("cosmos1a", 3))
+	require.Equal(t, uint64(0), tracker.reserve("cosmos1b", 0))
+
+	// the chain's sequence wins when it is ahead.
+	require.Equal(t, uint64(10), tracker.reserve("cosmos1a", 10))
+
+	// the chain's sequence is used again after a reset.
+	tracker.reset("cosmos1a")
+	require.Equal(t, uint64(7), tracker.reserve("cosmos1a", 7))
+
+	// a nil tracker always uses the chain's sequence.
+	var nilTracker *sequenceTracker
+	require.Equal(t, uint64(5), nilTracker.reserve("cosmos1a", 5))
+}
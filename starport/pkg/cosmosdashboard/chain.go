@@ -0,0 +1,244 @@
+package cosmosdashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Block is a block of the chain.
+type Block struct {
+	Height int64     `json:"height"`
+	Hash   string    `json:"hash"`
+	Time   time.Time `json:"time"`
+	NumTxs int       `json:"num_txs"`
+}
+
+// Tx is a transaction of the chain with its decoded messages.
+type Tx struct {
+	Hash    string `json:"hash"`
+	Height  int64  `json:"height"`
+	Code    uint32 `json:"code"`
+	Log     string `json:"log,omitempty"`
+	GasUsed int64  `json:"gas_used"`
+	Memo    string `json:"memo,omitempty"`
+	Msgs    []Msg  `json:"msgs"`
+}
+
+// Msg is a decoded message of a transaction.
+type Msg struct {
+	// Type is the type URL of the message.
+	Type string `json:"type"`
+
+	// Module is the name of the module handling the message.
+	Module string `json:"module"`
+
+	// Name is the name of the message type.
+	Name string `json:"name"`
+
+	// Fields holds the decoded fields of the message.
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// AccountBalances is an account of the chain with its balances.
+type AccountBalances struct {
+	Name     string `json:"name"`
+	Address  string `json:"address"`
+	Balances []Coin `json:"balances"`
+}
+
+// Coin is a balance of an account.
+type Coin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+// LatestBlocks returns the latest blocks of the chain, the most recent first.
+func (d Dashboard) LatestBlocks(ctx context.Context) ([]Block, error) {
+	var out struct {
+		Result struct {
+			BlockMetas []struct {
+				BlockID struct {
+					Hash string `json:"hash"`
+				} `json:"block_id"`
+				Header struct {
+					Height string    `json:"height"`
+					Time   time.Time `json:"time"`
+				} `json:"header"`
+				NumTxs string `json:"num_txs"`
+			} `json:"block_metas"`
+		} `json:"result"`
+	}
+	if err := getJSON(ctx, d.rpcAddress+"/blockchain", &out); err != nil {
+		return nil, err
+	}
+
+	blocks := make([]Block, 0, len(out.Result.BlockMetas))
+	for _, meta := range out.Result.BlockMetas {
+		height, err := strconv.ParseInt(meta.Header.Height, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		numTxs, err := strconv.Atoi(meta.NumTxs)
+		if err != nil {
+			return nil, err
+		}
+
+		blocks = append(blocks, Block{
+			Height: height,
+			Hash:   meta.BlockID.Hash,
+			Time:   meta.Header.Time,
+			NumTxs: numTxs,
+		})
+
+		if len(blocks) == DefaultBlocksLimit {
+			break
+		}
+	}
+
+	return blocks, nil
+}
+
+// LatestTxs returns the transactions of the latest blocks, the most recent first.
+func (d Dashboard) LatestTxs(ctx context.Context) ([]Tx, error) {
+	blocks, err := d.LatestBlocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := []Tx{}
+	for _, b := range blocks {
+		if b.NumTxs == 0 {
+			continue
+		}
+
+		blockTxs, err := d.BlockTxs(ctx, b.Height)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, blockTxs...)
+	}
+
+	return txs, nil
+}
+
+// BlockTxs returns the transactions of the block at height.
+func (d Dashboard) BlockTxs(ctx context.Context, height int64) ([]Tx, error) {
+	var out struct {
+		Txs []struct {
+			Body struct {
+				Messages []map[string]interface{} `json:"messages"`
+				Memo     string                   `json:"memo"`
+			} `json:"body"`
+		} `json:"txs"`
+		TxResponses []struct {
+			TxHash  string `json:"txhash"`
+			Code    uint32 `json:"code"`
+			RawLog  string `json:"raw_log"`
+			GasUsed string `json:"gas_used"`
+		} `json:"tx_responses"`
+	}
+
+	query := url.Values{"events": []string{fmt.Sprintf("tx.height=%d", height)}}
+	if err := getJSON(ctx, d.apiAddress+"/cosmos/tx/v1beta1/txs?"+query.Encode(), &out); err != nil {
+		return nil, err
+	}
+	if len(out.Txs) != len(out.TxResponses) {
+		return nil, fmt.Errorf("got %d txs with %d responses", len(out.Txs), len(out.TxResponses))
+	}
+
+	txs := make([]Tx, 0, len(out.Txs))
+	for i, rawTx := range out.Txs {
+		resp := out.TxResponses[i]
+
+		gasUsed, err := strconv.ParseInt(resp.GasUsed, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		tx := Tx{
+			Hash:    resp.TxHash,
+			Height:  height,
+			Code:    resp.Code,
+			GasUsed: gasUsed,
+			Memo:    rawTx.Body.Memo,
+			Msgs:    []Msg{},
+		}
+
+		// the log is only useful to understand why a tx failed.
+		if resp.Code != 0 {
+			tx.Log = resp.RawLog
+		}
+
+		for _, fields := range rawTx.Body.Messages {
+			typeURL, _ := fields["@type"].(string)
+			delete(fields, "@type")
+
+			tx.Msgs = append(tx.Msgs, Msg{
+				Type:   typeURL,
+				Module: d.msgModule(typeURL),
+				Name:   msgName(typeURL),
+				Fields: fields,
+			})
+		}
+
+		txs = append(txs, tx)
+	}
+
+	// the txs of the block are listed in their execution order.
+	for i, j := 0, len(txs)-1; i < j; i, j = i+1, j-1 {
+		txs[i], txs[j] = txs[j], txs[i]
+	}
+
+	return txs, nil
+}
+
+// Accounts returns the accounts of the dashboard with their balances.
+func (d Dashboard) Accounts(ctx context.Context) ([]AccountBalances, error) {
+	accounts := make([]AccountBalances, 0, len(d.accounts))
+
+	for _, a := range d.accounts {
+		var out struct {
+			Balances []Coin `json:"balances"`
+		}
+		if err := getJSON(ctx, fmt.Sprintf("%s/cosmos/bank/v1beta1/balances/%s", d.apiAddress, a.address), &out); err != nil {
+			return nil, err
+		}
+
+		if out.Balances == nil {
+			out.Balances = []Coin{}
+		}
+
+		accounts = append(accounts, AccountBalances{
+			Name:     a.name,
+			Address:  a.address,
+			Balances: out.Balances,
+		})
+	}
+
+	return accounts, nil
+}
+
+// getJSON decodes the JSON response of a GET request to address into v.
+func getJSON(ctx context.Context, address string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", address, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
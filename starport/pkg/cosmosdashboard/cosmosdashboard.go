@@ -0,0 +1,130 @@
+// Package cosmosdashboard is a web dashboard to explore the blocks, transactions and
+// accounts of a chain in development.
+package cosmosdashboard
+
+import (
+	"strings"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosanalysis/module"
+)
+
+// DefaultBlocksLimit is the number of latest blocks displayed by the dashboard.
+const DefaultBlocksLimit = 20
+
+// Dashboard is a web dashboard of a chain.
+type Dashboard struct {
+	// chainID is the id of the chain.
+	chainID string
+
+	// rpcAddress is the address of the Tendermint RPC of the node.
+	rpcAddress string
+
+	// apiAddress is the address of the Cosmos SDK API of the node.
+	apiAddress string
+
+	// faucetAddress is the address of the faucet, the faucet is disabled when empty.
+	faucetAddress string
+
+	// accounts to display with their balances.
+	accounts []account
+
+	// msgModules holds the module names of the msgs by their URI.
+	msgModules map[string]string
+}
+
+type account struct {
+	name    string
+	address string
+}
+
+// Option configures the dashboard.
+type Option func(*Dashboard)
+
+// ChainID sets the id of the chain.
+func ChainID(id string) Option {
+	return func(d *Dashboard) {
+		d.chainID = id
+	}
+}
+
+// RPCAddress sets the address of the Tendermint RPC used to query the blocks.
+func RPCAddress(address string) Option {
+	return func(d *Dashboard) {
+		d.rpcAddress = address
+	}
+}
+
+// APIAddress sets the address of the API used to query the transactions and the balances.
+func APIAddress(address string) Option {
+	return func(d *Dashboard) {
+		d.apiAddress = address
+	}
+}
+
+// FaucetAddress enables requesting tokens from the faucet at address.
+func FaucetAddress(address string) Option {
+	return func(d *Dashboard) {
+		d.faucetAddress = address
+	}
+}
+
+// Account adds an account to display with its balances.
+func Account(name, address string) Option {
+	return func(d *Dashboard) {
+		d.accounts = append(d.accounts, account{name, address})
+	}
+}
+
+// Modules sets the modules of the chain used to find out the modules of the messages.
+func Modules(modules []module.Module) Option {
+	return func(d *Dashboard) {
+		for _, m := range modules {
+			for _, msg := range m.Msgs {
+				d.msgModules[msg.URI] = m.Name
+			}
+		}
+	}
+}
+
+// New creates a new dashboard.
+func New(options ...Option) Dashboard {
+	d := Dashboard{
+		msgModules: make(map[string]string),
+	}
+
+	for _, apply := range options {
+		apply(&d)
+	}
+
+	return d
+}
+
+// msgModule returns the module of the msg with typeURL, e.g. /cosmos.bank.v1beta1.MsgSend.
+// when the msg isn't one of the discovered modules, the module is the package of the msg
+// without its version.
+func (d Dashboard) msgModule(typeURL string) string {
+	uri := strings.TrimPrefix(typeURL, "/")
+	if name, ok := d.msgModules[uri]; ok {
+		return name
+	}
+
+	parts := strings.Split(uri, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	pkg := parts[:len(parts)-1]
+	if len(pkg) > 1 && isVersion(pkg[len(pkg)-1]) {
+		pkg = pkg[:len(pkg)-1]
+	}
+	return pkg[len(pkg)-1]
+}
+
+// isVersion checks if the proto package part s is a version, e.g. v1 or v1beta1.
+func isVersion(s string) bool {
+	return len(s) > 1 && s[0] == 'v' && s[1] >= '0' && s[1] <= '9'
+}
+
+// msgName returns the name of the msg with typeURL.
+func msgName(typeURL string) string {
+	return typeURL[strings.LastIndex(typeURL, ".")+1:]
+}
@@ -0,0 +1,179 @@
+package cosmosdashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/starport/starport/pkg/cosmosanalysis/module"
+)
+
+const (
+	blockchainResponse = `{"result":{"last_height":"3","block_metas":[
+		{"block_id":{"hash":"C3"},"header":{"height":"3","time":"2021-11-02T10:00:03Z"},"num_txs":"2"},
+		{"block_id":{"hash":"C2"},"header":{"height":"2","time":"2021-11-02T10:00:02Z"},"num_txs":"0"},
+		{"block_id":{"hash":"C1"},"header":{"height":"1","time":"2021-11-02T10:00:01Z"},"num_txs":"0"}
+	]}}`
+
+	txsResponse = `{
+		"txs":[
+			{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"cosmos1a","to_address":"cosmos1b"}],"memo":"hi"}},
+			{"body":{"messages":[{"@type":"/tendermint.mars.mars.MsgCreatePost","creator":"cosmos1a","title":"mars"}],"memo":""}}
+		],
+		"tx_responses":[
+			{"height":"3","txhash":"A1","code":0,"raw_log":"[]","gas_used":"50000"},
+			{"height":"3","txhash":"A2","code":5,"raw_log":"insufficient funds","gas_used":"40000"}
+		]
+	}`
+)
+
+func newTestDashboard(t *testing.T) Dashboard {
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/blockchain", r.URL.Path)
+		fmt.Fprint(w, blockchainResponse)
+	}))
+	t.Cleanup(rpc.Close)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/tx/v1beta1/txs":
+			require.Equal(t, "tx.height=3", r.URL.Query().Get("events"))
+			fmt.Fprint(w, txsResponse)
+		case "/cosmos/bank/v1beta1/balances/cosmos1a":
+			fmt.Fprint(w, `{"balances":[{"denom":"stake","amount":"100"}]}`)
+		case "/cosmos/bank/v1beta1/balances/cosmos1b":
+			fmt.Fprint(w, `{"balances":[]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(api.Close)
+
+	return New(
+		ChainID("mars"),
+		RPCAddress(rpc.URL),
+		APIAddress(api.URL),
+		Account("alice", "cosmos1a"),
+		Account("bob", "cosmos1b"),
+		Modules([]module.Module{
+			{
+				Name: "mars",
+				Msgs: []module.Msg{{Name: "MsgCreatePost", URI: "tendermint.mars.mars.MsgCreatePost"}},
+			},
+		}),
+	)
+}
+
+func get(t *testing.T, d Dashboard, path string, v interface{}) {
+	w := httptest.NewRecorder()
+	d.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.NewDecoder(w.Body).Decode(v))
+}
+
+func TestDashboardBlocks(t *testing.T) {
+	d := newTestDashboard(t)
+
+	var blocks []Block
+	get(t, d, "/api/blocks", &blocks)
+	require.Len(t, blocks, 3)
+	require.Equal(t, int64(3), blocks[0].Height)
+	require.Equal(t, "C3", blocks[0].Hash)
+	require.Equal(t, 2, blocks[0].NumTxs)
+}
+
+func TestDashboardTxs(t *testing.T) {
+	d := newTestDashboard(t)
+
+	var txs []Tx
+	get(t, d, "/api/txs", &txs)
+	require.Equal(t, []Tx{
+		{
+			Hash:    "A2",
+			Height:  3,
+			Code:    5,
+			Log:     "insufficient funds",
+			GasUsed: 40000,
+			Msgs: []Msg{
+				{
+					Type:   "/tendermint.mars.mars.MsgCreatePost",
+					Module: "mars",
+					Name:   "MsgCreatePost",
+					Fields: map[string]interface{}{"creator": "cosmos1a", "title": "mars"},
+				},
+			},
+		},
+		{
+			Hash:    "A1",
+			Height:  3,
+			GasUsed: 50000,
+			Memo:    "hi",
+			Msgs: []Msg{
+				{
+					Type:   "/cosmos.bank.v1beta1.MsgSend",
+					Module: "bank",
+					Name:   "MsgSend",
+					Fields: map[string]interface{}{"from_address": "cosmos1a", "to_address": "cosmos1b"},
+				},
+			},
+		},
+	}, txs)
+
+	var blockTxs []Tx
+	get(t, d, "/api/txs?height=3", &blockTxs)
+	require.Equal(t, txs, blockTxs)
+}
+
+func TestDashboardAccounts(t *testing.T) {
+	d := newTestDashboard(t)
+
+	var accounts []AccountBalances
+	get(t, d, "/api/accounts", &accounts)
+	require.Equal(t, []AccountBalances{
+		{Name: "alice", Address: "cosmos1a", Balances: []Coin{{Denom: "stake", Amount: "100"}}},
+		{Name: "bob", Address: "cosmos1b", Balances: []Coin{}},
+	}, accounts)
+}
+
+func TestMsgModule(t *testing.T) {
+	d := New()
+
+	cases := map[string]string{
+		"/cosmos.bank.v1beta1.MsgSend":              "bank",
+		"/ibc.applications.transfer.v1.MsgTransfer": "transfer",
+		"/cosmos.gov.v1beta1.MsgVote":               "gov",
+		"/tendermint.mars.mars.MsgCreatePost":       "mars",
+		"/cosmwasm.wasm.v1.MsgExecuteContract":      "wasm",
+		"/ibc.core.client.v1.MsgCreateClient":       "client",
+		"/planet.blog.MsgCreatePost":                "blog",
+		"/MsgUnknown":                               "",
+	}
+	for typeURL, name := range cases {
+		require.Equal(t, name, d.msgModule(typeURL), typeURL)
+	}
+}
+
+func TestDashboardIndex(t *testing.T) {
+	d := New(ChainID("mars"), RPCAddress("http://localhost:26657"))
+
+	w := httptest.NewRecorder()
+	d.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "<title>mars - Dashboard</title>")
+	require.Contains(t, w.Body.String(), `href="http://localhost:26657"`)
+	require.NotContains(t, w.Body.String(), `id="faucet"`)
+}
+
+func TestDashboardIndexFaucet(t *testing.T) {
+	d := New(ChainID("mars"), RPCAddress("http://localhost:26657"), FaucetAddress("http://localhost:4500"))
+
+	// the browser requests the tokens from the faucet directly.
+	w := httptest.NewRecorder()
+	d.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `id="faucet"`)
+	require.Contains(t, w.Body.String(), `const faucetAddress = "http://localhost:4500";`)
+}
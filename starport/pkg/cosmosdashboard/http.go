@@ -0,0 +1,116 @@
+package cosmosdashboard
+
+import (
+	_ "embed" // used for embedding the dashboard page.
+	"errors"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+)
+
+const fileNameIndex = "dashboard/index.html.tmpl"
+
+//go:embed dashboard/index.html.tmpl
+var bytesIndex []byte
+
+var tmplIndex = template.Must(template.New(fileNameIndex).Parse(string(bytesIndex)))
+
+// ServeHTTP implements http.Handler to serve the dashboard page and the API it uses.
+func (d Dashboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	router := mux.NewRouter()
+
+	router.HandleFunc("/", d.indexHandler).
+		Methods(http.MethodGet)
+
+	router.HandleFunc("/api/info", d.infoHandler).
+		Methods(http.MethodGet)
+
+	router.HandleFunc("/api/blocks", d.blocksHandler).
+		Methods(http.MethodGet)
+
+	router.HandleFunc("/api/txs", d.txsHandler).
+		Methods(http.MethodGet)
+
+	router.HandleFunc("/api/accounts", d.accountsHandler).
+		Methods(http.MethodGet)
+
+	router.ServeHTTP(w, r)
+}
+
+// InfoResponse describes the chain displayed by the dashboard.
+type InfoResponse struct {
+	ChainID       string `json:"chain_id"`
+	RPCAddress    string `json:"rpc_address"`
+	APIAddress    string `json:"api_address"`
+	FaucetAddress string `json:"faucet_address,omitempty"`
+}
+
+func (d Dashboard) indexHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	tmplIndex.Execute(w, d.info())
+}
+
+func (d Dashboard) infoHandler(w http.ResponseWriter, r *http.Request) {
+	xhttp.ResponseJSON(w, http.StatusOK, d.info())
+}
+
+func (d Dashboard) blocksHandler(w http.ResponseWriter, r *http.Request) {
+	blocks, err := d.LatestBlocks(r.Context())
+	if err != nil {
+		responseError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, blocks)
+}
+
+func (d Dashboard) txsHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		txs []Tx
+		err error
+	)
+
+	// the txs of the latest blocks are returned when no height is given.
+	if param := r.URL.Query().Get("height"); param != "" {
+		height, perr := strconv.ParseInt(param, 10, 64)
+		if perr != nil {
+			responseError(w, http.StatusBadRequest, errors.New("height must be a number"))
+			return
+		}
+		txs, err = d.BlockTxs(r.Context(), height)
+	} else {
+		txs, err = d.LatestTxs(r.Context())
+	}
+	if err != nil {
+		responseError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, txs)
+}
+
+func (d Dashboard) accountsHandler(w http.ResponseWriter, r *http.Request) {
+	accounts, err := d.Accounts(r.Context())
+	if err != nil {
+		responseError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, accounts)
+}
+
+func (d Dashboard) info() InfoResponse {
+	return InfoResponse{
+		ChainID:       d.chainID,
+		RPCAddress:    d.rpcAddress,
+		APIAddress:    d.apiAddress,
+		FaucetAddress: d.faucetAddress,
+	}
+}
+
+func responseError(w http.ResponseWriter, code int, err error) {
+	xhttp.ResponseJSON(w, code, xhttp.NewErrorResponse(err))
+}
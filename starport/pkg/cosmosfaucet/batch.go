@@ -0,0 +1,181 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// pendingTransfer is a transfer waiting in the batch to be sent.
+type pendingTransfer struct {
+	address string
+	coin    coin
+
+	// done receives the result of the transfer.
+	done chan error
+}
+
+// transferBatch collects the transfers requested during a window to pay them out together.
+type transferBatch struct {
+	mu      sync.Mutex
+	window  time.Duration
+	pending []*pendingTransfer
+}
+
+// add adds a transfer to the batch, the batch is flushed with flush once the window
+// started by its first transfer is over.
+func (b *transferBatch) add(t *pendingTransfer, flush func([]*pendingTransfer)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pending = append(b.pending, t)
+
+	if len(b.pending) == 1 {
+		time.AfterFunc(b.window, func() {
+			b.mu.Lock()
+			pending := b.pending
+			b.pending = nil
+			b.mu.Unlock()
+
+			flush(pending)
+		})
+	}
+}
+
+// transferBatched queues the transfer of coins to toAccountAddress into the current batch
+// and waits until the batch is paid out. an error is returned for each coin.
+// transfers are made one by one when batching is not enabled.
+func (f Faucet) transferBatched(ctx context.Context, toAccountAddress string, coins []coin) []error {
+	errs := make([]error, len(coins))
+
+	if f.batch == nil {
+		for i, c := range coins {
+			errs[i] = f.Transfer(ctx, toAccountAddress, c.amount, c.denom)
+		}
+		return errs
+	}
+
+	transfers := make([]*pendingTransfer, len(coins))
+	for i, c := range coins {
+		transfers[i] = &pendingTransfer{
+			address: toAccountAddress,
+			coin:    c,
+			done:    make(chan error, 1),
+		}
+		f.batch.add(transfers[i], f.transferMany)
+	}
+
+	for i, t := range transfers {
+		select {
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+		case errs[i] = <-t.done:
+		}
+	}
+
+	return errs
+}
+
+// transferMany checks the limits of the transfers and pays out the accepted ones.
+func (f Faucet) transferMany(transfers []*pendingTransfer) {
+	ctx := context.Background()
+
+	f.transferMu.Lock()
+	defer f.transferMu.Unlock()
+
+	var (
+		accepted []*pendingTransfer
+
+		// pendingTotals holds the amounts accepted in this batch by address and denom.
+		pendingTotals = make(map[string]uint64)
+	)
+
+	for _, t := range transfers {
+		key := t.address + "/" + t.coin.denom
+
+		totalSent, err := f.TotalTransferredAmount(ctx, t.address, t.coin.denom)
+		if err != nil {
+			t.done <- err
+			continue
+		}
+
+		if err := f.checkLimit(totalSent+pendingTotals[key], t.coin.amount, t.coin.denom); err != nil {
+			t.done <- err
+			continue
+		}
+
+		pendingTotals[key] += t.coin.amount
+		accepted = append(accepted, t)
+	}
+
+	if len(accepted) == 0 {
+		return
+	}
+
+	errs := f.sendMany(ctx, accepted)
+
+	for i, t := range accepted {
+		if errs[i] == nil {
+			errs[i] = f.limitStore.RecordTransfer(ctx, t.address, t.coin.denom, t.coin.amount, time.Now())
+		}
+		t.done <- errs[i]
+	}
+}
+
+// sendMany sends the coins of transfers in a single multi-send transaction when the sender
+// supports it. otherwise, the transfers are sent one by one.
+func (f Faucet) sendMany(ctx context.Context, transfers []*pendingTransfer) []error {
+	errs := make([]error, len(transfers))
+
+	multiSender, ok := f.sender.(MultiCoinSender)
+	if !ok {
+		for i, t := range transfers {
+			errs[i] = f.sender.SendCoins(ctx, f.accountName, t.address, t.coin.String())
+		}
+		return errs
+	}
+
+	// group the coins by address, keeping the order of the first request of each address.
+	var (
+		addresses []string
+		amounts   = make(map[string]map[string]uint64)
+	)
+	for _, t := range transfers {
+		if _, ok := amounts[t.address]; !ok {
+			addresses = append(addresses, t.address)
+			amounts[t.address] = make(map[string]uint64)
+		}
+		amounts[t.address][t.coin.denom] += t.coin.amount
+	}
+
+	outputs := make([]SendOutput, len(addresses))
+	for i, address := range addresses {
+		var denoms []string
+		for denom := range amounts[address] {
+			denoms = append(denoms, denom)
+		}
+		sort.Strings(denoms)
+
+		var coins []string
+		for _, denom := range denoms {
+			coins = append(coins, coin{amounts[address][denom], denom}.String())
+		}
+
+		outputs[i] = SendOutput{
+			Address: address,
+			Amount:  strings.Join(coins, ","),
+		}
+	}
+
+	if err := multiSender.MultiSendCoins(ctx, f.accountName, outputs); err != nil {
+		err = fmt.Errorf("cannot send the batch of %d transfers: %w", len(transfers), err)
+		for i := range errs {
+			errs[i] = err
+		}
+	}
+
+	return errs
+}
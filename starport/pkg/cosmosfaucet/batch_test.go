@@ -0,0 +1,101 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
+)
+
+type multiSenderMock struct {
+	mu      sync.Mutex
+	batches [][]SendOutput
+}
+
+func (s *multiSenderMock) SendCoins(context.Context, string, string, string) error {
+	panic("transfers must be sent in batches")
+}
+
+func (s *multiSenderMock) MultiSendCoins(_ context.Context, _ string, outputs []SendOutput) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.batches = append(s.batches, outputs)
+	return nil
+}
+
+// len returns the number of transfers waiting in the batch.
+func (b *transferBatch) len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.pending)
+}
+
+func TestTransferBatched(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		sender = &multiSenderMock{}
+	)
+
+	f, err := New(
+		ctx,
+		chaincmdrunner.Runner{},
+		ChainID("mars"),
+		Sender(sender),
+		BatchWindow(time.Second),
+		Coin(10, 25, "token"),
+	)
+	require.NoError(t, err)
+
+	requests := []struct {
+		address string
+		coins   []coin
+	}{
+		{"cosmos1a", []coin{{10, "token"}, {5, "stake"}}},
+		{"cosmos1b", []coin{{10, "token"}}},
+		{"cosmos1a", []coin{{10, "token"}}},
+		{"cosmos1a", []coin{{10, "token"}}},
+	}
+
+	var (
+		wg     sync.WaitGroup
+		errs   = make([][]error, len(requests))
+		queued int
+	)
+	for i, r := range requests {
+		i, r := i, r
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			errs[i] = f.transferBatched(ctx, r.address, r.coins)
+		}()
+
+		// make sure that the requests are queued in order.
+		queued += len(r.coins)
+		n := queued
+		require.Eventually(t, func() bool { return f.batch.len() == n }, time.Second, time.Millisecond)
+	}
+	wg.Wait()
+
+	require.Equal(t, []error{nil, nil}, errs[0])
+	require.Equal(t, []error{nil}, errs[1])
+	require.Equal(t, []error{nil}, errs[2])
+
+	// the last request exceeds the max amount of token allowed per account.
+	require.Len(t, errs[3], 1)
+	require.Error(t, errs[3][0])
+
+	require.Equal(t, [][]SendOutput{{
+		{Address: "cosmos1a", Amount: "5stake,20token"},
+		{Address: "cosmos1b", Amount: "10token"},
+	}}, sender.batches)
+
+	total, err := f.TotalTransferredAmount(ctx, "cosmos1a", "token")
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), total)
+}
@@ -19,28 +19,78 @@ func NewClient(addr string) HTTPClient {
 }
 
 // Transfer requests tokens from the faucet with req.
+// when the faucet requires a proof of work and req doesn't carry one, a challenge is
+// retrieved and solved before retrying the request.
 func (c HTTPClient) Transfer(ctx context.Context, req TransferRequest) (TransferResponse, error) {
-	data, err := json.Marshal(req)
+	res, status, err := c.transfer(ctx, req)
 	if err != nil {
 		return TransferResponse{}, err
 	}
 
+	if status == http.StatusPreconditionRequired && req.Nonce == "" {
+		challenge, err := c.Challenge(ctx)
+		if err != nil {
+			return TransferResponse{}, err
+		}
+
+		req.Challenge = challenge.Challenge
+		req.Nonce = SolveChallenge(challenge.Challenge, req.AccountAddress, challenge.Difficulty)
+
+		if res, status, err = c.transfer(ctx, req); err != nil {
+			return TransferResponse{}, err
+		}
+	}
+
+	if status != http.StatusOK {
+		return TransferResponse{}, errors.New(http.StatusText(status))
+	}
+
+	return res, nil
+}
+
+func (c HTTPClient) transfer(ctx context.Context, req TransferRequest) (res TransferResponse, status int, err error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return TransferResponse{}, 0, err
+	}
+
 	hreq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.addr, bytes.NewReader(data))
 	if err != nil {
-		return TransferResponse{}, err
+		return TransferResponse{}, 0, err
 	}
 
 	hres, err := http.DefaultClient.Do(hreq)
 	if err != nil {
-		return TransferResponse{}, err
+		return TransferResponse{}, 0, err
+	}
+	defer hres.Body.Close()
+
+	if hres.StatusCode != http.StatusOK {
+		return TransferResponse{}, hres.StatusCode, nil
+	}
+
+	err = json.NewDecoder(hres.Body).Decode(&res)
+	return res, hres.StatusCode, err
+}
+
+// Challenge retrieves a proof-of-work challenge to solve before requesting a transfer.
+func (c HTTPClient) Challenge(ctx context.Context) (ChallengeResponse, error) {
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.addr+"/challenge", nil)
+	if err != nil {
+		return ChallengeResponse{}, err
+	}
+
+	hres, err := http.DefaultClient.Do(hreq)
+	if err != nil {
+		return ChallengeResponse{}, err
 	}
 	defer hres.Body.Close()
 
 	if hres.StatusCode != http.StatusOK {
-		return TransferResponse{}, errors.New(http.StatusText(hres.StatusCode))
+		return ChallengeResponse{}, errors.New(http.StatusText(hres.StatusCode))
 	}
 
-	var res TransferResponse
+	var res ChallengeResponse
 	err = json.NewDecoder(hres.Body).Decode(&res)
 	return res, err
 }
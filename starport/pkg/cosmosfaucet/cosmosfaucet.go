@@ -4,6 +4,7 @@ package cosmosfaucet
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
@@ -31,9 +32,12 @@ const (
 
 // Faucet represents a faucet.
 type Faucet struct {
-	// runner used to intereact with blockchain's binary to transfer tokens.
+	// runner used to intereact with blockchain's binary.
 	runner chaincmdrunner.Runner
 
+	// sender used to transfer tokens, the blockchain's binary is used by default.
+	sender CoinSender
+
 	// chainID is the chain id of the chain that faucet is operating for.
 	chainID string
 
@@ -55,6 +59,21 @@ type Faucet struct {
 
 	limitRefreshWindow time.Duration
 
+	// limitStore keeps track of the transferred coins to enforce coinsMax.
+	limitStore LimitStore
+
+	// pow requires clients to solve a proof-of-work challenge before each transfer, disabled when nil.
+	pow *proofOfWork
+
+	// ipLimiter limits the number of transfer requests per IP, disabled when nil.
+	ipLimiter *ipRateLimiter
+
+	// batch collects the transfers to pay them out together, batching is disabled when nil.
+	batch *transferBatch
+
+	// transferMu serializes transfers so the limits are checked and recorded atomically.
+	transferMu *sync.Mutex
+
 	// openAPIData holds template data customizations for serving OpenAPI page & spec.
 	openAPIData openAPIData
 }
@@ -104,6 +123,50 @@ func RefreshWindow(refreshWindow time.Duration) Option {
 	}
 }
 
+// Store sets the store used to keep track of the transferred coins to enforce
+// the transfer limits. an in-memory store is used when it isn't provided.
+func Store(store LimitStore) Option {
+	return func(f *Faucet) {
+		f.limitStore = store
+	}
+}
+
+// Sender sets the sender used to transfer tokens. it can be used to sign and broadcast
+// the transfers in-process instead of through the blockchain's binary.
+func Sender(sender CoinSender) Option {
+	return func(f *Faucet) {
+		f.sender = sender
+	}
+}
+
+// BatchWindow enables batching of transfers. transfers requested during window are
+// collected and paid out together, in a single transaction when the sender supports it.
+func BatchWindow(window time.Duration) Option {
+	return func(f *Faucet) {
+		f.batch = &transferBatch{window: window}
+	}
+}
+
+// ProofOfWork requires clients to solve a hashcash-style challenge, with difficulty
+// leading zero bits, before each transfer request. challenges expire after ttl,
+// DefaultChallengeTTL is used when ttl is zero. difficulty cannot be above MaxProofOfWorkDifficulty.
+func ProofOfWork(difficulty uint, ttl time.Duration) Option {
+	return func(f *Faucet) {
+		if ttl == 0 {
+			ttl = DefaultChallengeTTL
+		}
+		f.pow = newProofOfWork(difficulty, ttl)
+	}
+}
+
+// IPRateLimit limits the number of transfer requests that can be made from an IP within window,
+// only the requests that pass the validation and the proof of work are counted.
+func IPRateLimit(requests int, window time.Duration) Option {
+	return func(f *Faucet) {
+		f.ipLimiter = newIPRateLimiter(requests, window)
+	}
+}
+
 // ChainID adds chain id to faucet. faucet will automatically fetch when it isn't provided.
 func ChainID(id string) Option {
 	return func(f *Faucet) {
@@ -122,9 +185,11 @@ func OpenAPI(apiAddress string) Option {
 func New(ctx context.Context, ccr chaincmdrunner.Runner, options ...Option) (Faucet, error) {
 	f := Faucet{
 		runner:      ccr,
+		sender:      runnerSender{ccr},
 		accountName: DefaultAccountName,
 		coinsMax:    make(map[string]uint64),
 		openAPIData: openAPIData{"Blockchain", "http://localhost:1317"},
+		transferMu:  &sync.Mutex{},
 	}
 
 	for _, apply := range options {
@@ -139,6 +204,14 @@ func New(ctx context.Context, ccr chaincmdrunner.Runner, options ...Option) (Fau
 		RefreshWindow(DefaultRefreshWindow)(&f)
 	}
 
+	if f.limitStore == nil {
+		Store(NewMemoryLimitStore())(&f)
+	}
+
+	if f.pow != nil && f.pow.difficulty > MaxProofOfWorkDifficulty {
+		return Faucet{}, fmt.Errorf("proof-of-work difficulty %d is above the maximum of %d", f.pow.difficulty, MaxProofOfWorkDifficulty)
+	}
+
 	// import the account if mnemonic is provided.
 	if f.accountMnemonic != "" {
 		_, err := f.runner.AddAccount(ctx, f.accountName, f.accountMnemonic, f.coinType)
@@ -3,9 +3,13 @@ package cosmosfaucet
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/tendermint/starport/starport/pkg/cosmoscoin"
+	"github.com/tendermint/starport/starport/pkg/cosmosutil"
 	"github.com/tendermint/starport/starport/pkg/xhttp"
 )
 
@@ -14,6 +18,9 @@ const (
 	statusError = "error"
 )
 
+// ErrTooManyRequests is returned when an IP exceeds the number of requests allowed.
+var ErrTooManyRequests = errors.New("too many requests, try again later")
+
 type TransferRequest struct {
 	// AccountAddress to request for coins.
 	AccountAddress string `json:"address"`
@@ -21,6 +28,13 @@ type TransferRequest struct {
 	// Coins that are requested.
 	// default ones used when this one isn't provided.
 	Coins []string `json:"coins"`
+
+	// Challenge is the proof-of-work challenge solved for this request.
+	// required only when the faucet has proof of work enabled.
+	Challenge string `json:"challenge,omitempty"`
+
+	// Nonce solves Challenge for AccountAddress.
+	Nonce string `json:"nonce,omitempty"`
 }
 
 type TransferResponse struct {
@@ -43,6 +57,24 @@ func (f Faucet) faucetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// validate the account address before queueing its transfers.
+	if _, err := cosmosutil.GetAddressPrefix(req.AccountAddress); err != nil {
+		responseError(w, http.StatusBadRequest, fmt.Errorf("invalid account address %q: %w", req.AccountAddress, err))
+		return
+	}
+
+	// verify the proof of work.
+	if f.pow != nil {
+		if err := f.pow.verify(req.Challenge, req.AccountAddress, req.Nonce); err != nil {
+			code := http.StatusForbidden
+			if err == ErrProofOfWorkRequired {
+				code = http.StatusPreconditionRequired
+			}
+			responseError(w, code, err)
+			return
+		}
+	}
+
 	// determine coins to transfer.
 	coins, err := f.coinsToTransfer(req)
 	if err != nil {
@@ -50,16 +82,24 @@ func (f Faucet) faucetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// only the valid requests count against the limit of the IP.
+	if f.ipLimiter != nil && !f.ipLimiter.allow(requestIP(r)) {
+		responseError(w, http.StatusTooManyRequests, ErrTooManyRequests)
+		return
+	}
+
 	// send coins and create a transfers response.
 	var transfers []Transfer
 
-	for _, coin := range coins {
+	errs := f.transferBatched(r.Context(), req.AccountAddress, coins)
+
+	for i, coin := range coins {
 		t := Transfer{
 			Coin:   coin.String(),
 			Status: statusOK,
 		}
 
-		if err := f.Transfer(r.Context(), req.AccountAddress, coin.amount, coin.denom); err != nil {
+		if err := errs[i]; err != nil {
 			if err == context.Canceled {
 				return
 			}
@@ -83,12 +123,59 @@ type FaucetInfoResponse struct {
 
 	// ChainID is chain id of the chain that faucet is running for.
 	ChainID string `json:"chain_id"`
+
+	// ProofOfWorkDifficulty is the difficulty of the challenges to solve before requesting
+	// transfers, it is zero when proof of work is not required.
+	ProofOfWorkDifficulty uint `json:"pow_difficulty,omitempty"`
 }
 
 func (f Faucet) faucetInfoHandler(w http.ResponseWriter, r *http.Request) {
-	xhttp.ResponseJSON(w, http.StatusOK, FaucetInfoResponse{
+	res := FaucetInfoResponse{
 		IsAFaucet: true,
 		ChainID:   f.chainID,
+	}
+	if f.pow != nil {
+		res.ProofOfWorkDifficulty = f.pow.difficulty
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, res)
+}
+
+// ChallengeResponse is a proof-of-work challenge to solve before requesting a transfer.
+type ChallengeResponse struct {
+	// Challenge to solve.
+	Challenge string `json:"challenge"`
+
+	// Difficulty is the number of leading zero bits required in the solution hash.
+	Difficulty uint `json:"difficulty"`
+
+	// ExpiresAt is the time after which the challenge cannot be used.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (f Faucet) challengeHandler(w http.ResponseWriter, r *http.Request) {
+	if f.pow == nil {
+		responseError(w, http.StatusNotFound, errors.New("proof of work is not enabled"))
+		return
+	}
+
+	challenge, expiresAt, err := f.pow.issue(requestIP(r))
+	switch {
+	case err == ErrTooManyRequests:
+		responseError(w, http.StatusTooManyRequests, err)
+		return
+	case err == ErrTooManyChallenges:
+		responseError(w, http.StatusServiceUnavailable, err)
+		return
+	case err != nil:
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, ChallengeResponse{
+		Challenge:  challenge,
+		Difficulty: f.pow.difficulty,
+		ExpiresAt:  expiresAt,
 	})
 }
 
@@ -0,0 +1,147 @@
+package cosmosfaucet
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/bits"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultChallengeTTL is the default duration a proof-of-work challenge can be solved in.
+	DefaultChallengeTTL = time.Minute * 5
+
+	// MaxProofOfWorkDifficulty is the highest difficulty that clients can be asked to solve.
+	MaxProofOfWorkDifficulty = 24
+
+	// challengesPerIP is the number of challenges an IP can request within challengeWindow.
+	challengesPerIP = 10
+	challengeWindow = time.Minute
+
+	// maxChallenges is the number of unsolved challenges the faucet keeps at most.
+	maxChallenges = 10000
+)
+
+var (
+	// ErrProofOfWorkRequired is returned when a transfer is requested without solving a challenge.
+	ErrProofOfWorkRequired = errors.New("proof of work is required, solve a challenge from /challenge")
+
+	// ErrInvalidChallenge is returned when a challenge is unknown, expired or already used.
+	ErrInvalidChallenge = errors.New("challenge is unknown, expired or already used")
+
+	// ErrInvalidProofOfWork is returned when the nonce doesn't solve the challenge.
+	ErrInvalidProofOfWork = errors.New("nonce doesn't solve the challenge")
+
+	// ErrTooManyChallenges is returned when the faucet already keeps the maximum number of unsolved challenges.
+	ErrTooManyChallenges = errors.New("too many pending challenges, try again later")
+)
+
+// proofOfWork is a hashcash-style gate. a challenge is solved by finding a nonce for which
+// sha256(challenge + address + nonce) starts with difficulty zero bits.
+type proofOfWork struct {
+	difficulty uint
+	ttl        time.Duration
+
+	// ipLimiter limits the number of challenges issued per IP.
+	ipLimiter *ipRateLimiter
+
+	mu         sync.Mutex
+	challenges map[string]time.Time
+}
+
+func newProofOfWork(difficulty uint, ttl time.Duration) *proofOfWork {
+	return &proofOfWork{
+		difficulty: difficulty,
+		ttl:        ttl,
+		ipLimiter:  newIPRateLimiter(challengesPerIP, challengeWindow),
+		challenges: make(map[string]time.Time),
+	}
+}
+
+// issue creates a new challenge for the client at ip and returns it with its expiration time.
+func (p *proofOfWork) issue(ip string) (challenge string, expiresAt time.Time, err error) {
+	if !p.ipLimiter.allow(ip) {
+		return "", time.Time{}, ErrTooManyRequests
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", time.Time{}, err
+	}
+
+	challenge = hex.EncodeToString(b)
+	expiresAt = time.Now().Add(p.ttl)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// forget the expired challenges.
+	now := time.Now()
+	for c, exp := range p.challenges {
+		if now.After(exp) {
+			delete(p.challenges, c)
+		}
+	}
+
+	if len(p.challenges) >= maxChallenges {
+		return "", time.Time{}, ErrTooManyChallenges
+	}
+
+	p.challenges[challenge] = expiresAt
+
+	return challenge, expiresAt, nil
+}
+
+// verify checks that nonce solves challenge for address. a challenge can only be used once.
+func (p *proofOfWork) verify(challenge, address, nonce string) error {
+	if challenge == "" || nonce == "" {
+		return ErrProofOfWorkRequired
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	expiresAt, ok := p.challenges[challenge]
+	if !ok || time.Now().After(expiresAt) {
+		delete(p.challenges, challenge)
+		return ErrInvalidChallenge
+	}
+
+	if !IsChallengeSolved(challenge, address, nonce, p.difficulty) {
+		return ErrInvalidProofOfWork
+	}
+
+	delete(p.challenges, challenge)
+
+	return nil
+}
+
+// IsChallengeSolved checks if nonce solves challenge for address with the given difficulty.
+func IsChallengeSolved(challenge, address, nonce string, difficulty uint) bool {
+	hash := sha256.Sum256([]byte(challenge + address + nonce))
+
+	var zeros uint
+	for _, b := range hash {
+		if b != 0 {
+			zeros += uint(bits.LeadingZeros8(b))
+			break
+		}
+		zeros += 8
+	}
+
+	return zeros >= difficulty
+}
+
+// SolveChallenge finds a nonce that solves challenge for address with the given difficulty.
+func SolveChallenge(challenge, address string, difficulty uint) string {
+	for i := uint64(0); ; i++ {
+		nonce := strconv.FormatUint(i, 10)
+		if IsChallengeSolved(challenge, address, nonce, difficulty) {
+			return nonce
+		}
+	}
+}
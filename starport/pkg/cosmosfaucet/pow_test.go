@@ -0,0 +1,149 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
+)
+
+func TestProofOfWork(t *testing.T) {
+	p := newProofOfWork(8, time.Minute)
+
+	challenge, _, err := p.issue("1.1.1.1")
+	require.NoError(t, err)
+
+	nonce := SolveChallenge(challenge, "cosmos1a", 8)
+	require.True(t, IsChallengeSolved(challenge, "cosmos1a", nonce, 8))
+
+	require.Equal(t, ErrProofOfWorkRequired, p.verify("", "cosmos1a", ""))
+	require.Equal(t, ErrInvalidChallenge, p.verify("unknown", "cosmos1a", nonce))
+	require.NoError(t, p.verify(challenge, "cosmos1a", nonce))
+
+	// a challenge can only be used once.
+	require.Equal(t, ErrInvalidChallenge, p.verify(challenge, "cosmos1a", nonce))
+}
+
+func TestProofOfWorkExpired(t *testing.T) {
+	p := newProofOfWork(1, time.Millisecond)
+
+	challenge, _, err := p.issue("1.1.1.1")
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	nonce := SolveChallenge(challenge, "cosmos1a", 1)
+	require.Equal(t, ErrInvalidChallenge, p.verify(challenge, "cosmos1a", nonce))
+}
+
+func TestProofOfWorkIssueLimits(t *testing.T) {
+	p := newProofOfWork(1, time.Minute)
+
+	// challenges are limited per IP.
+	for i := 0; i < challengesPerIP; i++ {
+		_, _, err := p.issue("1.1.1.1")
+		require.NoError(t, err)
+	}
+	_, _, err := p.issue("1.1.1.1")
+	require.Equal(t, ErrTooManyRequests, err)
+
+	// the number of unsolved challenges is bounded.
+	for i := len(p.challenges); i < maxChallenges; i++ {
+		p.challenges[strconv.Itoa(i)] = time.Now().Add(time.Minute)
+	}
+	_, _, err = p.issue("2.2.2.2")
+	require.Equal(t, ErrTooManyChallenges, err)
+}
+
+func TestProofOfWorkMaxDifficulty(t *testing.T) {
+	_, err := New(
+		context.Background(),
+		chaincmdrunner.Runner{},
+		ChainID("mars"),
+		ProofOfWork(MaxProofOfWorkDifficulty+1, 0),
+	)
+	require.Error(t, err)
+}
+
+func TestIPRateLimiter(t *testing.T) {
+	l := newIPRateLimiter(2, 20*time.Millisecond)
+
+	require.True(t, l.allow("1.1.1.1"))
+	require.True(t, l.allow("1.1.1.1"))
+	require.False(t, l.allow("1.1.1.1"))
+	require.True(t, l.allow("2.2.2.2"))
+
+	// requests are allowed again once the window is over.
+	time.Sleep(25 * time.Millisecond)
+	require.True(t, l.allow("1.1.1.1"))
+}
+
+func TestHTTPClientTransferWithProofOfWork(t *testing.T) {
+	ctx := context.Background()
+
+	f, err := New(
+		ctx,
+		chaincmdrunner.Runner{},
+		ChainID("mars"),
+		Sender(&multiSenderMock{}),
+		BatchWindow(time.Millisecond),
+		ProofOfWork(4, 0),
+	)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(f)
+	defer server.Close()
+
+	const address = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+
+	res, err := NewClient(server.URL).Transfer(ctx, TransferRequest{AccountAddress: address})
+	require.NoError(t, err)
+	require.Equal(t, []Transfer{{Coin: "10000000uatom", Status: statusOK}}, res.Transfers)
+
+	// a wrong nonce is rejected.
+	_, err = NewClient(server.URL).Transfer(ctx, TransferRequest{
+		AccountAddress: address,
+		Challenge:      "unknown",
+		Nonce:          "1",
+	})
+	require.EqualError(t, err, http.StatusText(http.StatusForbidden))
+
+	// an invalid address is rejected before being queued.
+	_, err = NewClient(server.URL).Transfer(ctx, TransferRequest{AccountAddress: "cosmos1a"})
+	require.EqualError(t, err, http.StatusText(http.StatusBadRequest))
+}
+
+func TestHTTPClientTransferWithIPRateLimit(t *testing.T) {
+	ctx := context.Background()
+
+	f, err := New(
+		ctx,
+		chaincmdrunner.Runner{},
+		ChainID("mars"),
+		Sender(&multiSenderMock{}),
+		BatchWindow(time.Millisecond),
+		ProofOfWork(4, 0),
+		IPRateLimit(1, time.Hour),
+	)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(f)
+	defer server.Close()
+
+	const address = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+
+	// the invalid requests and the requests without proof of work don't count against the limit.
+	_, err = NewClient(server.URL).Transfer(ctx, TransferRequest{AccountAddress: "cosmos1a"})
+	require.EqualError(t, err, http.StatusText(http.StatusBadRequest))
+
+	res, err := NewClient(server.URL).Transfer(ctx, TransferRequest{AccountAddress: address})
+	require.NoError(t, err)
+	require.Equal(t, []Transfer{{Coin: "10000000uatom", Status: statusOK}}, res.Transfers)
+
+	_, err = NewClient(server.URL).Transfer(ctx, TransferRequest{AccountAddress: address})
+	require.EqualError(t, err, http.StatusText(http.StatusTooManyRequests))
+}
@@ -0,0 +1,68 @@
+package cosmosfaucet
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ipRateLimiter limits the number of requests made by each IP within a window.
+type ipRateLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu      sync.Mutex
+	windows map[string]ipWindow
+}
+
+// ipWindow holds the requests made by an IP since the window start.
+type ipWindow struct {
+	start    time.Time
+	requests int
+}
+
+func newIPRateLimiter(limit int, window time.Duration) *ipRateLimiter {
+	return &ipRateLimiter{
+		limit:   limit,
+		window:  window,
+		windows: make(map[string]ipWindow),
+	}
+}
+
+// allow records a request from ip and checks if it is allowed.
+func (l *ipRateLimiter) allow(ip string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+
+	// forget the windows that are over.
+	for addr, w := range l.windows {
+		if now.Sub(w.start) >= l.window {
+			delete(l.windows, addr)
+		}
+	}
+
+	w, ok := l.windows[ip]
+	if !ok {
+		w = ipWindow{start: now}
+	}
+	if w.requests >= l.limit {
+		return false
+	}
+
+	w.requests++
+	l.windows[ip] = w
+
+	return true
+}
+
+// requestIP returns the IP of the client that made r.
+func requestIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
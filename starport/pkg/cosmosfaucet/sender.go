@@ -0,0 +1,43 @@
+package cosmosfaucet
+
+import (
+	"context"
+
+	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
+)
+
+// CoinSender sends the coins distributed by the faucet.
+type CoinSender interface {
+	// SendCoins sends amount of coins from the account named fromAccountName to toAddress.
+	SendCoins(ctx context.Context, fromAccountName, toAddress, amount string) error
+}
+
+// runnerSender sends coins through the chain's binary.
+type runnerSender struct {
+	runner chaincmdrunner.Runner
+}
+
+// SendCoins implements CoinSender.
+func (s runnerSender) SendCoins(ctx context.Context, fromAccountName, toAddress, amount string) error {
+	fromAccount, err := s.runner.ShowAccount(ctx, fromAccountName)
+	if err != nil {
+		return err
+	}
+
+	return s.runner.BankSend(ctx, fromAccount.Address, toAddress, amount)
+}
+
+// SendOutput is an address and the amount of coins to send to it.
+type SendOutput struct {
+	Address string
+	Amount  string
+}
+
+// MultiCoinSender is a CoinSender that can send coins to many addresses in a single transaction.
+// batched transfers are paid out with a single transaction when the faucet's sender implements it.
+type MultiCoinSender interface {
+	CoinSender
+
+	// MultiSendCoins sends coins from the account named fromAccountName to all outputs.
+	MultiSendCoins(ctx context.Context, fromAccountName string, outputs []SendOutput) error
+}
@@ -0,0 +1,172 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// LimitStore keeps track of the coins transferred by the faucet so the transfer
+// limits can be enforced without querying the chain.
+type LimitStore interface {
+	// TotalTransferred returns the total amount of denom transferred to address since the given time.
+	TotalTransferred(ctx context.Context, address, denom string, since time.Time) (uint64, error)
+
+	// RecordTransfer records a transfer of amount of denom made to address at the given time.
+	RecordTransfer(ctx context.Context, address, denom string, amount uint64, at time.Time) error
+}
+
+// transferRecord is a transfer recorded by a limit store.
+type transferRecord struct {
+	Amount uint64    `json:"amount"`
+	Time   time.Time `json:"time"`
+}
+
+// transferRecords holds transfer records by address and denom.
+type transferRecords map[string]map[string][]transferRecord
+
+func (r transferRecords) total(address, denom string, since time.Time) (amount uint64) {
+	for _, record := range r[address][denom] {
+		if record.Time.After(since) {
+			amount += record.Amount
+		}
+	}
+	return amount
+}
+
+func (r transferRecords) add(address, denom string, amount uint64, at time.Time) {
+	if r[address] == nil {
+		r[address] = make(map[string][]transferRecord)
+	}
+	r[address][denom] = append(r[address][denom], transferRecord{amount, at})
+}
+
+// MemoryLimitStore is a LimitStore that keeps the transfers in memory.
+type MemoryLimitStore struct {
+	mu      sync.Mutex
+	records transferRecords
+}
+
+// NewMemoryLimitStore creates a new in-memory limit store.
+func NewMemoryLimitStore() *MemoryLimitStore {
+	return &MemoryLimitStore{
+		records: make(transferRecords),
+	}
+}
+
+// TotalTransferred implements LimitStore.
+func (s *MemoryLimitStore) TotalTransferred(_ context.Context, address, denom string, since time.Time) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.records.total(address, denom, since), nil
+}
+
+// RecordTransfer implements LimitStore.
+func (s *MemoryLimitStore) RecordTransfer(_ context.Context, address, denom string, amount uint64, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records.add(address, denom, amount, at)
+	return nil
+}
+
+// FileLimitStore is a LimitStore that persists the transfers in a JSON file
+// so the limits survive faucet restarts.
+type FileLimitStore struct {
+	mu      sync.Mutex
+	path    string
+	records transferRecords
+}
+
+// NewFileLimitStore creates a new limit store persisted at path.
+// transfers previously recorded at path are loaded.
+func NewFileLimitStore(path string) (*FileLimitStore, error) {
+	s := &FileLimitStore{
+		path:    path,
+		records: make(transferRecords),
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &s.records); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// TotalTransferred implements LimitStore.
+func (s *FileLimitStore) TotalTransferred(_ context.Context, address, denom string, since time.Time) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.records.total(address, denom, since), nil
+}
+
+// RecordTransfer implements LimitStore.
+func (s *FileLimitStore) RecordTransfer(_ context.Context, address, denom string, amount uint64, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records.add(address, denom, amount, at)
+
+	return s.save()
+}
+
+// Prune removes the transfers recorded before the given time.
+func (s *FileLimitStore) Prune(before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for address, denoms := range s.records {
+		for denom, records := range denoms {
+			var kept []transferRecord
+			for _, record := range records {
+				if record.Time.After(before) {
+					kept = append(kept, record)
+				}
+			}
+			if len(kept) == 0 {
+				delete(denoms, denom)
+				continue
+			}
+			denoms[denom] = kept
+		}
+		if len(denoms) == 0 {
+			delete(s.records, address)
+		}
+	}
+
+	return s.save()
+}
+
+// save writes the records to the store file, the file is replaced atomically.
+func (s *FileLimitStore) save() error {
+	data, err := json.Marshal(s.records)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,64 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLimitStore(t *testing.T) {
+	testLimitStore(t, NewMemoryLimitStore())
+}
+
+func TestFileLimitStore(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		path = filepath.Join(t.TempDir(), "limits.json")
+	)
+
+	s, err := NewFileLimitStore(path)
+	require.NoError(t, err)
+	testLimitStore(t, s)
+
+	// records are loaded back from the file.
+	s, err = NewFileLimitStore(path)
+	require.NoError(t, err)
+	total, err := s.TotalTransferred(ctx, "cosmos1a", "token", time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), total)
+
+	// old records are pruned.
+	require.NoError(t, s.Prune(time.Now().Add(-time.Minute)))
+	s, err = NewFileLimitStore(path)
+	require.NoError(t, err)
+	total, err = s.TotalTransferred(ctx, "cosmos1a", "token", time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), total)
+}
+
+func testLimitStore(t *testing.T, s LimitStore) {
+	var (
+		ctx = context.Background()
+		now = time.Now()
+	)
+
+	require.NoError(t, s.RecordTransfer(ctx, "cosmos1a", "token", 10, now.Add(-time.Hour)))
+	require.NoError(t, s.RecordTransfer(ctx, "cosmos1a", "token", 20, now))
+	require.NoError(t, s.RecordTransfer(ctx, "cosmos1a", "stake", 5, now))
+	require.NoError(t, s.RecordTransfer(ctx, "cosmos1b", "token", 7, now))
+
+	total, err := s.TotalTransferred(ctx, "cosmos1a", "token", time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), total)
+
+	total, err = s.TotalTransferred(ctx, "cosmos1a", "token", now.Add(-time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), total)
+
+	total, err = s.TotalTransferred(ctx, "cosmos1c", "token", time.Time{})
+	require.NoError(t, err)
+	require.Zero(t, total)
+}
@@ -3,51 +3,20 @@ package cosmosfaucet
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"strings"
 	"time"
-
-	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
 )
 
-// TotalTransferredAmount returns the total transferred amount from faucet account to toAccountAddress.
+// TotalTransferredAmount returns the total transferred amount from faucet account to toAccountAddress
+// within the limit refresh window.
 func (f Faucet) TotalTransferredAmount(ctx context.Context, toAccountAddress, denom string) (amount uint64, err error) {
-	fromAccount, err := f.runner.ShowAccount(ctx, f.accountName)
-	if err != nil {
-		return 0, err
-	}
-
-	events, err := f.runner.QueryTxEvents(ctx,
-		chaincmdrunner.NewEventSelector("message", "sender", fromAccount.Address),
-		chaincmdrunner.NewEventSelector("transfer", "recipient", toAccountAddress))
-	if err != nil {
-		return 0, err
-	}
-
-	for _, event := range events {
-		if event.Type == "transfer" {
-			for _, attr := range event.Attributes {
-				if attr.Key == "amount" {
-					if !strings.HasSuffix(attr.Value, denom) {
-						continue
-					}
-
-					if time.Since(event.Time) < f.limitRefreshWindow {
-						amountStr := strings.TrimRight(attr.Value, denom)
-						if a, err := strconv.ParseUint(amountStr, 10, 64); err == nil {
-							amount += a
-						}
-					}
-				}
-			}
-		}
-	}
-
-	return amount, nil
+	return f.limitStore.TotalTransferred(ctx, toAccountAddress, denom, time.Now().Add(-f.limitRefreshWindow))
 }
 
 // Transfer transfer amount of tokens from the faucet account to toAccountAddress.
 func (f Faucet) Transfer(ctx context.Context, toAccountAddress string, amount uint64, denom string) error {
+	f.transferMu.Lock()
+	defer f.transferMu.Unlock()
+
 	amountStr := fmt.Sprintf("%d%s", amount, denom)
 
 	totalSent, err := f.TotalTransferredAmount(ctx, toAccountAddress, denom)
@@ -55,6 +24,20 @@ func (f Faucet) Transfer(ctx context.Context, toAccountAddress string, amount ui
 		return err
 	}
 
+	if err := f.checkLimit(totalSent, amount, denom); err != nil {
+		return err
+	}
+
+	if err := f.sender.SendCoins(ctx, f.accountName, toAccountAddress, amountStr); err != nil {
+		return err
+	}
+
+	return f.limitStore.RecordTransfer(ctx, toAccountAddress, denom, amount, time.Now())
+}
+
+// checkLimit checks if amount of denom can be transferred to an account that already
+// received totalSent.
+func (f Faucet) checkLimit(totalSent, amount uint64, denom string) error {
 	if f.coinsMax[denom] != 0 {
 		if totalSent >= f.coinsMax[denom] {
 			return fmt.Errorf("account has reached maximum credit allowed per account (%d)", f.coinsMax[denom])
@@ -64,11 +47,5 @@ func (f Faucet) Transfer(ctx context.Context, toAccountAddress string, amount ui
 			return fmt.Errorf("account is about to reach maximum credit allowed per account. it can only receive up to (%d) in total", f.coinsMax[denom])
 		}
 	}
-
-	fromAccount, err := f.runner.ShowAccount(ctx, f.accountName)
-	if err != nil {
-		return err
-	}
-
-	return f.runner.BankSend(ctx, fromAccount.Address, toAccountAddress, amountStr)
+	return nil
 }
@@ -33,9 +33,10 @@ type Version struct {
 }
 
 var (
-	MaxLaunchpadVersion      = newVersion("0.39.99", Launchpad)
-	StargateFortyVersion     = newVersion("0.40.0", Stargate)
-	StargateFortyFourVersion = newVersion("0.44.0-alpha", Stargate)
+	MaxLaunchpadVersion       = newVersion("0.39.99", Launchpad)
+	StargateFortyVersion      = newVersion("0.40.0", Stargate)
+	StargateFortyFourVersion  = newVersion("0.44.0-alpha", Stargate)
+	StargateFortySevenVersion = newVersion("0.47.0", Stargate)
 )
 
 var (
@@ -44,6 +45,7 @@ var (
 		MaxLaunchpadVersion,
 		StargateFortyVersion,
 		StargateFortyFourVersion,
+		StargateFortySevenVersion,
 	}
 
 	// Latest is the latest known version of the Cosmos-SDK.
@@ -74,6 +76,14 @@ func Parse(version string) (v Version, err error) {
 	return
 }
 
+// Release returns v without its prerelease and build metadata so the prereleases of
+// a version, like v0.47.0-rc1, compare as the version they are released as.
+func (v Version) Release() Version {
+	v.Semantic.Pre = nil
+	v.Semantic.Build = nil
+	return v
+}
+
 // GTE checks if v is greater than or equal to version.
 func (v Version) GTE(version Version) bool {
 	return v.Semantic.GTE(version.Semantic)
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 var ErrNoFile = errors.New("no file in specified paths")
@@ -68,9 +69,71 @@ func HasDirChecksumChanged(workdir string, paths []string, checksumSavePath stri
 	return true, nil
 }
 
+// ChecksumOption configures the computation of a checksum.
+type ChecksumOption func(*checksumOptions)
+
+type checksumOptions struct {
+	excludePaths []string
+	excludeExts  []string
+}
+
+// ExcludePaths excludes the files under the provided paths from the checksum
+// paths are relative to workdir, if workdir is empty string paths are absolute
+func ExcludePaths(paths ...string) ChecksumOption {
+	return func(o *checksumOptions) {
+		o.excludePaths = append(o.excludePaths, paths...)
+	}
+}
+
+// ExcludeExts excludes the files with matching file extensions from the checksum
+func ExcludeExts(exts ...string) ChecksumOption {
+	return func(o *checksumOptions) {
+		o.excludeExts = append(o.excludeExts, exts...)
+	}
+}
+
+// ChecksumFromPaths computes the md5 checksum from the provided paths (directories or files)
+// paths are relative to workdir, if workdir is empty string paths are absolute
+func ChecksumFromPaths(workdir string, paths []string, options ...ChecksumOption) ([]byte, error) {
+	var o checksumOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	excludedPaths := make([]string, len(o.excludePaths))
+	for i, path := range o.excludePaths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(workdir, path)
+		}
+		excludedPaths[i] = filepath.Clean(path)
+	}
+
+	isExcluded := func(path string) bool {
+		for _, excluded := range excludedPaths {
+			if path == excluded || strings.HasPrefix(path, excluded+string(filepath.Separator)) {
+				return true
+			}
+		}
+		for _, ext := range o.excludeExts {
+			if strings.HasSuffix(path, ext) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return checksum(workdir, paths, isExcluded)
+}
+
 // checksumFromPaths computes the md5 checksum from the provided paths
 // paths are relative to workdir, if workdir is empty string paths are absolute
 func checksumFromPaths(workdir string, paths []string) ([]byte, error) {
+	return checksum(workdir, paths, nil)
+}
+
+// checksum computes the md5 checksum from the provided paths, files are skipped
+// when isExcluded is not nil and returns true for them
+func checksum(workdir string, paths []string, isExcluded func(path string) bool) ([]byte, error) {
 	hash := md5.New()
 
 	// Can't compute hash if no file present
@@ -94,6 +157,13 @@ func checksumFromPaths(workdir string, paths []string) ([]byte, error) {
 				return err
 			}
 
+			if isExcluded != nil && isExcluded(subPath) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
 			// ignore directory
 			if info.IsDir() {
 				return nil
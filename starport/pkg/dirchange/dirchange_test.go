@@ -150,3 +150,38 @@ func TestHasDirChecksumChanged(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, changed)
 }
+
+func TestChecksumFromPathsExclude(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "x", "client"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "x", "keeper.go"), []byte("keeper"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "x", "types.pb.go"), []byte("types"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "x", "client", "index.ts"), []byte("client"), 0644))
+
+	options := []ChecksumOption{
+		ExcludePaths(filepath.Join("x", "client")),
+		ExcludeExts("pb.go"),
+	}
+
+	checksum, err := ChecksumFromPaths(dir, []string{"x"}, options...)
+	require.NoError(t, err)
+
+	// changes to excluded files don't change the checksum
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "x", "types.pb.go"), []byte("new types"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "x", "client", "index.ts"), []byte("new client"), 0644))
+	newChecksum, err := ChecksumFromPaths(dir, []string{"x"}, options...)
+	require.NoError(t, err)
+	require.Equal(t, checksum, newChecksum)
+
+	// changes to other files do
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "x", "keeper.go"), []byte("new keeper"), 0644))
+	newChecksum, err = ChecksumFromPaths(dir, []string{"x"}, options...)
+	require.NoError(t, err)
+	require.NotEqual(t, checksum, newChecksum)
+
+	// without options, all the files are part of the checksum
+	allChecksum, err := ChecksumFromPaths(dir, []string{"x"})
+	require.NoError(t, err)
+	require.NotEqual(t, newChecksum, allChecksum)
+}
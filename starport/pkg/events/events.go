@@ -2,7 +2,13 @@
 // for others to consume and display to end users in meaningful ways.
 package events
 
-import "fmt"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
 
 // Event represents a state.
 type Event struct {
@@ -11,6 +17,32 @@ type Event struct {
 
 	// Description of the state.
 	Description string
+
+	// kind identifies the state for machines, e.g. build-started.
+	kind string
+
+	// attributes holds the data of the state.
+	attributes map[string]interface{}
+}
+
+// Option configures an event.
+type Option func(*Event)
+
+// Kind sets the kind of the event.
+func Kind(kind string) Option {
+	return func(e *Event) {
+		e.kind = kind
+	}
+}
+
+// Attribute adds an attribute with key and value to the event.
+func Attribute(key string, value interface{}) Option {
+	return func(e *Event) {
+		if e.attributes == nil {
+			e.attributes = make(map[string]interface{})
+		}
+		e.attributes[key] = value
+	}
 }
 
 // Status shows if state is ongoing or completed.
@@ -21,9 +53,21 @@ const (
 	StatusDone
 )
 
+// String returns the name of the status.
+func (s Status) String() string {
+	if s == StatusOngoing {
+		return "ongoing"
+	}
+	return "done"
+}
+
 // New creates a new event with given config.
-func New(status Status, description string) Event {
-	return Event{status, description}
+func New(status Status, description string, options ...Option) Event {
+	e := Event{status: status, Description: description}
+	for _, apply := range options {
+		apply(&e)
+	}
+	return e
 }
 
 // IsOngoing checks if state change that triggered this event is still ongoing.
@@ -31,6 +75,16 @@ func (e Event) IsOngoing() bool {
 	return e.status == StatusOngoing
 }
 
+// Kind returns the kind of the event, it's empty for events that are only displayed.
+func (e Event) Kind() string {
+	return e.kind
+}
+
+// Attributes returns the attributes of the event.
+func (e Event) Attributes() map[string]interface{} {
+	return e.attributes
+}
+
 // Text returns the text state of event.
 func (e Event) Text() string {
 	if e.IsOngoing() {
@@ -39,6 +93,16 @@ func (e Event) Text() string {
 	return e.Description
 }
 
+// MarshalJSON encodes the event with its status, kind and attributes.
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Status      string                 `json:"status"`
+		Description string                 `json:"description"`
+		Kind        string                 `json:"kind,omitempty"`
+		Attributes  map[string]interface{} `json:"attributes,omitempty"`
+	}{e.status.String(), e.Description, e.kind, e.attributes})
+}
+
 // Bus is a send/receive event bus.
 type Bus chan Event
 
@@ -62,3 +126,36 @@ func (b Bus) Shutdown() {
 	}
 	close(b)
 }
+
+// Writer is an io.Writer that sends each line written to it as a done event to a bus.
+type Writer struct {
+	bus Bus
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// NewWriter creates a new writer that sends the lines written to it to bus.
+func NewWriter(bus Bus) *Writer {
+	return &Writer{bus: bus}
+}
+
+// Write writes p and sends its complete lines as events.
+func (w *Writer) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Write(p)
+	for {
+		line, err := w.buf.ReadString('\n')
+		if err != nil {
+			// keep the incomplete line for the next write.
+			w.buf.WriteString(line)
+			break
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			w.bus.Send(New(StatusDone, line))
+		}
+	}
+
+	return len(p), nil
+}
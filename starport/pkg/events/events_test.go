@@ -0,0 +1,53 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(New(StatusOngoing, "Building the app"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"status":"ongoing","description":"Building the app"}`, string(data))
+
+	data, err = json.Marshal(New(StatusDone, "Built"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"status":"done","description":"Built"}`, string(data))
+
+	data, err = json.Marshal(New(StatusDone, "Built", Kind("build-finished"), Attribute("duration_ms", 1200)))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"status": "done",
+		"description": "Built",
+		"kind": "build-finished",
+		"attributes": {"duration_ms": 1200}
+	}`, string(data))
+}
+
+func TestWriter(t *testing.T) {
+	bus := NewBus()
+
+	var got []Event
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for e := range bus {
+			got = append(got, e)
+		}
+	}()
+
+	w := NewWriter(bus)
+	fmt.Fprint(w, "🛠️  Building proto")
+	fmt.Fprint(w, "...\n\n💿 Initializing")
+	fmt.Fprintln(w, " the app...")
+	bus.Shutdown()
+	<-done
+
+	require.Equal(t, []Event{
+		New(StatusDone, "🛠️  Building proto..."),
+		New(StatusDone, "💿 Initializing the app..."),
+	}, got)
+}
@@ -0,0 +1,135 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// streamClientBuffer is the number of events buffered for each client of a stream,
+// events are dropped for clients that don't keep up.
+const streamClientBuffer = 64
+
+// Stream broadcasts the events of a bus to HTTP clients as server-sent events.
+type Stream struct {
+	mu      sync.Mutex
+	clients map[chan Event]struct{}
+	last    *Event
+	closed  bool
+}
+
+// NewStream creates a new stream.
+func NewStream() *Stream {
+	return &Stream{
+		clients: make(map[chan Event]struct{}),
+	}
+}
+
+// Run broadcasts the events received from bus until bus is shut down.
+func (s *Stream) Run(bus Bus) {
+	for e := range bus {
+		e := e
+
+		s.mu.Lock()
+		s.last = &e
+		for client := range s.clients {
+			select {
+			case client <- e:
+			default:
+			}
+		}
+		s.mu.Unlock()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for client := range s.clients {
+		close(client)
+		delete(s.clients, client)
+	}
+}
+
+// ServeHTTP streams the events to the client, starting with the last broadcasted event
+// to let the client know the current state.
+func (s *Stream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	client, ok := s.subscribe()
+	if !ok {
+		http.Error(w, "stream is closed", http.StatusServiceUnavailable)
+		return
+	}
+	defer s.unsubscribe(client)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case e, ok := <-client:
+			if !ok {
+				return
+			}
+			if err := writeServerSentEvent(w, e); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *Stream) subscribe() (chan Event, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, false
+	}
+
+	client := make(chan Event, streamClientBuffer)
+	if s.last != nil {
+		client <- *s.last
+	}
+	s.clients[client] = struct{}{}
+	return client, true
+}
+
+func (s *Stream) unsubscribe(client chan Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the client is already closed when the stream is done.
+	if _, ok := s.clients[client]; ok {
+		close(client)
+		delete(s.clients, client)
+	}
+}
+
+// writeServerSentEvent writes e as a server-sent event named by the kind of e.
+func writeServerSentEvent(w http.ResponseWriter, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if e.kind != "" {
+		if _, err := fmt.Fprintf(w, "event: %s\n", e.kind); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "data: %s\n\n", data)
+	return err
+}
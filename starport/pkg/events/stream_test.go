@@ -0,0 +1,49 @@
+package events
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream(t *testing.T) {
+	bus := NewBus()
+	stream := NewStream()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		stream.Run(bus)
+	}()
+
+	server := httptest.NewServer(stream)
+	defer server.Close()
+
+	// the client receives the last event on connect.
+	bus.Send(New(StatusOngoing, "Building the blockchain", Kind("build-started")))
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	bus.Send(New(StatusDone, "Blockchain built"))
+	bus.Shutdown()
+	<-done
+
+	var lines []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, []string{
+		"event: build-started",
+		`data: {"status":"ongoing","description":"Building the blockchain","kind":"build-started"}`,
+		"",
+		`data: {"status":"done","description":"Blockchain built"}`,
+		"",
+	}, lines)
+}
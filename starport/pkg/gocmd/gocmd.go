@@ -1,6 +1,7 @@
 package gocmd
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -28,18 +29,27 @@ const (
 
 	// CommandModVerify represents go mod "verify" command.
 	CommandModVerify = "verify"
+
+	// CommandEnv represents go "env" command.
+	CommandEnv = "env"
 )
 
 const (
 	FlagMod              = "-mod"
 	FlagModValueReadOnly = "readonly"
 	FlagLdflags          = "-ldflags"
+	FlagTags             = "-tags"
+	FlagTrimpath         = "-trimpath"
 	FlagOut              = "-o"
 )
 
 const (
 	EnvGOOS   = "GOOS"
 	EnvGOARCH = "GOARCH"
+	// EnvCGOEnabled enables or disables cgo.
+	EnvCGOEnabled = "CGO_ENABLED"
+	// EnvGOVERSION is the Go version of the toolchain, available since Go 1.16.
+	EnvGOVERSION = "GOVERSION"
 )
 
 // Name returns the name of Go binary to use.
@@ -61,6 +71,15 @@ func ModVerify(ctx context.Context, path string, options ...exec.Option) error {
 	return exec.Exec(ctx, []string{Name(), CommandMod, CommandModVerify}, append(options, exec.StepOption(step.Workdir(path)))...)
 }
 
+// Env returns the value of the Go environment variable name as reported by go env.
+func Env(ctx context.Context, name string) (string, error) {
+	out := &bytes.Buffer{}
+	if err := exec.Exec(ctx, []string{Name(), CommandEnv, name}, exec.StepOption(step.Stdout(out))); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
 // BuildPath runs go install on cmd folder with options.
 func BuildPath(ctx context.Context, output, binary, path string, flags []string, options ...exec.Option) error {
 	binaryOutput, err := binaryPath(output, binary)
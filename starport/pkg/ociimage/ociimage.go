@@ -0,0 +1,279 @@
+// Package ociimage creates single layer container images in the OCI image layout format
+// without needing a container runtime.
+// see https://github.com/opencontainers/image-spec/blob/main/image-layout.md.
+package ociimage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"time"
+)
+
+const (
+	mediaTypeIndex    = "application/vnd.oci.image.index.v1+json"
+	mediaTypeManifest = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeConfig   = "application/vnd.oci.image.config.v1+json"
+	mediaTypeLayer    = "application/vnd.oci.image.layer.v1.tar+gzip"
+
+	annotationRefName = "org.opencontainers.image.ref.name"
+
+	layoutVersion = "1.0.0"
+)
+
+// File is a file or a dir inside the image.
+type File struct {
+	// Path is the absolute path of the file inside the image.
+	Path string
+
+	// Data is the content of a regular file.
+	Data []byte
+
+	// Dir is true when the file is a dir.
+	Dir bool
+
+	// Mode holds the permission bits of the file.
+	Mode int64
+
+	// UID and GID own the file.
+	UID, GID int
+}
+
+// Config holds the runtime configuration of the image.
+type Config struct {
+	User         string              `json:"User,omitempty"`
+	ExposedPorts map[string]struct{} `json:"ExposedPorts,omitempty"`
+	Env          []string            `json:"Env,omitempty"`
+	Entrypoint   []string            `json:"Entrypoint,omitempty"`
+	Cmd          []string            `json:"Cmd,omitempty"`
+	WorkingDir   string              `json:"WorkingDir,omitempty"`
+	Labels       map[string]string   `json:"Labels,omitempty"`
+}
+
+// Image is a single layer image.
+type Image struct {
+	// Ref is the reference name of the image in the layout, typically its tag.
+	Ref string
+
+	// OS and Architecture of the image in GOOS and GOARCH notation.
+	OS, Architecture string
+
+	// Created is the creation time of the image, it's also the mod time of its files.
+	Created time.Time
+
+	Config Config
+
+	// Files are the contents of the image layer.
+	Files []File
+}
+
+type descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Platform    *platform         `json:"platform,omitempty"`
+}
+
+type platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+type rootFS struct {
+	Type    string   `json:"type"`
+	DiffIDs []string `json:"diff_ids"`
+}
+
+type imageConfig struct {
+	Created      string `json:"created"`
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Config       Config `json:"config"`
+	RootFS       rootFS `json:"rootfs"`
+}
+
+type manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Config        descriptor   `json:"config"`
+	Layers        []descriptor `json:"layers"`
+}
+
+type index struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Manifests     []descriptor `json:"manifests"`
+}
+
+// Write writes img to w as a tarball of an OCI image layout. the tarball is reproducible,
+// the same image always produces the same tarball.
+func Write(w io.Writer, img Image) error {
+	layer, diffID, err := createLayer(img.Files, img.Created)
+	if err != nil {
+		return err
+	}
+
+	config, err := json.Marshal(imageConfig{
+		Created:      img.Created.UTC().Format(time.RFC3339),
+		Architecture: img.Architecture,
+		OS:           img.OS,
+		Config:       img.Config,
+		RootFS: rootFS{
+			Type:    "layers",
+			DiffIDs: []string{diffID},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	manifestData, err := json.Marshal(manifest{
+		SchemaVersion: 2,
+		MediaType:     mediaTypeManifest,
+		Config:        newDescriptor(mediaTypeConfig, config),
+		Layers:        []descriptor{newDescriptor(mediaTypeLayer, layer)},
+	})
+	if err != nil {
+		return err
+	}
+
+	manifestDesc := newDescriptor(mediaTypeManifest, manifestData)
+	manifestDesc.Platform = &platform{
+		Architecture: img.Architecture,
+		OS:           img.OS,
+	}
+	if img.Ref != "" {
+		manifestDesc.Annotations = map[string]string{annotationRefName: img.Ref}
+	}
+
+	indexData, err := json.Marshal(index{
+		SchemaVersion: 2,
+		MediaType:     mediaTypeIndex,
+		Manifests:     []descriptor{manifestDesc},
+	})
+	if err != nil {
+		return err
+	}
+
+	layoutData, err := json.Marshal(map[string]string{"imageLayoutVersion": layoutVersion})
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	entries := []struct {
+		name string
+		data []byte
+	}{
+		{"oci-layout", layoutData},
+		{"index.json", indexData},
+		{blobPath(manifestData), manifestData},
+		{blobPath(config), config},
+		{blobPath(layer), layer},
+	}
+	for _, dir := range []string{"blobs/", "blobs/sha256/"} {
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeDir,
+			Name:     dir,
+			Mode:     0755,
+			ModTime:  img.Created.UTC(),
+		}); err != nil {
+			return err
+		}
+	}
+	for _, e := range entries {
+		if err := writeFile(tw, e.name, e.data, 0644, 0, 0, img.Created); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// createLayer creates the gzipped layer tarball of files and returns it with
+// the digest of its uncompressed content.
+func createLayer(files []File, modTime time.Time) (layer []byte, diffID string, err error) {
+	files = append([]File(nil), files...)
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	var (
+		buf bytes.Buffer
+		h   = sha256.New()
+	)
+
+	gw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, "", err
+	}
+	tw := tar.NewWriter(io.MultiWriter(gw, h))
+
+	for _, f := range files {
+		name := path.Clean(f.Path)[1:]
+		if f.Dir {
+			if err := tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeDir,
+				Name:     name + "/",
+				Mode:     f.Mode,
+				Uid:      f.UID,
+				Gid:      f.GID,
+				ModTime:  modTime.UTC(),
+			}); err != nil {
+				return nil, "", err
+			}
+			continue
+		}
+		if err := writeFile(tw, name, f.Data, f.Mode, f.UID, f.GID, modTime); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, "", err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), digest(h.Sum(nil)), nil
+}
+
+func writeFile(tw *tar.Writer, name string, data []byte, mode int64, uid, gid int, modTime time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     int64(len(data)),
+		Mode:     mode,
+		Uid:      uid,
+		Gid:      gid,
+		ModTime:  modTime.UTC(),
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+func newDescriptor(mediaType string, data []byte) descriptor {
+	sum := sha256.Sum256(data)
+	return descriptor{
+		MediaType: mediaType,
+		Digest:    digest(sum[:]),
+		Size:      int64(len(data)),
+	}
+}
+
+func blobPath(data []byte) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("blobs/sha256/%x", sum)
+}
+
+func digest(sum []byte) string {
+	return "sha256:" + hex.EncodeToString(sum)
+}
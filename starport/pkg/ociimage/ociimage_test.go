@@ -0,0 +1,98 @@
+package ociimage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	img := Image{
+		Ref:          "v1.0.0",
+		OS:           "linux",
+		Architecture: "amd64",
+		Created:      time.Unix(1600000000, 0),
+		Config: Config{
+			User:       "65532:65532",
+			Entrypoint: []string{"/usr/local/bin/entrypoint"},
+		},
+		Files: []File{
+			{Path: "/usr/local/bin/entrypoint", Data: []byte("entrypoint"), Mode: 0755},
+			{Path: "/usr/local/bin", Dir: true, Mode: 0755},
+			{Path: "/usr", Dir: true, Mode: 0755},
+			{Path: "/usr/local", Dir: true, Mode: 0755},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, img))
+
+	var again bytes.Buffer
+	require.NoError(t, Write(&again, img))
+	require.Equal(t, buf.Bytes(), again.Bytes(), "images must be reproducible")
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(&buf)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = data
+	}
+
+	require.JSONEq(t, `{"imageLayoutVersion":"1.0.0"}`, string(files["oci-layout"]))
+
+	// blobs are stored by their digest.
+	blob := func(d descriptor) []byte {
+		data, ok := files["blobs/sha256/"+d.Digest[len("sha256:"):]]
+		require.True(t, ok, "blob %s is missing", d.Digest)
+		require.Equal(t, fmt.Sprintf("sha256:%x", sha256.Sum256(data)), d.Digest)
+		require.EqualValues(t, len(data), d.Size)
+		return data
+	}
+
+	var idx index
+	require.NoError(t, json.Unmarshal(files["index.json"], &idx))
+	require.Len(t, idx.Manifests, 1)
+	require.Equal(t, "v1.0.0", idx.Manifests[0].Annotations[annotationRefName])
+
+	var m manifest
+	require.NoError(t, json.Unmarshal(blob(idx.Manifests[0]), &m))
+	require.Len(t, m.Layers, 1)
+
+	var config imageConfig
+	require.NoError(t, json.Unmarshal(blob(m.Config), &config))
+	require.Equal(t, "linux", config.OS)
+	require.Equal(t, img.Config.Entrypoint, config.Config.Entrypoint)
+
+	// the diff id is the digest of the uncompressed layer.
+	gr, err := gzip.NewReader(bytes.NewReader(blob(m.Layers[0])))
+	require.NoError(t, err)
+	layer, err := io.ReadAll(gr)
+	require.NoError(t, err)
+	require.Equal(t, []string{fmt.Sprintf("sha256:%x", sha256.Sum256(layer))}, config.RootFS.DiffIDs)
+
+	var names []string
+	lr := tar.NewReader(bytes.NewReader(layer))
+	for {
+		header, err := lr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, header.Name)
+	}
+	require.Equal(t, []string{"usr/", "usr/local/", "usr/local/bin/", "usr/local/bin/entrypoint"}, names)
+}
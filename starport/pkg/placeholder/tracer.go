@@ -32,7 +32,7 @@ func WithAdditionalInfo(info string) Option {
 
 // New instantiates Session with provided options.
 func New(opts ...Option) *Tracer {
-	s := &Tracer{missing: iterableStringSet{}}
+	s := &Tracer{missing: iterableStringSet{}, once: iterableStringSet{}}
 	for _, opt := range opts {
 		opt(s)
 	}
@@ -49,6 +49,7 @@ type Replacer interface {
 // Tracer keeps track of missing placeholders or other issues related to file modification.
 type Tracer struct {
 	missing        iterableStringSet
+	once           iterableStringSet
 	miscErrors     []string
 	additionalInfo string
 }
@@ -75,12 +76,23 @@ func (t *Tracer) Replace(content, placeholder, replacement string) string {
 
 // ReplaceOnce will replace placeholder in content only if replacement is not already found in content.
 func (t *Tracer) ReplaceOnce(content, placeholder, replacement string) string {
+	t.once.Add(replacement)
 	if !strings.Contains(content, replacement) {
 		return t.Replace(content, placeholder, replacement)
 	}
 	return content
 }
 
+// OnceReplacements returns the replacements passed to ReplaceOnce, the code they insert
+// can be shared by several modifications.
+func (t *Tracer) OnceReplacements() (replacements []string) {
+	t.once.Iterate(func(_ int, replacement string) bool {
+		replacements = append(replacements, replacement)
+		return true
+	})
+	return replacements
+}
+
 // AppendMiscError allows to track errors not related to missing placeholders during file modification
 func (t *Tracer) AppendMiscError(miscError string) {
 	t.miscErrors = append(t.miscErrors, miscError)
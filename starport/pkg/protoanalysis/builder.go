@@ -1,7 +1,6 @@
 package protoanalysis
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
 
@@ -21,6 +20,7 @@ func build(p pkg) Package {
 		Path:     p.dir,
 		Files:    br.buildFiles(),
 		Messages: br.buildMessages(),
+		Enums:    br.buildEnums(),
 		Services: br.toServices(p.services()),
 	}
 
@@ -60,26 +60,8 @@ func (b builder) buildMessages() (messages []Message) {
 			// some proto messages might be defined inside another proto messages.
 			// to represents these types, an underscore is used.
 			// e.g. if C message inside B, and B inside A: A_B_C.
-			var (
-				name   = message.Name
-				parent = message.Parent
-			)
-			for {
-				if parent == nil {
-					break
-				}
-
-				parentMessage, ok := parent.(*proto.Message)
-				if !ok {
-					break
-				}
-
-				name = fmt.Sprintf("%s_%s", parentMessage.Name, name)
-				parent = parentMessage.Parent
-			}
-
 			messages = append(messages, Message{
-				Name:               name,
+				Name:               messageName(message),
 				Path:               f.path,
 				HighestFieldNumber: highestFieldNumber,
 			})
@@ -89,6 +71,26 @@ func (b builder) buildMessages() (messages []Message) {
 	return messages
 }
 
+func (b builder) buildEnums() (enums []Enum) {
+	for _, f := range b.p.files {
+		for _, enum := range f.enums {
+			name := enum.Name
+
+			// like messages, an enum defined inside a message is prefixed with the message name.
+			if message, ok := enum.Parent.(*proto.Message); ok {
+				name = messageName(message) + "_" + name
+			}
+
+			enums = append(enums, Enum{
+				Name: name,
+				Path: f.path,
+			})
+		}
+	}
+
+	return enums
+}
+
 func (b builder) toServices(ps []*proto.Service) (services []Service) {
 	for _, service := range ps {
 		s := Service{
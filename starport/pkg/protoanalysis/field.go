@@ -0,0 +1,148 @@
+package protoanalysis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/emicklei/proto"
+)
+
+// Field is a field of a proto message.
+type Field struct {
+	// Name of the field as it is defined in the proto file.
+	Name string
+
+	// Type of the field. it is either a scalar type, or the name of a message or an enum.
+	Type string
+
+	// Repeated is true when the field is a list.
+	Repeated bool
+
+	// Map is true when the field is a map. Type is the type of the map values.
+	Map bool
+}
+
+// JSONName returns the lowerCamelCase name of the field used by the proto JSON encoding.
+func (f Field) JSONName() string {
+	var (
+		b     strings.Builder
+		upper bool
+	)
+	for _, r := range f.Name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = []rune(strings.ToUpper(string(r)))[0]
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// MessageFields holds the fields of proto messages by message name.
+// nested messages are named with an underscore, e.g. A_B for B defined inside A.
+type MessageFields map[string][]Field
+
+// Lookup returns the name of the message referenced by typ from the message named in of the
+// proto package pkg. typ can be a nested, a local or a fully qualified name, the messages of
+// other packages are not found.
+func (m MessageFields) Lookup(pkg, in, typ string) (name string, ok bool) {
+	// fully qualified names of messages from the same package.
+	if strings.HasPrefix(typ, ".") {
+		typ = strings.TrimPrefix(typ, ".")
+		if !strings.HasPrefix(typ, pkg+".") {
+			return "", false
+		}
+	}
+	if pkg != "" && strings.HasPrefix(typ, pkg+".") {
+		name = strings.ReplaceAll(strings.TrimPrefix(typ, pkg+"."), ".", "_")
+		_, ok = m[name]
+		return name, ok
+	}
+
+	name = strings.ReplaceAll(typ, ".", "_")
+
+	// nested messages are looked up from the innermost scope.
+	for scope := in; scope != ""; {
+		nested := fmt.Sprintf("%s_%s", scope, name)
+		if _, ok = m[nested]; ok {
+			return nested, true
+		}
+		i := strings.LastIndex(scope, "_")
+		if i < 0 {
+			break
+		}
+		scope = scope[:i]
+	}
+
+	if _, ok = m[name]; ok {
+		return name, true
+	}
+
+	return "", false
+}
+
+// ParseMessageFields parses the proto packages under path and returns the fields of their messages.
+func ParseMessageFields(ctx context.Context, path string) (MessageFields, error) {
+	parsed, err := parse(ctx, path, protoFilePattern)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(MessageFields)
+
+	for _, p := range parsed {
+		for _, message := range p.messages() {
+			var messageFields []Field
+
+			for _, elem := range message.Elements {
+				switch field := elem.(type) {
+				case *proto.NormalField:
+					messageFields = append(messageFields, Field{
+						Name:     field.Name,
+						Type:     field.Type,
+						Repeated: field.Repeated,
+					})
+				case *proto.MapField:
+					messageFields = append(messageFields, Field{
+						Name: field.Name,
+						Type: field.Type,
+						Map:  true,
+					})
+				case *proto.Oneof:
+					for _, oneofElem := range field.Elements {
+						if oneofField, ok := oneofElem.(*proto.OneOfField); ok {
+							messageFields = append(messageFields, Field{
+								Name: oneofField.Name,
+								Type: oneofField.Type,
+							})
+						}
+					}
+				}
+			}
+
+			fields[messageName(message)] = messageFields
+		}
+	}
+
+	return fields, nil
+}
+
+// messageName returns the name of the message, prefixed with the names of its parents
+// when it is a nested message.
+func messageName(message *proto.Message) string {
+	name := message.Name
+	for parent := message.Parent; parent != nil; {
+		parentMessage, ok := parent.(*proto.Message)
+		if !ok {
+			break
+		}
+		name = fmt.Sprintf("%s_%s", parentMessage.Name, name)
+		parent = parentMessage.Parent
+	}
+	return name
+}
@@ -0,0 +1,58 @@
+package protoanalysis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMessageFields(t *testing.T) {
+	fields, err := ParseMessageFields(context.Background(), "testdata/liquidity")
+	require.NoError(t, err)
+
+	require.Equal(t, []Field{
+		{Name: "params", Type: "Params"},
+		{Name: "pool_records", Type: "PoolRecord", Repeated: true},
+	}, fields["GenesisState"])
+
+	name, ok := fields.Lookup("tendermint.liquidity", "GenesisState", "PoolRecord")
+	require.True(t, ok)
+	require.Equal(t, "PoolRecord", name)
+	require.Len(t, fields[name], 6)
+
+	name, ok = fields.Lookup("tendermint.liquidity", "GenesisState", "tendermint.liquidity.Params")
+	require.True(t, ok)
+	require.Equal(t, "Params", name)
+
+	_, ok = fields.Lookup("tendermint.liquidity", "GenesisState", "cosmos.base.v1beta1.Coin")
+	require.False(t, ok)
+
+	// the messages of other packages are not resolved to the local messages with the same name.
+	_, ok = fields.Lookup("tendermint.liquidity", "GenesisState", "cosmos.bank.v1beta1.Params")
+	require.False(t, ok)
+	_, ok = fields.Lookup("tendermint.liquidity", "GenesisState", ".cosmos.bank.v1beta1.Params")
+	require.False(t, ok)
+}
+
+func TestParseNestedMessageFields(t *testing.T) {
+	fields, err := ParseMessageFields(context.Background(), "testdata/nested_messages")
+	require.NoError(t, err)
+
+	name, ok := fields.Lookup("nested_messages", "A_B", "C")
+	require.True(t, ok)
+	require.Equal(t, "A_B_C", name)
+
+	name, ok = fields.Lookup("nested_messages", "A", "B.C")
+	require.True(t, ok)
+	require.Equal(t, "A_B_C", name)
+
+	name, ok = fields.Lookup("nested_messages", "A", "nested_messages.A.B.C")
+	require.True(t, ok)
+	require.Equal(t, "A_B_C", name)
+}
+
+func TestFieldJSONName(t *testing.T) {
+	require.Equal(t, "poolRecords", Field{Name: "pool_records"}.JSONName())
+	require.Equal(t, "postList", Field{Name: "postList"}.JSONName())
+}
@@ -61,6 +61,7 @@ type file struct {
 	imports  []string // imported protos.
 	options  []*proto.Option
 	messages []*proto.Message
+	enums    []*proto.Enum
 	services []*proto.Service
 }
 
@@ -132,6 +133,7 @@ func (p *parser) parseFile(path string) error {
 		proto.WithImport(func(s *proto.Import) { pf.imports = append(pf.imports, s.Filename) }),
 		proto.WithOption(func(o *proto.Option) { pf.options = append(pf.options, o) }),
 		proto.WithMessage(func(m *proto.Message) { pf.messages = append(pf.messages, m) }),
+		proto.WithEnum(func(e *proto.Enum) { pf.enums = append(pf.enums, e) }),
 		proto.WithService(func(s *proto.Service) { pf.services = append(pf.services, s) }),
 	)
 
@@ -287,3 +287,15 @@ func TestLiquidity(t *testing.T) {
 
 	require.Equal(t, expected, packages)
 }
+
+func TestEnums(t *testing.T) {
+	packages, err := Parse(context.Background(), nil, "testdata/enums")
+	require.NoError(t, err)
+	require.Equal(t, []Enum{
+		{Name: "Status", Path: "testdata/enums/enums.proto"},
+		{Name: "A_Kind", Path: "testdata/enums/enums.proto"},
+	}, packages[0].Enums)
+
+	require.NoError(t, HasEnums(context.Background(), "testdata/enums", "Status", "A_Kind"))
+	require.Error(t, HasEnums(context.Background(), "testdata/enums", "A"))
+}
@@ -0,0 +1,97 @@
+// Package tarball creates reproducible gzipped tarballs.
+package tarball
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Create writes a gzipped tarball of the files under dir to w. the tarball is reproducible:
+// files are added in lexical order, with the same modTime and without owner information,
+// so the same files always produce the same tarball.
+func Create(w io.Writer, dir string, modTime time.Time) error {
+	gw, err := gzip.NewWriterLevel(w, gzip.BestCompression)
+	if err != nil {
+		return err
+	}
+	// gzip header has no name and mod time by default, keep it that way.
+	tw := tar.NewWriter(gw)
+
+	// WalkDir visits the files in lexical order.
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		header := &tar.Header{
+			Name:    filepath.ToSlash(name),
+			ModTime: modTime.UTC(),
+			Format:  tar.FormatPAX,
+		}
+
+		switch {
+		case info.IsDir():
+			header.Typeflag = tar.TypeDir
+			header.Name += "/"
+			header.Mode = 0755
+		case info.Mode().IsRegular():
+			header.Typeflag = tar.TypeReg
+			header.Size = info.Size()
+			header.Mode = normalizeMode(info.Mode())
+		default:
+			// links and special files are not part of releases.
+			return nil
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// normalizeMode keeps the executable bit of a file mode only, umask of the machine
+// that builds the release shouldn't change the tarball.
+func normalizeMode(mode fs.FileMode) int64 {
+	if mode&0111 != 0 {
+		return 0755
+	}
+	return 0644
+}
@@ -0,0 +1,56 @@
+package tarball
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreate(t *testing.T) {
+	modTime := time.Unix(1600000000, 0)
+
+	create := func(mode os.FileMode) []byte {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b.txt"), []byte("b"), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), []byte("a"), mode))
+
+		var buf bytes.Buffer
+		require.NoError(t, Create(&buf, dir, modTime))
+		return buf.Bytes()
+	}
+
+	first := create(0700)
+	require.Equal(t, first, create(0750), "tarballs of the same files must be the same")
+
+	gr, err := gzip.NewReader(bytes.NewReader(first))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.True(t, header.ModTime.Equal(modTime))
+		require.Zero(t, header.Uid)
+		names = append(names, header.Name)
+
+		if header.Name == "a" {
+			require.EqualValues(t, 0755, header.Mode)
+		}
+		if header.Name == "sub/b.txt" {
+			require.EqualValues(t, 0644, header.Mode)
+		}
+	}
+	require.Equal(t, []string{"a", "sub/", "sub/b.txt"}, names)
+}
@@ -0,0 +1,59 @@
+package tendermintrpc
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+)
+
+const methodABCIQuery = "abci_query"
+
+// ABCIQueryResponse is the response of an ABCI query.
+type ABCIQueryResponse struct {
+	Code      uint32
+	Codespace string
+	Log       string
+	Height    int64
+	Key       []byte
+	Value     []byte
+}
+
+// ABCIQuery queries the app at path with data, e.g. /store/bank/key or a gRPC method
+// like /cosmos.bank.v1beta1.Query/Balance with the encoded request as data.
+// the latest state is queried when height is zero.
+func (c Client) ABCIQuery(ctx context.Context, path string, data []byte, height int64) (ABCIQueryResponse, error) {
+	var out struct {
+		Response struct {
+			Code      uint32 `json:"code"`
+			Codespace string `json:"codespace"`
+			Log       string `json:"log"`
+			Height    string `json:"height"`
+			Key       []byte `json:"key"`
+			Value     []byte `json:"value"`
+		} `json:"response"`
+	}
+
+	params := map[string]interface{}{
+		"path":   path,
+		"data":   hex.EncodeToString(data),
+		"height": strconv.FormatInt(height, 10),
+		"prove":  false,
+	}
+	if err := c.call(ctx, methodABCIQuery, params, &out); err != nil {
+		return ABCIQueryResponse{}, err
+	}
+
+	h, err := parseInt(out.Response.Height)
+	if err != nil {
+		return ABCIQueryResponse{}, err
+	}
+
+	return ABCIQueryResponse{
+		Code:      out.Response.Code,
+		Codespace: out.Response.Codespace,
+		Log:       out.Response.Log,
+		Height:    h,
+		Key:       out.Response.Key,
+		Value:     out.Response.Value,
+	}, nil
+}
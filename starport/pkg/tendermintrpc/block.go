@@ -0,0 +1,237 @@
+package tendermintrpc
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	methodBlock        = "block"
+	methodBlockResults = "block_results"
+)
+
+// Block is a block of the chain.
+type Block struct {
+	Height          int64
+	Hash            string
+	ChainID         string
+	Time            time.Time
+	ProposerAddress string
+
+	// Txs holds the encoded txs of the block.
+	Txs [][]byte
+}
+
+// BlockResults holds the results of the execution of a block.
+type BlockResults struct {
+	Height           int64
+	TxsResults       []TxResult
+	BeginBlockEvents []Event
+	EndBlockEvents   []Event
+}
+
+// TxResult is the result of the execution of a tx.
+type TxResult struct {
+	Code      uint32
+	Codespace string
+	Log       string
+	GasWanted int64
+	GasUsed   int64
+	Events    []Event
+}
+
+// Event is an event emitted during the execution of a block.
+type Event struct {
+	Type       string
+	Attributes []EventAttribute
+}
+
+// EventAttribute is an attribute of an event.
+type EventAttribute struct {
+	Key   string
+	Value string
+	Index bool
+}
+
+type blockOut struct {
+	BlockID struct {
+		Hash string `json:"hash"`
+	} `json:"block_id"`
+	Block struct {
+		Header struct {
+			ChainID         string    `json:"chain_id"`
+			Height          string    `json:"height"`
+			Time            time.Time `json:"time"`
+			ProposerAddress string    `json:"proposer_address"`
+		} `json:"header"`
+		Data struct {
+			Txs [][]byte `json:"txs"`
+		} `json:"data"`
+	} `json:"block"`
+}
+
+type txResultOut struct {
+	Code      uint32     `json:"code"`
+	Codespace string     `json:"codespace"`
+	Log       string     `json:"log"`
+	GasWanted string     `json:"gas_wanted"`
+	GasUsed   string     `json:"gas_used"`
+	Events    []eventOut `json:"events"`
+}
+
+type eventOut struct {
+	Type       string `json:"type"`
+	Attributes []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+		Index bool   `json:"index"`
+	} `json:"attributes"`
+}
+
+// Block retrieves the block at height, the latest block is retrieved when height is zero.
+func (c Client) Block(ctx context.Context, height int64) (Block, error) {
+	var out blockOut
+	if err := c.call(ctx, methodBlock, heightParams(height), &out); err != nil {
+		return Block{}, err
+	}
+
+	h, err := strconv.ParseInt(out.Block.Header.Height, 10, 64)
+	if err != nil {
+		return Block{}, err
+	}
+
+	return Block{
+		Height:          h,
+		Hash:            out.BlockID.Hash,
+		ChainID:         out.Block.Header.ChainID,
+		Time:            out.Block.Header.Time,
+		ProposerAddress: out.Block.Header.ProposerAddress,
+		Txs:             out.Block.Data.Txs,
+	}, nil
+}
+
+// BlockResults retrieves the results of the block at height, the results of the latest block
+// are retrieved when height is zero.
+func (c Client) BlockResults(ctx context.Context, height int64) (BlockResults, error) {
+	var out struct {
+		Height           string        `json:"height"`
+		TxsResults       []txResultOut `json:"txs_results"`
+		BeginBlockEvents []eventOut    `json:"begin_block_events"`
+		EndBlockEvents   []eventOut    `json:"end_block_events"`
+	}
+	if err := c.call(ctx, methodBlockResults, heightParams(height), &out); err != nil {
+		return BlockResults{}, err
+	}
+
+	encoded, err := c.eventsEncoded(ctx)
+	if err != nil {
+		return BlockResults{}, err
+	}
+
+	h, err := strconv.ParseInt(out.Height, 10, 64)
+	if err != nil {
+		return BlockResults{}, err
+	}
+
+	results := BlockResults{Height: h}
+	for _, r := range out.TxsResults {
+		result, err := r.decode(encoded)
+		if err != nil {
+			return BlockResults{}, err
+		}
+		results.TxsResults = append(results.TxsResults, result)
+	}
+	if results.BeginBlockEvents, err = decodeEvents(out.BeginBlockEvents, encoded); err != nil {
+		return BlockResults{}, err
+	}
+	if results.EndBlockEvents, err = decodeEvents(out.EndBlockEvents, encoded); err != nil {
+		return BlockResults{}, err
+	}
+
+	return results, nil
+}
+
+func (r txResultOut) decode(encoded bool) (TxResult, error) {
+	result := TxResult{
+		Code:      r.Code,
+		Codespace: r.Codespace,
+		Log:       r.Log,
+	}
+
+	var err error
+	if result.GasWanted, err = parseInt(r.GasWanted); err != nil {
+		return TxResult{}, err
+	}
+	if result.GasUsed, err = parseInt(r.GasUsed); err != nil {
+		return TxResult{}, err
+	}
+	if result.Events, err = decodeEvents(r.Events, encoded); err != nil {
+		return TxResult{}, err
+	}
+
+	return result, nil
+}
+
+// eventsEncoded checks if the node encodes the keys and the values of the event attributes
+// in base64, which is the case before Tendermint v0.37.
+func (c Client) eventsEncoded(ctx context.Context) (bool, error) {
+	version, err := c.nodeVersion(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 || parts[0] != "0" {
+		return false, nil
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false, nil
+	}
+	return minor < 37, nil
+}
+
+func decodeEvents(events []eventOut, encoded bool) ([]Event, error) {
+	var decoded []Event
+
+	for _, e := range events {
+		event := Event{Type: e.Type}
+
+		for _, attr := range e.Attributes {
+			key, value := attr.Key, attr.Value
+
+			if encoded {
+				k, err := base64.StdEncoding.DecodeString(key)
+				if err != nil {
+					return nil, err
+				}
+				v, err := base64.StdEncoding.DecodeString(value)
+				if err != nil {
+					return nil, err
+				}
+				key, value = string(k), string(v)
+			}
+
+			event.Attributes = append(event.Attributes, EventAttribute{
+				Key:   key,
+				Value: value,
+				Index: attr.Index,
+			})
+		}
+
+		decoded = append(decoded, event)
+	}
+
+	return decoded, nil
+}
+
+// parseInt parses the integer s encoded as a string, an empty string is zero.
+func parseInt(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
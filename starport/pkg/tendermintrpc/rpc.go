@@ -5,58 +5,140 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 )
 
 const (
-	endpointNetInfo = "/net_info"
-	endpointGenesis = "/genesis"
-	endpointStatus  = "/status"
+	methodNetInfo = "net_info"
+	methodGenesis = "genesis"
+	methodStatus  = "status"
 )
 
 // Client is a Tendermint RPC client.
 type Client struct {
 	addr string
+
+	// node caches the version of the node to decode its events.
+	node *nodeCache
+}
+
+type nodeCache struct {
+	mu      sync.Mutex
+	version string
 }
 
 // New creates a new Tendermint RPC client.
 func New(addr string) Client {
-	return Client{addr: addr}
+	return Client{
+		addr: strings.TrimSuffix(addr, "/"),
+		node: &nodeCache{},
+	}
 }
 
-// NetInfo represents Network Info.
-type NetInfo struct {
-	ConnectedPeers int
+// Error is an error returned by the RPC.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
 }
 
-func (c Client) url(endpoint string) string {
-	return fmt.Sprintf("%s%s", c.addr, endpoint)
+func (e *Error) Error() string {
+	if e.Data != "" {
+		return fmt.Sprintf("%s: %s", e.Message, e.Data)
+	}
+	return e.Message
 }
 
-// GetNetInfo retrieves network info.
-func (c Client) GetNetInfo(ctx context.Context) (NetInfo, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url(endpointNetInfo), nil)
+type request struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int         `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type response struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+func newRequest(method string, params map[string]interface{}) request {
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	return request{
+		JSONRPC: "2.0",
+		ID:      0,
+		Method:  method,
+		Params:  params,
+	}
+}
+
+// call calls method with params and decodes its result into result.
+func (c Client) call(ctx context.Context, method string, params map[string]interface{}, result interface{}) error {
+	data, err := json.Marshal(newRequest(method, params))
 	if err != nil {
-		return NetInfo{}, err
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.addr, bytes.NewReader(data))
+	if err != nil {
+		return err
 	}
+	req.Header.Set("Content-Type", "application/json")
+
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return NetInfo{}, err
+		return err
 	}
 	defer resp.Body.Close()
 
-	var res struct {
-		Result struct {
-			Peers string `json:"n_peers"`
-		} `json:"result"`
+	var out response
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		// errors of the server are not always encoded as JSON.
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("%d", resp.StatusCode)
+		}
+		return err
+	}
+	if out.Error != nil {
+		return out.Error
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%d", resp.StatusCode)
+	}
+
+	return json.Unmarshal(out.Result, result)
+}
+
+// heightParams returns the params of a request at height, the latest height is used when
+// height is zero.
+func heightParams(height int64) map[string]interface{} {
+	params := map[string]interface{}{}
+	if height > 0 {
+		params["height"] = strconv.FormatInt(height, 10)
+	}
+	return params
+}
+
+// NetInfo represents Network Info.
+type NetInfo struct {
+	ConnectedPeers int
+}
+
+// GetNetInfo retrieves network info.
+func (c Client) GetNetInfo(ctx context.Context) (NetInfo, error) {
+	var out struct {
+		Peers string `json:"n_peers"`
+	}
+	if err := c.call(ctx, methodNetInfo, nil, &out); err != nil {
 		return NetInfo{}, err
 	}
 
-	peers, err := strconv.ParseUint(res.Result.Peers, 10, 64)
+	peers, err := strconv.ParseUint(out.Peers, 10, 64)
 	if err != nil {
 		return NetInfo{}, err
 	}
@@ -73,88 +155,86 @@ type Genesis struct {
 
 // GetGenesis retrieves Genesis.
 func (c Client) GetGenesis(ctx context.Context) (Genesis, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url(endpointGenesis), nil)
-	if err != nil {
-		return Genesis{}, err
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return Genesis{}, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return Genesis{}, fmt.Errorf("%d", resp.StatusCode)
-	}
-
 	var out struct {
-		Result struct {
-			Genesis Genesis `json:"genesis"`
-		} `json:"Result"`
+		Genesis Genesis `json:"genesis"`
 	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+	if err := c.call(ctx, methodGenesis, nil, &out); err != nil {
 		return Genesis{}, err
 	}
 
-	return out.Result.Genesis, nil
+	return out.Genesis, nil
 }
 
 // NodeInfo holds node info.
 type NodeInfo struct {
-	Network string
+	// Network is the chain id of the node.
+	Network string `json:"network"`
+
+	// Version is the Tendermint version of the node.
+	Version string `json:"version"`
+}
+
+// SyncInfo holds the sync info of a node.
+type SyncInfo struct {
+	LatestBlockHeight int64
 }
 
 // Status retrieves node Status.
 func (c Client) Status(ctx context.Context) (NodeInfo, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url(endpointStatus), nil)
-	if err != nil {
-		return NodeInfo{}, err
-	}
+	info, _, err := c.status(ctx)
+	return info, err
+}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return NodeInfo{}, err
-	}
-	defer resp.Body.Close()
+// GetSyncInfo retrieves the sync info of the node.
+func (c Client) GetSyncInfo(ctx context.Context) (SyncInfo, error) {
+	_, syncInfo, err := c.status(ctx)
+	return syncInfo, err
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return NodeInfo{}, fmt.Errorf("%d", resp.StatusCode)
-	}
+func (c Client) status(ctx context.Context) (NodeInfo, SyncInfo, error) {
+	var out struct {
+		NodeInfo NodeInfo `json:"node_info"`
 
-	var (
-		info NodeInfo
-		b    = &bytes.Buffer{}
-		r    = io.TeeReader(resp.Body, b)
-	)
+		// some Stargate versions have a different response payload.
+		LegacyNodeInfo NodeInfo `json:"NodeInfo"`
 
-	var out struct {
-		Result struct {
-			NodeInfo NodeInfo `json:"node_info"`
-		} `json:"result"`
+		SyncInfo struct {
+			LatestBlockHeight string `json:"latest_block_height"`
+		} `json:"sync_info"`
 	}
-
-	if err := json.NewDecoder(r).Decode(&out); err != nil {
-		return NodeInfo{}, err
+	if err := c.call(ctx, methodStatus, nil, &out); err != nil {
+		return NodeInfo{}, SyncInfo{}, err
 	}
 
-	info = out.Result.NodeInfo
-
-	// some Stargate versions have a different response payload.
+	info := out.NodeInfo
 	if info.Network == "" {
-		var out struct {
-			Result struct {
-				NodeInfo NodeInfo `json:"NodeInfo"`
-			} `json:"result"`
-		}
+		info = out.LegacyNodeInfo
+	}
 
-		if err := json.NewDecoder(b).Decode(&out); err != nil {
-			return NodeInfo{}, err
+	var syncInfo SyncInfo
+	if out.SyncInfo.LatestBlockHeight != "" {
+		height, err := strconv.ParseInt(out.SyncInfo.LatestBlockHeight, 10, 64)
+		if err != nil {
+			return NodeInfo{}, SyncInfo{}, err
 		}
+		syncInfo.LatestBlockHeight = height
+	}
+
+	return info, syncInfo, nil
+}
 
-		info = out.Result.NodeInfo
+// nodeVersion returns the Tendermint version of the node, it's only retrieved once.
+func (c Client) nodeVersion(ctx context.Context) (string, error) {
+	c.node.mu.Lock()
+	defer c.node.mu.Unlock()
+
+	if c.node.version == "" {
+		info, err := c.Status(ctx)
+		if err != nil {
+			return "", err
+		}
+		c.node.version = info.Version
 	}
 
-	return info, nil
+	return c.node.version, nil
 }
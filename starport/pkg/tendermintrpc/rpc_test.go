@@ -0,0 +1,282 @@
+package tendermintrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/starport/starport/pkg/tendermintrpc"
+	"github.com/tendermint/starport/starport/pkg/tendermintrpc/tendermintrpctest"
+)
+
+func TestStatus(t *testing.T) {
+	server := tendermintrpctest.NewServer()
+	defer server.Close()
+
+	client := tendermintrpc.New(server.URL)
+
+	info, err := client.Status(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, tendermintrpc.NodeInfo{Network: "test", Version: tendermintrpctest.DefaultVersion}, info)
+
+	syncInfo, err := client.GetSyncInfo(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), syncInfo.LatestBlockHeight)
+}
+
+func TestError(t *testing.T) {
+	server := tendermintrpctest.NewServer()
+	defer server.Close()
+
+	server.Handle("block", func(json.RawMessage) (interface{}, error) {
+		return nil, errors.New("height 10 is not available")
+	})
+
+	_, err := tendermintrpc.New(server.URL).Block(context.Background(), 10)
+	var rpcErr *tendermintrpc.Error
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, "Internal error: height 10 is not available", err.Error())
+}
+
+func TestBlock(t *testing.T) {
+	server := tendermintrpctest.NewServer()
+	defer server.Close()
+
+	server.HandleResult("block", map[string]interface{}{
+		"block_id": map[string]interface{}{"hash": "AB12"},
+		"block": map[string]interface{}{
+			"header": map[string]interface{}{
+				"chain_id":         "mars",
+				"height":           "5",
+				"time":             "2021-11-02T10:00:00Z",
+				"proposer_address": "CD34",
+			},
+			"data": map[string]interface{}{"txs": []string{"dHgx"}},
+		},
+	})
+
+	block, err := tendermintrpc.New(server.URL).Block(context.Background(), 5)
+	require.NoError(t, err)
+	require.Equal(t, tendermintrpc.Block{
+		Height:          5,
+		Hash:            "AB12",
+		ChainID:         "mars",
+		Time:            time.Date(2021, 11, 2, 10, 0, 0, 0, time.UTC),
+		ProposerAddress: "CD34",
+		Txs:             [][]byte{[]byte("tx1")},
+	}, block)
+	require.Equal(t, map[string]interface{}{"height": "5"}, server.Calls()[0].Params)
+}
+
+func TestBlockResults(t *testing.T) {
+	cases := []struct {
+		name      string
+		version   string
+		attribute map[string]interface{}
+	}{
+		{
+			name:      "base64 encoded attributes",
+			version:   "0.34.14",
+			attribute: map[string]interface{}{"key": "c2VuZGVy", "value": "Y29zbW9zMWE=", "index": true},
+		},
+		{
+			name:      "plain attributes",
+			version:   "0.37.0",
+			attribute: map[string]interface{}{"key": "sender", "value": "cosmos1a", "index": true},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := tendermintrpctest.NewServer()
+			defer server.Close()
+
+			server.HandleResult("status", map[string]interface{}{
+				"node_info": map[string]interface{}{"network": "mars", "version": tt.version},
+			})
+			events := []interface{}{
+				map[string]interface{}{"type": "message", "attributes": []interface{}{tt.attribute}},
+			}
+			server.HandleResult("block_results", map[string]interface{}{
+				"height": "5",
+				"txs_results": []interface{}{
+					map[string]interface{}{
+						"code":       0,
+						"gas_wanted": "200000",
+						"gas_used":   "50000",
+						"events":     events,
+					},
+				},
+				"end_block_events": events,
+			})
+
+			results, err := tendermintrpc.New(server.URL).BlockResults(context.Background(), 5)
+			require.NoError(t, err)
+
+			expectedEvents := []tendermintrpc.Event{
+				{
+					Type:       "message",
+					Attributes: []tendermintrpc.EventAttribute{{Key: "sender", Value: "cosmos1a", Index: true}},
+				},
+			}
+			require.Equal(t, tendermintrpc.BlockResults{
+				Height: 5,
+				TxsResults: []tendermintrpc.TxResult{
+					{GasWanted: 200000, GasUsed: 50000, Events: expectedEvents},
+				},
+				EndBlockEvents: expectedEvents,
+			}, results)
+		})
+	}
+}
+
+func TestTxSearch(t *testing.T) {
+	server := tendermintrpctest.NewServer()
+	defer server.Close()
+
+	// 3 txs served by pages of 2.
+	server.Handle("tx_search", func(params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Page string `json:"page"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		page, _ := strconv.Atoi(p.Page)
+
+		var txs []interface{}
+		for i := (page - 1) * 2; i < page*2 && i < 3; i++ {
+			txs = append(txs, map[string]interface{}{
+				"hash":      "HASH" + strconv.Itoa(i),
+				"height":    strconv.Itoa(i + 1),
+				"index":     0,
+				"tx_result": map[string]interface{}{"code": 0, "gas_used": "10"},
+				"tx":        "dHg=",
+			})
+		}
+		return map[string]interface{}{"txs": txs, "total_count": "3"}, nil
+	})
+
+	client := tendermintrpc.New(server.URL)
+
+	txs, err := client.TxSearch(context.Background(), "message.sender='cosmos1a'", tendermintrpc.TxSearchPerPage(2))
+	require.NoError(t, err)
+	require.Len(t, txs, 3)
+	require.Equal(t, tendermintrpc.Tx{
+		Hash:   "HASH2",
+		Height: 3,
+		Result: tendermintrpc.TxResult{GasUsed: 10},
+		Tx:     []byte("tx"),
+	}, txs[2])
+
+	var searches []map[string]interface{}
+	for _, call := range server.Calls() {
+		if call.Method == "tx_search" {
+			searches = append(searches, call.Params)
+		}
+	}
+	require.Equal(t, []map[string]interface{}{
+		{"query": "message.sender='cosmos1a'", "prove": false, "page": "1", "per_page": "2", "order_by": "asc"},
+		{"query": "message.sender='cosmos1a'", "prove": false, "page": "2", "per_page": "2", "order_by": "asc"},
+	}, searches)
+
+	txs, err = client.TxSearch(
+		context.Background(),
+		"message.sender='cosmos1a'",
+		tendermintrpc.TxSearchPerPage(2),
+		tendermintrpc.TxSearchLimit(1),
+	)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+}
+
+func TestValidators(t *testing.T) {
+	server := tendermintrpctest.NewServer()
+	defer server.Close()
+
+	server.HandleResult("validators", map[string]interface{}{
+		"block_height": "5",
+		"validators": []interface{}{
+			map[string]interface{}{
+				"address":           "AB12",
+				"pub_key":           map[string]interface{}{"type": "tendermint/PubKeyEd25519", "value": "a2V5"},
+				"voting_power":      "100",
+				"proposer_priority": "-50",
+			},
+		},
+		"count": "1",
+		"total": "1",
+	})
+
+	validators, err := tendermintrpc.New(server.URL).Validators(context.Background(), 0)
+	require.NoError(t, err)
+	require.Equal(t, []tendermintrpc.Validator{
+		{
+			Address:          "AB12",
+			PubKey:           tendermintrpc.PubKey{Type: "tendermint/PubKeyEd25519", Value: "a2V5"},
+			VotingPower:      100,
+			ProposerPriority: -50,
+		},
+	}, validators)
+	require.Equal(t, map[string]interface{}{"page": "1", "per_page": "100"}, server.Calls()[0].Params)
+}
+
+func TestABCIQuery(t *testing.T) {
+	server := tendermintrpctest.NewServer()
+	defer server.Close()
+
+	server.HandleResult("abci_query", map[string]interface{}{
+		"response": map[string]interface{}{
+			"code":   0,
+			"height": "5",
+			"value":  "dmFsdWU=",
+		},
+	})
+
+	res, err := tendermintrpc.New(server.URL).ABCIQuery(context.Background(), "/store/bank/key", []byte{0x01, 0xff}, 0)
+	require.NoError(t, err)
+	require.Equal(t, tendermintrpc.ABCIQueryResponse{Height: 5, Value: []byte("value")}, res)
+	require.Equal(t, map[string]interface{}{
+		"path":   "/store/bank/key",
+		"data":   "01ff",
+		"height": "0",
+		"prove":  false,
+	}, server.Calls()[0].Params)
+}
+
+func TestSubscribe(t *testing.T) {
+	server := tendermintrpctest.NewServer()
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const query = "tm.event='NewBlock'"
+	events, err := tendermintrpc.New(server.URL).Subscribe(ctx, query)
+	require.NoError(t, err)
+
+	server.WaitSubscribed()
+	require.NoError(t, server.Publish(
+		query,
+		"tendermint/event/NewBlock",
+		map[string]interface{}{"block": map[string]interface{}{}},
+		map[string][]string{"tm.event": {"NewBlock"}},
+	))
+
+	event := <-events
+	require.Equal(t, tendermintrpc.SubscriptionEvent{
+		Query:  query,
+		Type:   "tendermint/event/NewBlock",
+		Data:   json.RawMessage(`{"block":{}}`),
+		Events: map[string][]string{"tm.event": {"NewBlock"}},
+	}, event)
+
+	// the events are closed once the subscription is canceled.
+	cancel()
+	for range events {
+	}
+}
@@ -0,0 +1,124 @@
+package tendermintrpc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	methodSubscribe = "subscribe"
+
+	endpointWebsocket = "/websocket"
+
+	// subscriptionBuffer is the number of events buffered for a subscriber.
+	subscriptionBuffer = 100
+)
+
+// SubscriptionEvent is an event received by a subscription.
+type SubscriptionEvent struct {
+	// Query is the query of the subscription.
+	Query string
+
+	// Type of the event data, e.g. tendermint/event/NewBlock.
+	Type string
+
+	// Data is the JSON encoded event data.
+	Data json.RawMessage
+
+	// Events holds the values of the events of the data by their composite keys,
+	// e.g. tm.event or message.sender.
+	Events map[string][]string
+}
+
+// Subscribe subscribes to the events matching query, e.g. "tm.event='NewBlock'".
+// the events are sent to the returned channel, the channel is closed once ctx is done or
+// the connection to the node is lost.
+func (c Client) Subscribe(ctx context.Context, query string) (<-chan SubscriptionEvent, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, c.websocketAddress(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.WriteJSON(newRequest(methodSubscribe, map[string]interface{}{"query": query})); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	// the first response confirms the subscription.
+	var confirmation response
+	if err := conn.ReadJSON(&confirmation); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if confirmation.Error != nil {
+		conn.Close()
+		return nil, confirmation.Error
+	}
+
+	events := make(chan SubscriptionEvent, subscriptionBuffer)
+
+	// close the connection to stop reading once ctx is done.
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		conn.Close()
+	}()
+
+	go func() {
+		defer close(events)
+		defer close(done)
+
+		for {
+			var resp response
+			if err := conn.ReadJSON(&resp); err != nil {
+				return
+			}
+			if resp.Error != nil {
+				return
+			}
+
+			var out struct {
+				Query string `json:"query"`
+				Data  struct {
+					Type  string          `json:"type"`
+					Value json.RawMessage `json:"value"`
+				} `json:"data"`
+				Events map[string][]string `json:"events"`
+			}
+			if err := json.Unmarshal(resp.Result, &out); err != nil {
+				return
+			}
+
+			select {
+			case events <- SubscriptionEvent{
+				Query:  out.Query,
+				Type:   out.Data.Type,
+				Data:   out.Data.Value,
+				Events: out.Events,
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// websocketAddress returns the address of the websocket endpoint of the RPC.
+func (c Client) websocketAddress() string {
+	addr := c.addr
+	switch {
+	case strings.HasPrefix(addr, "https://"):
+		addr = "wss://" + strings.TrimPrefix(addr, "https://")
+	case strings.HasPrefix(addr, "http://"):
+		addr = "ws://" + strings.TrimPrefix(addr, "http://")
+	}
+	return addr + endpointWebsocket
+}
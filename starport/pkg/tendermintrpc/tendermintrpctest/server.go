@@ -0,0 +1,237 @@
+// Package tendermintrpctest provides a fake Tendermint RPC server for tests.
+package tendermintrpctest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// DefaultVersion is the Tendermint version reported by the status of the server.
+const DefaultVersion = "0.34.14"
+
+// HandlerFunc handles a call of a method with its JSON encoded params and returns its
+// result or an error.
+type HandlerFunc func(params json.RawMessage) (interface{}, error)
+
+// Call is a call of a method received by the server.
+type Call struct {
+	Method string
+	Params map[string]interface{}
+}
+
+// Server is a fake Tendermint RPC server that handles JSON-RPC requests over HTTP and
+// subscriptions over websocket.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	handlers      map[string]HandlerFunc
+	calls         []Call
+	subscriptions map[*websocket.Conn]subscription
+	subscribed    chan struct{}
+}
+
+type subscription struct {
+	id    json.RawMessage
+	query string
+}
+
+type request struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+var upgrader = websocket.Upgrader{}
+
+// NewServer starts a new fake server, its status reports DefaultVersion.
+// the server must be closed once done.
+func NewServer() *Server {
+	s := &Server{
+		handlers:      make(map[string]HandlerFunc),
+		subscriptions: make(map[*websocket.Conn]subscription),
+		subscribed:    make(chan struct{}, 1),
+	}
+
+	s.HandleResult("status", map[string]interface{}{
+		"node_info": map[string]interface{}{
+			"network": "test",
+			"version": DefaultVersion,
+		},
+		"sync_info": map[string]interface{}{
+			"latest_block_height": "1",
+		},
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleHTTP)
+	mux.HandleFunc("/websocket", s.handleWebsocket)
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// Handle sets the handler of method.
+func (s *Server) Handle(method string, handler HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[method] = handler
+}
+
+// HandleResult sets result as the result of method.
+func (s *Server) HandleResult(method string, result interface{}) {
+	s.Handle(method, func(json.RawMessage) (interface{}, error) {
+		return result, nil
+	})
+}
+
+// Calls returns the calls received by the server, subscriptions excluded.
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Call(nil), s.calls...)
+}
+
+// WaitSubscribed waits for the next subscription.
+func (s *Server) WaitSubscribed() {
+	<-s.subscribed
+}
+
+// Publish sends an event to the subscriptions with query.
+func (s *Server) Publish(query, dataType string, data interface{}, events map[string][]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for conn, sub := range s.subscriptions {
+		if sub.query != query {
+			continue
+		}
+
+		err := conn.WriteJSON(response{
+			JSONRPC: "2.0",
+			ID:      sub.id,
+			Result: map[string]interface{}{
+				"query": query,
+				"data": map[string]interface{}{
+					"type":  dataType,
+					"value": data,
+				},
+				"events": events,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) handleHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.call(req))
+}
+
+func (s *Server) call(req request) response {
+	s.mu.Lock()
+	handler, ok := s.handlers[req.Method]
+
+	var params map[string]interface{}
+	json.Unmarshal(req.Params, &params)
+	s.calls = append(s.calls, Call{Method: req.Method, Params: params})
+	s.mu.Unlock()
+
+	res := response{JSONRPC: "2.0", ID: req.ID}
+	if !ok {
+		res.Error = &rpcError{Code: -32601, Message: "Method not found"}
+		return res
+	}
+
+	result, err := handler(req.Params)
+	if err != nil {
+		res.Error = &rpcError{Code: -32603, Message: "Internal error", Data: err.Error()}
+		return res
+	}
+	res.Result = result
+	return res
+}
+
+func (s *Server) handleWebsocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		s.mu.Lock()
+		delete(s.subscriptions, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	for {
+		var req request
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+
+		// only subscriptions are served over websocket.
+		if req.Method != "subscribe" {
+			s.mu.Lock()
+			err := conn.WriteJSON(response{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error:   &rpcError{Code: -32601, Message: "Method not found"},
+			})
+			s.mu.Unlock()
+			if err != nil {
+				return
+			}
+			continue
+		}
+
+		var params struct {
+			Query string `json:"query"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.subscriptions[conn] = subscription{req.ID, params.Query}
+		err := conn.WriteJSON(response{JSONRPC: "2.0", ID: req.ID, Result: struct{}{}})
+		s.mu.Unlock()
+		if err != nil {
+			return
+		}
+
+		select {
+		case s.subscribed <- struct{}{}:
+		default:
+		}
+	}
+}
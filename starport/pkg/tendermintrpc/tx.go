@@ -0,0 +1,127 @@
+package tendermintrpc
+
+import (
+	"context"
+	"strconv"
+)
+
+const methodTxSearch = "tx_search"
+
+// DefaultTxSearchPerPage is the number of txs retrieved per page by TxSearch.
+const DefaultTxSearchPerPage = 100
+
+// Tx is a tx of the chain with its result.
+type Tx struct {
+	Hash   string
+	Height int64
+	Index  uint32
+	Result TxResult
+
+	// Tx is the encoded tx.
+	Tx []byte
+}
+
+// TxSearchOption configures a tx search.
+type TxSearchOption func(*txSearchOptions)
+
+type txSearchOptions struct {
+	perPage   int
+	limit     int
+	orderDesc bool
+}
+
+// TxSearchPerPage sets the number of txs retrieved per request.
+func TxSearchPerPage(perPage int) TxSearchOption {
+	return func(o *txSearchOptions) {
+		o.perPage = perPage
+	}
+}
+
+// TxSearchLimit sets the maximum number of txs to retrieve, all the txs are retrieved by default.
+func TxSearchLimit(limit int) TxSearchOption {
+	return func(o *txSearchOptions) {
+		o.limit = limit
+	}
+}
+
+// TxSearchOrderDesc retrieves the most recent txs first.
+func TxSearchOrderDesc() TxSearchOption {
+	return func(o *txSearchOptions) {
+		o.orderDesc = true
+	}
+}
+
+// TxSearch retrieves the txs matching query, e.g. "message.sender='cosmos1...'".
+func (c Client) TxSearch(ctx context.Context, query string, options ...TxSearchOption) ([]Tx, error) {
+	o := txSearchOptions{perPage: DefaultTxSearchPerPage}
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	orderBy := "asc"
+	if o.orderDesc {
+		orderBy = "desc"
+	}
+
+	encoded, err := c.eventsEncoded(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var txs []Tx
+
+	for page := 1; ; page++ {
+		var out struct {
+			Txs []struct {
+				Hash     string      `json:"hash"`
+				Height   string      `json:"height"`
+				Index    uint32      `json:"index"`
+				TxResult txResultOut `json:"tx_result"`
+				Tx       []byte      `json:"tx"`
+			} `json:"txs"`
+			TotalCount string `json:"total_count"`
+		}
+
+		params := map[string]interface{}{
+			"query":    query,
+			"prove":    false,
+			"page":     strconv.Itoa(page),
+			"per_page": strconv.Itoa(o.perPage),
+			"order_by": orderBy,
+		}
+		if err := c.call(ctx, methodTxSearch, params, &out); err != nil {
+			return nil, err
+		}
+
+		for _, t := range out.Txs {
+			height, err := strconv.ParseInt(t.Height, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			result, err := t.TxResult.decode(encoded)
+			if err != nil {
+				return nil, err
+			}
+
+			txs = append(txs, Tx{
+				Hash:   t.Hash,
+				Height: height,
+				Index:  t.Index,
+				Result: result,
+				Tx:     t.Tx,
+			})
+
+			if o.limit > 0 && len(txs) == o.limit {
+				return txs, nil
+			}
+		}
+
+		total, err := strconv.Atoi(out.TotalCount)
+		if err != nil {
+			return nil, err
+		}
+		if len(out.Txs) == 0 || len(txs) >= total {
+			return txs, nil
+		}
+	}
+}
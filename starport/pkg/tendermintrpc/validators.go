@@ -0,0 +1,82 @@
+package tendermintrpc
+
+import (
+	"context"
+	"strconv"
+)
+
+const (
+	methodValidators = "validators"
+
+	// validatorsPerPage is the maximum number of validators retrieved per request.
+	validatorsPerPage = 100
+)
+
+// Validator is a validator of the chain.
+type Validator struct {
+	Address          string
+	PubKey           PubKey
+	VotingPower      int64
+	ProposerPriority int64
+}
+
+// PubKey is a public key.
+type PubKey struct {
+	// Type of the key, e.g. tendermint/PubKeyEd25519.
+	Type string `json:"type"`
+
+	// Value is the base64 encoded key.
+	Value string `json:"value"`
+}
+
+// Validators retrieves the validators of the chain at height, the latest validators
+// are retrieved when height is zero.
+func (c Client) Validators(ctx context.Context, height int64) ([]Validator, error) {
+	var validators []Validator
+
+	for page := 1; ; page++ {
+		var out struct {
+			Validators []struct {
+				Address          string `json:"address"`
+				PubKey           PubKey `json:"pub_key"`
+				VotingPower      string `json:"voting_power"`
+				ProposerPriority string `json:"proposer_priority"`
+			} `json:"validators"`
+			Total string `json:"total"`
+		}
+
+		params := heightParams(height)
+		params["page"] = strconv.Itoa(page)
+		params["per_page"] = strconv.Itoa(validatorsPerPage)
+
+		if err := c.call(ctx, methodValidators, params, &out); err != nil {
+			return nil, err
+		}
+
+		for _, v := range out.Validators {
+			power, err := parseInt(v.VotingPower)
+			if err != nil {
+				return nil, err
+			}
+			priority, err := parseInt(v.ProposerPriority)
+			if err != nil {
+				return nil, err
+			}
+
+			validators = append(validators, Validator{
+				Address:          v.Address,
+				PubKey:           v.PubKey,
+				VotingPower:      power,
+				ProposerPriority: priority,
+			})
+		}
+
+		total, err := strconv.Atoi(out.Total)
+		if err != nil {
+			return nil, err
+		}
+		if len(out.Validators) == 0 || len(validators) >= total {
+			return validators, nil
+		}
+	}
+}
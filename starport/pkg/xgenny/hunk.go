@@ -0,0 +1,196 @@
+package xgenny
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gobuffalo/genny"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Hunk describes lines of a file replaced by a run
+type Hunk struct {
+	// Line is the index of the first replaced line in the modified file
+	Line int `json:"line"`
+
+	// Before contains the lines of the original file
+	Before []string `json:"before,omitempty"`
+
+	// After contains the lines of the modified file
+	After []string `json:"after,omitempty"`
+}
+
+// Diff returns the hunks to go from before to after, the lines are compared
+// regardless of their indentation so the hunks only contain the changed code,
+// the reindented lines have their own hunk to restore their indentation
+func Diff(before, after string) (hunks []Hunk) {
+	a, b := strings.Split(before, "\n"), strings.Split(after, "\n")
+	ca, cb := compactLines(a), compactLines(b)
+	ops := difflib.NewMatcherWithJunk(ca, cb, false, nil).GetOpCodes()
+	covered := 0 // end of the lines of the last hunk in after
+	for k, op := range ops {
+		if op.Tag == 'e' {
+			for i := 0; i < op.I2-op.I1; i++ {
+				if op.J1+i >= covered && a[op.I1+i] != b[op.J1+i] {
+					hunks = append(hunks, Hunk{
+						Line:   op.J1 + i,
+						Before: a[op.I1+i : op.I1+i+1],
+						After:  b[op.J1+i : op.J1+i+1],
+					})
+				}
+			}
+			continue
+		}
+
+		// the lines inserted after a line identical to their last line, like a
+		// closing parenthesis, are moved down so the inserted code is complete
+		j1, j2 := op.J1, op.J2
+		if op.Tag == 'i' && k+1 < len(ops) && ops[k+1].Tag == 'e' {
+			for j2 < ops[k+1].J2 && cb[j1] == cb[j2] {
+				j1++
+				j2++
+			}
+		}
+		hunks = append(hunks, Hunk{
+			Line:   j1,
+			Before: a[op.I1:op.I2],
+			After:  b[j1:j2],
+		})
+		covered = j2
+	}
+	return hunks
+}
+
+// Revert reverts the hunks of content from the last one, the replaced lines
+// are searched around their line regardless of their indentation in case the
+// content has been modified or formatted since
+func Revert(content string, hunks []Hunk) (string, error) {
+	lines := strings.Split(content, "\n")
+	for i := len(hunks) - 1; i >= 0; i-- {
+		hunk := hunks[i]
+		start, end := findLines(lines, hunk.After, hunk.Line)
+		if start < 0 {
+			return "", fmt.Errorf("the code %q can't be found", strings.Join(hunk.After, "\n"))
+		}
+		reverted := append([]string{}, lines[:start]...)
+		reverted = append(reverted, hunk.Before...)
+		lines = append(reverted, lines[end:]...)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// NewRevertGenerator returns a generator reverting the source modification,
+// the created files are deleted and the hunks of the modified files reverted
+func NewRevertGenerator(sm SourceModification) *genny.Generator {
+	g := genny.New()
+	g.RunFn(func(r *genny.Runner) error {
+		for _, createdFile := range sm.CreatedFiles() {
+			if _, err := os.Stat(createdFile); os.IsNotExist(err) {
+				continue
+			}
+			if err := r.Delete(createdFile); err != nil {
+				return err
+			}
+		}
+		for _, modifiedFile := range sm.ModifiedFiles() {
+			hunks := sm.Hunks(modifiedFile)
+			if len(hunks) == 0 {
+				continue
+			}
+			f, err := r.Disk.Find(modifiedFile)
+			if err != nil {
+				return err
+			}
+			content, err := Revert(f.String(), hunks)
+			if err != nil {
+				return fmt.Errorf("%s: %w", modifiedFile, err)
+			}
+			if err := r.File(genny.NewFileS(modifiedFile, content)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return g
+}
+
+// findLines returns the range of the lines in content nearest to line, or -1
+// if content doesn't contain the lines. The blank lines are ignored when the
+// lines can't be found as is, since the blank lines between the code added by
+// different runs can't be attributed to one of them.
+func findLines(content, lines []string, line int) (start, end int) {
+	compacted := compactLines(lines)
+	matches := func(at int) bool {
+		if at < 0 || at+len(lines) > len(content) {
+			return false
+		}
+		for i, l := range compacted {
+			if compactLine(content[at+i]) != l {
+				return false
+			}
+		}
+		return true
+	}
+	if at := nearest(line, len(content), matches); at >= 0 {
+		return at, at + len(lines)
+	}
+
+	var code []string
+	for _, l := range compacted {
+		if l != "" {
+			code = append(code, l)
+		}
+	}
+	if len(code) == 0 {
+		return -1, -1
+	}
+	matchesCode := func(at int) bool {
+		if at < 0 || at >= len(content) || compactLine(content[at]) != code[0] {
+			return false
+		}
+		for i, j := at, 0; j < len(code); i++ {
+			if i >= len(content) {
+				return false
+			}
+			switch compactLine(content[i]) {
+			case "":
+			case code[j]:
+				j++
+				end = i + 1
+			default:
+				return false
+			}
+		}
+		return true
+	}
+	if at := nearest(line, len(content), matchesCode); at >= 0 {
+		return at, end
+	}
+	return -1, -1
+}
+
+// nearest returns the index nearest to line between 0 and n that matches, or -1
+func nearest(line, n int, matches func(int) bool) int {
+	for d := 0; line-d >= 0 || line+d < n; d++ {
+		if matches(line - d) {
+			return line - d
+		}
+		if matches(line + d) {
+			return line + d
+		}
+	}
+	return -1
+}
+
+func compactLines(lines []string) []string {
+	compacted := make([]string, len(lines))
+	for i, line := range lines {
+		compacted[i] = compactLine(line)
+	}
+	return compacted
+}
+
+func compactLine(line string) string {
+	return strings.Join(strings.Fields(line), "")
+}
@@ -0,0 +1,77 @@
+package xgenny_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/starport/starport/pkg/xgenny"
+)
+
+const hunkOriginal = `package app
+
+import (
+	"fmt"
+)
+
+func New() {
+	keys := newKeys(
+		"foo",
+	)
+	fmt.Println(keys)
+}
+`
+
+const hunkModified = `package app
+
+import (
+	"fmt"
+
+	barmodule "example.com/x/bar"
+)
+
+func New() {
+	keys := newKeys(
+		"foo",
+		"bar",
+	)
+	fmt.Println(keys)
+}
+`
+
+func TestDiff(t *testing.T) {
+	hunks := xgenny.Diff(hunkOriginal, hunkModified)
+	require.Equal(t, []xgenny.Hunk{
+		{Line: 4, Before: []string{}, After: []string{"", "\tbarmodule \"example.com/x/bar\""}},
+		{Line: 11, Before: []string{}, After: []string{"\t\t\"bar\","}},
+	}, hunks)
+
+	require.Empty(t, xgenny.Diff(hunkOriginal, hunkOriginal))
+
+	// the inserted code is moved down to be complete
+	hunks = xgenny.Diff("f(\n)\n", "f(\n)\ng(\n)\n")
+	require.Equal(t, []xgenny.Hunk{{Line: 2, Before: []string{}, After: []string{"g(", ")"}}}, hunks)
+
+	// the reindented lines have their own hunk
+	hunks = xgenny.Diff("f(\n\t// comment\n)", "f(\n\tx,\n// comment\n)")
+	require.Equal(t, []xgenny.Hunk{
+		{Line: 1, Before: []string{}, After: []string{"\tx,"}},
+		{Line: 2, Before: []string{"\t// comment"}, After: []string{"// comment"}},
+	}, hunks)
+}
+
+func TestRevert(t *testing.T) {
+	hunks := xgenny.Diff(hunkOriginal, hunkModified)
+
+	reverted, err := xgenny.Revert(hunkModified, hunks)
+	require.NoError(t, err)
+	require.Equal(t, hunkOriginal, reverted)
+
+	// the hunks are found even if the content has been modified since
+	modified := "// Package app\n" + hunkModified + "\nfunc foo() {}\n"
+	reverted, err = xgenny.Revert(modified, hunks)
+	require.NoError(t, err)
+	require.Equal(t, "// Package app\n"+hunkOriginal+"\nfunc foo() {}\n", reverted)
+
+	_, err = xgenny.Revert(hunkOriginal, hunks)
+	require.Error(t, err)
+}
@@ -36,50 +36,99 @@ func RunWithValidation(
 	tracer *placeholder.Tracer,
 	gens ...*genny.Generator,
 ) (sm SourceModification, err error) {
-	// run executes the provided runner with the provided generator
-	run := func(runner *genny.Runner, gen *genny.Generator) error {
-		err := runner.With(gen)
-		if err != nil {
-			return err
-		}
-		return runner.Run()
-	}
+	sm = NewSourceModification()
 	for _, gen := range gens {
 		// check with a dry runner the generators
-		dryRunner := DryRunner(context.Background())
-		if err := run(dryRunner, gen); err != nil {
-			if errors.Is(err, os.ErrNotExist) {
-				return sm, &dryRunError{err}
+		genSm, err := dryRun(tracer, false, gen)
+		if err != nil {
+			return sm, err
+		}
+		sm.Merge(genSm)
+
+		// keep the original content of the modified files to compute their hunks once
+		// written since the content of the dry and wet runs can differ, like random values
+		originals := make(map[string]string)
+		for _, modifiedFile := range genSm.ModifiedFiles() {
+			original, err := os.ReadFile(modifiedFile)
+			if err != nil {
+				return sm, err
 			}
+			originals[modifiedFile] = string(original)
+		}
+
+		// execute the modification with a wet runner
+		runner := genny.WetRunner(context.Background())
+		if err := runner.With(gen); err != nil {
 			return sm, err
 		}
-		if err := tracer.Err(); err != nil {
+		if err := runner.Run(); err != nil {
 			return sm, err
 		}
 
-		// fetch the source modification
-		sm = NewSourceModification()
-		for _, file := range dryRunner.Results().Files {
-			fileName := file.Name()
-			_, err := os.Stat(fileName)
-
-			// nolint:gocritic
-			if os.IsNotExist(err) {
-				// if the file doesn't exist in the source, it means it has been created by the runner
-				sm.AppendCreatedFiles(fileName)
-			} else if err != nil {
+		for modifiedFile, original := range originals {
+			modified, err := os.ReadFile(modifiedFile)
+			if err != nil {
 				return sm, err
-			} else {
-				// the file has been modified by the runner
-				sm.AppendModifiedFiles(fileName)
 			}
+			sm.AppendHunks(modifiedFile, Diff(original, string(modified))...)
 		}
+	}
+	return sm, nil
+}
 
-		// execute the modification with a wet runner
-		if err := run(genny.WetRunner(context.Background()), gen); err != nil {
+// DryRun checks the generators with a single dry runner without modifying the source,
+// the generators see the files written by the previous ones. The source modification
+// documents the content of the created and modified files to show their diff.
+func DryRun(tracer *placeholder.Tracer, gens ...*genny.Generator) (SourceModification, error) {
+	return dryRun(tracer, true, gens...)
+}
+
+// dryRun runs the generators with a dry runner and returns the source modification,
+// the deleted files are only recorded
+func dryRun(tracer *placeholder.Tracer, withContents bool, gens ...*genny.Generator) (sm SourceModification, err error) {
+	sm = NewSourceModification()
+
+	var deleted []string
+	runner := DryRunner(context.Background())
+	runner.DeleteFn = func(path string) error {
+		deleted = append(deleted, path)
+		return nil
+	}
+	for _, gen := range gens {
+		if err := runner.With(gen); err != nil {
 			return sm, err
 		}
 	}
+	if err := runner.Run(); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return sm, &dryRunError{err}
+		}
+		return sm, err
+	}
+	if err := tracer.Err(); err != nil {
+		return sm, err
+	}
+
+	// fetch the source modification
+	for _, file := range runner.Results().Files {
+		fileName := file.Name()
+		_, err := os.Stat(fileName)
+
+		// nolint:gocritic
+		if os.IsNotExist(err) {
+			// if the file doesn't exist in the source, it means it has been created by the runner
+			sm.AppendCreatedFiles(fileName)
+		} else if err != nil {
+			return sm, err
+		} else {
+			// the file has been modified by the runner
+			sm.AppendModifiedFiles(fileName)
+		}
+		if withContents {
+			sm.SetContent(fileName, file.String())
+		}
+	}
+	sm.AppendDeletedFiles(deleted...)
 	return sm, nil
 }
 
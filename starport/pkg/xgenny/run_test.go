@@ -0,0 +1,51 @@
+package xgenny_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gobuffalo/genny"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/starport/starport/pkg/placeholder"
+	"github.com/tendermint/starport/starport/pkg/xgenny"
+)
+
+func TestDryRun(t *testing.T) {
+	dir := t.TempDir()
+	modified := filepath.Join(dir, "modified.go")
+	created := filepath.Join(dir, "created.go")
+	require.NoError(t, os.WriteFile(modified, []byte("package foo\n\nvar a = 1\n"), 0644))
+
+	create := genny.New()
+	create.File(genny.NewFileS(created, "package foo\n"))
+	modify := genny.New()
+	modify.RunFn(func(r *genny.Runner) error {
+		// the file created by the previous generator is visible
+		if _, err := r.Disk.Find(created); err != nil {
+			return err
+		}
+		return r.File(genny.NewFileS(modified, "package foo\n\nvar a = 2\n"))
+	})
+
+	sm, err := xgenny.DryRun(placeholder.New(), create, modify)
+	require.NoError(t, err)
+	require.Equal(t, []string{created}, sm.CreatedFiles())
+	require.Equal(t, []string{modified}, sm.ModifiedFiles())
+	require.NoFileExists(t, created)
+
+	diff, err := sm.UnifiedDiff(dir)
+	require.NoError(t, err)
+	require.Equal(t, `--- /dev/null
++++ b/created.go
+@@ -0,0 +1 @@
++package foo
+--- a/modified.go
++++ b/modified.go
+@@ -1,3 +1,3 @@
+ package foo
+ 
+-var a = 1
++var a = 2
+`, diff)
+}
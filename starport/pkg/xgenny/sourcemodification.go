@@ -1,15 +1,30 @@
 package xgenny
 
-// SourceModification describes modified and created files in the source code after a run
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// SourceModification describes modified, created and deleted files in the source code after a run
 type SourceModification struct {
 	modified map[string]struct{}
 	created  map[string]struct{}
+	deleted  map[string]struct{}
+	hunks    map[string][]Hunk
+	contents map[string]string
 }
 
 func NewSourceModification() SourceModification {
 	return SourceModification{
 		make(map[string]struct{}),
 		make(map[string]struct{}),
+		make(map[string]struct{}),
+		make(map[string][]Hunk),
+		make(map[string]string),
 	}
 }
 
@@ -29,6 +44,19 @@ func (sm SourceModification) CreatedFiles() (createdFiles []string) {
 	return
 }
 
+// DeletedFiles returns the deleted files of the source modification
+func (sm SourceModification) DeletedFiles() (deletedFiles []string) {
+	for deleted := range sm.deleted {
+		deletedFiles = append(deletedFiles, deleted)
+	}
+	return
+}
+
+// Hunks returns the hunks of a modified file of the source modification
+func (sm SourceModification) Hunks(modifiedFile string) []Hunk {
+	return sm.hunks[modifiedFile]
+}
+
 // AppendModifiedFiles appends modified files in the source modification that are not already documented
 func (sm *SourceModification) AppendModifiedFiles(modifiedFiles ...string) {
 	for _, modifiedFile := range modifiedFiles {
@@ -51,8 +79,101 @@ func (sm *SourceModification) AppendCreatedFiles(createdFiles ...string) {
 	}
 }
 
+// AppendDeletedFiles appends deleted files in the source modification that are not already documented
+func (sm *SourceModification) AppendDeletedFiles(deletedFiles ...string) {
+	for _, deletedFile := range deletedFiles {
+		sm.deleted[deletedFile] = struct{}{}
+	}
+}
+
+// AppendHunks appends the hunks of a modified file in the source modification,
+// the hunks of created files are not documented
+func (sm *SourceModification) AppendHunks(modifiedFile string, hunks ...Hunk) {
+	if _, alreadyCreated := sm.created[modifiedFile]; alreadyCreated {
+		return
+	}
+	sm.hunks[modifiedFile] = append(sm.hunks[modifiedFile], hunks...)
+}
+
+// SetContent documents the content of a created or modified file of the source modification
+func (sm *SourceModification) SetContent(file, content string) {
+	sm.contents[file] = content
+}
+
 // Merge merges new source modification to an existing one
 func (sm *SourceModification) Merge(newSm SourceModification) {
 	sm.AppendModifiedFiles(newSm.ModifiedFiles()...)
 	sm.AppendCreatedFiles(newSm.CreatedFiles()...)
+	sm.AppendDeletedFiles(newSm.DeletedFiles()...)
+	for modifiedFile, hunks := range newSm.hunks {
+		sm.AppendHunks(modifiedFile, hunks...)
+	}
+	for file, content := range newSm.contents {
+		sm.SetContent(file, content)
+	}
+}
+
+// UnifiedDiff returns the unified diff of the deleted files and of the created and
+// modified files with a documented content, like after a dry run. The original
+// content of the files is read from the source and their names are relative to dir.
+func (sm SourceModification) UnifiedDiff(dir string) (string, error) {
+	files := sm.DeletedFiles()
+	for file := range sm.contents {
+		if _, deleted := sm.deleted[file]; !deleted {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+
+	var b strings.Builder
+	for _, file := range files {
+		name, err := filepath.Rel(dir, file)
+		if err != nil {
+			return "", err
+		}
+		diff := difflib.UnifiedDiff{
+			FromFile: "a/" + name,
+			ToFile:   "b/" + name,
+			Context:  3,
+		}
+
+		info, err := os.Stat(file)
+		switch {
+		case os.IsNotExist(err):
+			diff.FromFile = "/dev/null"
+		case err != nil:
+			return "", err
+		case info.IsDir():
+			continue
+		default:
+			original, err := os.ReadFile(file)
+			if err != nil {
+				return "", err
+			}
+			diff.A = splitLines(string(original))
+		}
+		if _, deleted := sm.deleted[file]; deleted {
+			diff.ToFile = "/dev/null"
+		} else {
+			diff.B = splitLines(sm.contents[file])
+		}
+
+		text, err := difflib.GetUnifiedDiffString(diff)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(text)
+	}
+	return b.String(), nil
+}
+
+// splitLines splits s in lines ending with a new line
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if last := len(lines) - 1; lines[last] == "" {
+		lines = lines[:last]
+	} else {
+		lines[last] += "\n"
+	}
+	return lines
 }
@@ -79,3 +79,15 @@ func TestMerge(t *testing.T) {
 	require.Subset(t, sm1.ModifiedFiles(), []string{"foo1", "foo2", "foo3", "foo4", "foo5"})
 	require.Subset(t, sm1.CreatedFiles(), []string{"bar1", "bar2", "bar3"})
 }
+
+func TestAppendHunks(t *testing.T) {
+	sm := sourceModificationExample()
+	hunk := xgenny.Hunk{Line: 1, After: []string{"foo"}}
+	sm.AppendHunks("mfoo", hunk)
+	sm.AppendHunks("mfoo", hunk)
+	require.Equal(t, []xgenny.Hunk{hunk, hunk}, sm.Hunks("mfoo"))
+
+	// Do not document the hunks of created files
+	sm.AppendHunks("cfoo", hunk)
+	require.Empty(t, sm.Hunks("cfoo"))
+}
@@ -1,66 +1,378 @@
+// Package astutils edits Go source files structurally, by locating the nodes
+// to modify in the AST instead of relying on placeholder comments.
 package astutils
 
 import (
 	"bytes"
-	"fmt"
 	"go/ast"
 	"go/format"
 	"go/parser"
-	"go/printer"
 	"go/token"
-	"os"
+	"strconv"
+	"strings"
 
-	"golang.org/x/tools/go/ast/astutil"
+	"github.com/pkg/errors"
 )
 
-type AstHelper struct {
-	fileSet *token.FileSet
-	astFile *ast.File
-	file    string
+// ErrNotFound is returned when the node to edit can't be found in the source.
+var ErrNotFound = errors.New("node not found")
+
+// Edit modifies a Go source and returns the formatted result.
+type Edit func(src string) (string, error)
+
+// Apply applies edits to src in order and returns the modified source.
+func Apply(src string, edits ...Edit) (string, error) {
+	var err error
+	for _, edit := range edits {
+		if src, err = edit(src); err != nil {
+			return "", err
+		}
+	}
+	return src, nil
+}
+
+// AddImport imports path with an optional name, the import is skipped if
+// path is already imported.
+func AddImport(name, path string) Edit {
+	return func(src string) (string, error) {
+		fset, f, err := parse(src)
+		if err != nil {
+			return "", err
+		}
+		for _, spec := range f.Imports {
+			if p, _ := strconv.Unquote(spec.Path.Value); p == path {
+				return src, nil
+			}
+		}
+
+		imp := strconv.Quote(path)
+		if name != "" {
+			imp = name + " " + imp
+		}
+
+		// add the import to the first import block or create a new one
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.IMPORT {
+				continue
+			}
+			if gen.Lparen.IsValid() {
+				return insert(src, offset(fset, gen.Rparen), imp+"\n")
+			}
+			return insert(src, offset(fset, gen.Pos()), "import "+imp+"\n")
+		}
+		return insert(src, offset(fset, f.Name.End()), "\n\nimport "+imp+"\n")
+	}
 }
 
-func NewAstHelper(file string) (*AstHelper, error) {
-	astHelper := new(AstHelper)
-	fileSet := token.NewFileSet()
+// AddDecl adds top level declarations before the first function of the
+// source, or at its end when there is no function.
+func AddDecl(decl string) Edit {
+	return func(src string) (string, error) {
+		fset, f, err := parse(src)
+		if err != nil {
+			return "", err
+		}
+		for _, d := range f.Decls {
+			if fn, ok := d.(*ast.FuncDecl); ok {
+				return insert(src, lineStart(src, offset(fset, declPos(fn))), decl+"\n\n")
+			}
+		}
+		return insert(src, len(src), "\n"+decl+"\n")
+	}
+}
 
-	astFile, err := parser.ParseFile(fileSet, file, nil, parser.AllErrors|parser.ParseComments)
+// AddStructField adds a field to the struct type named structName, the field
+// is skipped if the struct already has a field with the same name.
+func AddStructField(structName, field string) Edit {
+	return func(src string) (string, error) {
+		fset, f, err := parse(src)
+		if err != nil {
+			return "", err
+		}
+		st := findStruct(f, structName)
+		if st == nil {
+			return "", errors.Wrapf(ErrNotFound, "struct %s", structName)
+		}
 
-	astHelper.fileSet = fileSet
+		fieldName := strings.Fields(field)
+		for _, existing := range st.Fields.List {
+			for _, name := range existing.Names {
+				if len(fieldName) > 0 && name.Name == fieldName[0] {
+					return src, nil
+				}
+			}
+		}
+		return insert(src, offset(fset, st.Fields.Closing), field+"\n")
+	}
+}
 
-	astHelper.astFile = astFile
+// AppendCallArgs appends args to the first call of fun, like
+// "module.NewManager", the args already passed to the call are skipped.
+func AppendCallArgs(fun string, args ...string) Edit {
+	return InsertCallArgs(fun, -1, args...)
+}
 
-	astHelper.file = file
+// InsertCallArgs inserts args at index in the arguments of the first call of
+// fun, a negative index appends the args.
+func InsertCallArgs(fun string, index int, args ...string) Edit {
+	return func(src string) (string, error) {
+		fset, f, err := parse(src)
+		if err != nil {
+			return "", err
+		}
 
-	return astHelper, err
+		var call *ast.CallExpr
+		ast.Inspect(f, func(n ast.Node) bool {
+			if c, ok := n.(*ast.CallExpr); ok && call == nil && sameCode(source(fset, src, c.Fun), fun) {
+				call = c
+			}
+			return call == nil
+		})
+		if call == nil {
+			return "", errors.Wrapf(ErrNotFound, "call of %s", fun)
+		}
+		return insertList(fset, src, call.Args, call.Rparen, index, args)
+	}
 }
 
-func (astHelper *AstHelper) Close() {
-	// TODO: cleanup the resources
+// AppendCompositeLit appends elements to the composite literal assigned to
+// the variable varName, the elements already in the literal are skipped.
+func AppendCompositeLit(varName string, elts ...string) Edit {
+	return func(src string) (string, error) {
+		fset, f, err := parse(src)
+		if err != nil {
+			return "", err
+		}
+
+		var lit *ast.CompositeLit
+		ast.Inspect(f, func(n ast.Node) bool {
+			if lit != nil {
+				return false
+			}
+			switch n := n.(type) {
+			case *ast.ValueSpec:
+				for i, name := range n.Names {
+					if name.Name == varName && i < len(n.Values) {
+						lit = compositeLit(n.Values[i])
+					}
+				}
+			case *ast.AssignStmt:
+				for i, lhs := range n.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok && ident.Name == varName && i < len(n.Rhs) {
+						lit = compositeLit(n.Rhs[i])
+					}
+				}
+			}
+			return true
+		})
+		if lit == nil {
+			return "", errors.Wrapf(ErrNotFound, "composite literal of %s", varName)
+		}
+		return insertList(fset, src, lit.Elts, lit.Rbrace, -1, elts)
+	}
 }
 
-func (astHelper *AstHelper) AddImport(pkg string) (done bool) {
+// AppendReturnLit appends elements to the composite literal returned by the
+// function funcName, the elements already in the literal are skipped.
+func AppendReturnLit(funcName string, elts ...string) Edit {
+	return func(src string) (string, error) {
+		fset, f, err := parse(src)
+		if err != nil {
+			return "", err
+		}
+		fn := findFunc(f, funcName)
+		if fn == nil {
+			return "", errors.Wrapf(ErrNotFound, "function %s", funcName)
+		}
+		for _, stmt := range fn.Body.List {
+			ret, ok := stmt.(*ast.ReturnStmt)
+			if !ok || len(ret.Results) == 0 {
+				continue
+			}
+			if lit := compositeLit(ret.Results[0]); lit != nil {
+				return insertList(fset, src, lit.Elts, lit.Rbrace, -1, elts)
+			}
+		}
+		return "", errors.Wrapf(ErrNotFound, "composite literal returned by %s", funcName)
+	}
+}
 
-	alreadyImported := astutil.UsesImport(astHelper.astFile, pkg)
+// InsertStatementsBefore inserts stmts in the body of the function funcName,
+// before the first statement starting with the code before, like "app.mm =".
+func InsertStatementsBefore(funcName, before, stmts string) Edit {
+	return func(src string) (string, error) {
+		fset, f, err := parse(src)
+		if err != nil {
+			return "", err
+		}
+		fn := findFunc(f, funcName)
+		if fn == nil {
+			return "", errors.Wrapf(ErrNotFound, "function %s", funcName)
+		}
+		for i, stmt := range fn.Body.List {
+			if strings.HasPrefix(compact(source(fset, src, stmt)), compact(before)) {
+				return insertStatements(fset, src, fn.Body, i, stmts)
+			}
+		}
+		return "", errors.Wrapf(ErrNotFound, "statement %s in %s", before, funcName)
+	}
+}
 
-	if !alreadyImported {
-		fmt.Println("adding import:" + pkg)
-		astutil.AddImport(astHelper.fileSet, astHelper.astFile, pkg)
-	} else {
-		fmt.Println("import of " + pkg + " already added")
+// InsertStatementsBeforeReturn inserts stmts in the body of the function
+// funcName, before its last return statement.
+func InsertStatementsBeforeReturn(funcName, stmts string) Edit {
+	return func(src string) (string, error) {
+		fset, f, err := parse(src)
+		if err != nil {
+			return "", err
+		}
+		fn := findFunc(f, funcName)
+		if fn == nil {
+			return "", errors.Wrapf(ErrNotFound, "function %s", funcName)
+		}
+		for i := len(fn.Body.List) - 1; i >= 0; i-- {
+			if _, ok := fn.Body.List[i].(*ast.ReturnStmt); ok {
+				return insertStatements(fset, src, fn.Body, i, stmts)
+			}
+		}
+		return "", errors.Wrapf(ErrNotFound, "return statement in %s", funcName)
 	}
+}
 
-	return !alreadyImported
+func parse(src string) (*token.FileSet, *ast.File, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot parse the source")
+	}
+	return fset, f, nil
 }
 
-func (astHelper *AstHelper) Print() {
-	printer.Fprint(os.Stdout, astHelper.fileSet, astHelper.astFile)
+// insert inserts text in src at offset and formats the result, an error is
+// returned when the inserted code is invalid.
+func insert(src string, offset int, text string) (string, error) {
+	res, err := format.Source([]byte(src[:offset] + text + src[offset:]))
+	if err != nil {
+		return "", errors.Wrap(err, "invalid code inserted")
+	}
+	return string(res), nil
 }
 
-func (astHelper *AstHelper) Write() error {
+// insertList inserts the items that are not yet in the list at index, the
+// items are appended when index is negative or out of the list.
+func insertList(fset *token.FileSet, src string, list []ast.Expr, closing token.Pos, index int, items []string) (string, error) {
+	existing := make(map[string]bool)
+	for _, expr := range list {
+		existing[compact(source(fset, src, expr))] = true
+	}
+	var added []string
+	for _, item := range items {
+		if !existing[compact(item)] {
+			added = append(added, item)
+		}
+	}
+	if len(added) == 0 {
+		return src, nil
+	}
 
-	var buf bytes.Buffer
+	if index >= 0 && index < len(list) {
+		return insert(src, offset(fset, list[index].Pos()), strings.Join(added, ",\n")+",\n")
+	}
 
-	format.Node(&buf, astHelper.fileSet, astHelper.astFile)
+	// keep the list on a single line when it doesn't end with a comma
+	end := offset(fset, closing)
+	if len(list) > 0 {
+		last := offset(fset, list[len(list)-1].End())
+		if !strings.HasPrefix(strings.TrimSpace(src[last:end]), ",") {
+			return insert(src, last, ", "+strings.Join(added, ", "))
+		}
+	}
+	if len(list) == 0 {
+		return insert(src, end, strings.Join(added, ", "))
+	}
+	return insert(src, end, strings.Join(added, ",\n")+",\n")
+}
+
+// insertStatements inserts stmts before the statement at index in block, at
+// the end of the line of the previous statement to keep the comments of the
+// next statement above it.
+func insertStatements(fset *token.FileSet, src string, block *ast.BlockStmt, index int, stmts string) (string, error) {
+	at, text := offset(fset, block.Lbrace)+1, "\n"+stmts+"\n"
+	if index > 0 {
+		at, text = offset(fset, block.List[index-1].End()), "\n"+text
+	}
+	if nl := strings.IndexByte(src[at:], '\n'); nl >= 0 {
+		at += nl
+	}
+	return insert(src, at, text)
+}
+
+func findFunc(f *ast.File, name string) *ast.FuncDecl {
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name.Name == name && fn.Body != nil {
+			return fn
+		}
+	}
+	return nil
+}
+
+func findStruct(f *ast.File, name string) *ast.StructType {
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == name {
+				if st, ok := ts.Type.(*ast.StructType); ok {
+					return st
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// compositeLit returns the composite literal of expr, or of the address of
+// the literal when expr is &T{}.
+func compositeLit(expr ast.Expr) *ast.CompositeLit {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	lit, _ := expr.(*ast.CompositeLit)
+	return lit
+}
+
+// declPos returns the position of a function including its doc comment.
+func declPos(fn *ast.FuncDecl) token.Pos {
+	if fn.Doc != nil {
+		return fn.Doc.Pos()
+	}
+	return fn.Pos()
+}
+
+func offset(fset *token.FileSet, pos token.Pos) int {
+	return fset.Position(pos).Offset
+}
+
+func lineStart(src string, offset int) int {
+	return strings.LastIndexByte(src[:offset], '\n') + 1
+}
+
+func source(fset *token.FileSet, src string, node ast.Node) string {
+	return src[offset(fset, node.Pos()):offset(fset, node.End())]
+}
+
+// compact removes the spaces of code to compare it regardless of its format.
+func compact(code string) string {
+	var b bytes.Buffer
+	for _, field := range strings.Fields(code) {
+		b.WriteString(field)
+	}
+	return b.String()
+}
 
-	return os.WriteFile(astHelper.file, buf.Bytes(), 0777)
+func sameCode(a, b string) bool {
+	return compact(a) == compact(b)
 }
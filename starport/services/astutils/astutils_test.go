@@ -0,0 +1,181 @@
+package astutils
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const src = `package app
+
+import (
+	"fmt"
+
+	// this line is used by starport scaffolding # imports
+)
+
+var (
+	perms = map[string][]string{
+		"foo": nil, // foo has no permissions
+	}
+)
+
+type App struct {
+	FooKeeper string
+
+	// mm is the module manager
+	mm string
+}
+
+func New() *App {
+	app := &App{}
+
+	keys := newKeys(
+		"foo",
+		"bar",
+	)
+	inline(keys)
+
+	// create the module manager
+	app.mm = fmt.Sprint(keys)
+
+	return app
+}
+
+func defaults() *App {
+	return &App{
+		FooKeeper: "foo",
+	}
+}
+
+func newKeys(keys ...string) []string { return keys }
+
+func inline(keys []string) {}
+`
+
+func TestEdits(t *testing.T) {
+	tests := []struct {
+		name     string
+		edit     Edit
+		contains []string
+		err      error
+	}{
+		{
+			name:     "add import",
+			edit:     AddImport("bartypes", "example.com/x/bar/types"),
+			contains: []string{"\tbartypes \"example.com/x/bar/types\"\n)"},
+		},
+		{
+			name:     "add existing import",
+			edit:     AddImport("", "fmt"),
+			contains: []string{"import (\n\t\"fmt\"\n\n\t// this line"},
+		},
+		{
+			name:     "add declaration",
+			edit:     AddDecl("const Name = \"app\""),
+			contains: []string{"}\n\nconst Name = \"app\"\n\nfunc New()"},
+		},
+		{
+			name:     "add struct field",
+			edit:     AddStructField("App", "BarKeeper string"),
+			contains: []string{"\tmm        string\n\tBarKeeper string\n}"},
+		},
+		{
+			name:     "add existing struct field",
+			edit:     AddStructField("App", "FooKeeper int"),
+			contains: []string{"\tFooKeeper string\n"},
+		},
+		{
+			name: "add field to missing struct",
+			edit: AddStructField("Foo", "BarKeeper string"),
+			err:  ErrNotFound,
+		},
+		{
+			name:     "append call args",
+			edit:     AppendCallArgs("newKeys", `"baz"`, `"foo"`),
+			contains: []string{"\t\t\"bar\",\n\t\t\"baz\",\n\t)"},
+		},
+		{
+			name:     "append inline call args",
+			edit:     AppendCallArgs("inline", "nil"),
+			contains: []string{"inline(keys, nil)"},
+		},
+		{
+			name:     "insert call args",
+			edit:     InsertCallArgs("newKeys", 1, `"baz"`),
+			contains: []string{"\t\t\"foo\",\n\t\t\"baz\",\n\t\t\"bar\",\n"},
+		},
+		{
+			name: "append args to missing call",
+			edit: AppendCallArgs("module.NewManager", "foo"),
+			err:  ErrNotFound,
+		},
+		{
+			name:     "append composite literal",
+			edit:     AppendCompositeLit("perms", `"bar": {"burner"}`),
+			contains: []string{"\"foo\": nil, // foo has no permissions\n\t\t\"bar\": {\"burner\"},\n\t}"},
+		},
+		{
+			name:     "append to empty composite literal",
+			edit:     AppendCompositeLit("app", `FooKeeper: "foo"`),
+			contains: []string{`app := &App{FooKeeper: "foo"}`},
+		},
+		{
+			name:     "append returned composite literal",
+			edit:     AppendReturnLit("defaults", `mm: "mm"`),
+			contains: []string{"\t\tFooKeeper: \"foo\",\n\t\tmm:        \"mm\",\n\t}"},
+		},
+		{
+			name:     "insert statements before",
+			edit:     InsertStatementsBefore("New", "app.mm =", "keys = append(keys, \"baz\")"),
+			contains: []string{"\tinline(keys)\n\n\tkeys = append(keys, \"baz\")\n\n\t// create the module manager\n"},
+		},
+		{
+			name: "insert statements before missing statement",
+			edit: InsertStatementsBefore("New", "app.sm =", "keys = nil"),
+			err:  ErrNotFound,
+		},
+		{
+			name:     "insert statements before return",
+			edit:     InsertStatementsBeforeReturn("New", "app.FooKeeper = \"bar\""),
+			contains: []string{"\tapp.FooKeeper = \"bar\"\n\n\treturn app\n"},
+		},
+		{
+			name: "insert invalid statements",
+			edit: InsertStatementsBeforeReturn("New", "app.FooKeeper ="),
+			err:  errors.New("invalid code inserted"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.edit(src)
+			if tt.err != nil {
+				require.Error(t, err)
+				if errors.Is(tt.err, ErrNotFound) {
+					require.True(t, errors.Is(err, ErrNotFound))
+				} else {
+					require.Contains(t, err.Error(), tt.err.Error())
+				}
+				return
+			}
+			require.NoError(t, err)
+			for _, c := range tt.contains {
+				require.Contains(t, got, c)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	got, err := Apply(src,
+		AddImport("", "strings"),
+		AppendCallArgs("newKeys", `strings.ToLower("BAZ")`),
+	)
+	require.NoError(t, err)
+	require.Contains(t, got, "\t\"strings\"\n)")
+	require.Contains(t, got, "\t\tstrings.ToLower(\"BAZ\"),\n\t)")
+
+	_, err = Apply("package app\nfunc {", AddImport("", "strings"))
+	require.Error(t, err)
+}
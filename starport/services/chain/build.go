@@ -2,14 +2,17 @@ package chain
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 
-	"github.com/docker/docker/pkg/archive"
 	"github.com/pkg/errors"
 	"github.com/tendermint/starport/starport/pkg/checksum"
 	"github.com/tendermint/starport/starport/pkg/cmdrunner"
@@ -17,6 +20,7 @@ import (
 	"github.com/tendermint/starport/starport/pkg/cmdrunner/step"
 	"github.com/tendermint/starport/starport/pkg/goanalysis"
 	"github.com/tendermint/starport/starport/pkg/gocmd"
+	"github.com/tendermint/starport/starport/pkg/tarball"
 )
 
 const (
@@ -65,13 +69,36 @@ func (c *Chain) build(ctx context.Context, output string) (err error) {
 		return err
 	}
 
-	return gocmd.BuildPath(ctx, output, binary, path, buildFlags)
+	env, err := c.buildEnv()
+	if err != nil {
+		return err
+	}
+
+	if err := gocmd.BuildPath(ctx, output, binary, path, buildFlags, exec.StepOption(step.Env(env...))); err != nil {
+		return err
+	}
+
+	return c.postBuild(ctx)
 }
 
 // BuildRelease builds binaries for a release. targets is a list
 // of GOOS:GOARCH when provided. It defaults to your system when no targets provided.
 // prefix is used as prefix to tarballs containing each target.
-func (c *Chain) BuildRelease(ctx context.Context, output, prefix string, targets ...string) (releasePath string, err error) {
+// builds are reproducible, the release dir contains a manifest.json that describes
+// the release and a checksum.txt, which is signed when a signing key is provided.
+// an OCI image tarball is created for each linux target when the DockerImage option is used.
+func (c *Chain) BuildRelease(
+	ctx context.Context,
+	output,
+	prefix string,
+	targets []string,
+	options ...ReleaseOption,
+) (releasePath string, err error) {
+	var opts releaseOptions
+	for _, apply := range options {
+		apply(&opts)
+	}
+
 	if prefix == "" {
 		prefix = c.app.Name
 	}
@@ -79,6 +106,23 @@ func (c *Chain) BuildRelease(ctx context.Context, output, prefix string, targets
 		targets = []string{gocmd.BuildTarget(runtime.GOOS, runtime.GOARCH)}
 	}
 
+	// load the signing key first to not build a release that cannot be signed.
+	var signingKey ed25519.PrivateKey
+	if opts.signingKeyPath != "" {
+		if signingKey, err = checksum.ParsePrivateKeyFile(opts.signingKeyPath); err != nil {
+			return "", errors.Wrap(err, "cannot load the signing key")
+		}
+	}
+
+	if opts.dockerImage && !hasLinuxTarget(targets) {
+		return "", errors.New("docker images can only be built for linux targets, add one with --release.targets")
+	}
+
+	modTime, err := releaseModTime()
+	if err != nil {
+		return "", err
+	}
+
 	// prepare for build.
 	if err := c.setup(); err != nil {
 		return "", err
@@ -88,6 +132,8 @@ func (c *Chain) BuildRelease(ctx context.Context, output, prefix string, targets
 	if err != nil {
 		return "", err
 	}
+	// remove the local paths from the binaries to make the builds reproducible.
+	buildFlags = append(buildFlags, gocmd.FlagTrimpath)
 
 	binary, err := c.Binary()
 	if err != nil {
@@ -99,6 +145,11 @@ func (c *Chain) BuildRelease(ctx context.Context, output, prefix string, targets
 		return "", err
 	}
 
+	env, err := c.buildEnv()
+	if err != nil {
+		return "", err
+	}
+
 	releasePath = output
 	if releasePath == "" {
 		releasePath = filepath.Join(c.app.Path, releaseDir)
@@ -112,6 +163,8 @@ func (c *Chain) BuildRelease(ctx context.Context, output, prefix string, targets
 		return "", err
 	}
 
+	var releaseTargets []ReleaseTarget
+
 	for _, t := range targets {
 		// build binary for a target, tarball it and save it under the release dir.
 		goos, goarch, err := gocmd.ParseTarget(t)
@@ -125,22 +178,24 @@ func (c *Chain) BuildRelease(ctx context.Context, output, prefix string, targets
 		}
 		defer os.RemoveAll(out)
 
+		// binaries of images must be statically linked even if the env of the config
+		// enables cgo. the target has priority over the env of the config.
+		targetEnv := append([]string{}, env...)
+		if opts.dockerImage && goos == imageOS {
+			targetEnv = append(targetEnv, cmdrunner.Env(gocmd.EnvCGOEnabled, "0"))
+		}
+		targetEnv = append(targetEnv,
+			cmdrunner.Env(gocmd.EnvGOOS, goos),
+			cmdrunner.Env(gocmd.EnvGOARCH, goarch),
+		)
 		buildOptions := []exec.Option{
-			exec.StepOption(step.Env(
-				cmdrunner.Env(gocmd.EnvGOOS, goos),
-				cmdrunner.Env(gocmd.EnvGOARCH, goarch),
-			)),
+			exec.StepOption(step.Env(targetEnv...)),
 		}
 
 		if err := gocmd.BuildPath(ctx, out, binary, mainPath, buildFlags, buildOptions...); err != nil {
 			return "", err
 		}
 
-		tarr, err := archive.Tar(out, archive.Gzip)
-		if err != nil {
-			return "", err
-		}
-
 		tarName := fmt.Sprintf("%s_%s_%s.tar.gz", prefix, goos, goarch)
 		tarPath := filepath.Join(releasePath, tarName)
 
@@ -150,42 +205,120 @@ func (c *Chain) BuildRelease(ctx context.Context, output, prefix string, targets
 		}
 		defer tarf.Close()
 
-		if _, err := io.Copy(tarf, tarr); err != nil {
+		h := sha256.New()
+		if err := tarball.Create(io.MultiWriter(tarf, h), out, modTime); err != nil {
+			return "", err
+		}
+		if err := tarf.Close(); err != nil {
 			return "", err
 		}
-		tarf.Close()
+
+		releaseTarget := ReleaseTarget{
+			Target:  gocmd.BuildTarget(goos, goarch),
+			Archive: tarName,
+			SHA256:  hex.EncodeToString(h.Sum(nil)),
+		}
+
+		if opts.dockerImage && goos == imageOS {
+			imageName := fmt.Sprintf("%s_%s_%s.oci.tar", prefix, goos, goarch)
+			if err := c.buildImage(ctx, filepath.Join(releasePath, imageName), out, goarch, env, modTime); err != nil {
+				return "", err
+			}
+			releaseTarget.Image = imageName
+		}
+
+		releaseTargets = append(releaseTargets, releaseTarget)
+	}
+
+	manifest, err := c.releaseManifest(ctx, buildFlags, releaseTargets)
+	if err != nil {
+		return "", err
+	}
+	if signingKey != nil {
+		manifest.setPublicKey(signingKey.Public().(ed25519.PublicKey))
+	}
+	if err := writeReleaseManifest(releasePath, manifest); err != nil {
+		return "", err
+	}
+
+	if err := c.postBuild(ctx); err != nil {
+		return "", err
 	}
 
 	checksumPath := filepath.Join(releasePath, checksumTxt)
 
+	// a checksum file left from a previous release in the output dir must not be checksummed.
+	for _, path := range []string{checksumPath, checksumPath + checksum.SignatureExt} {
+		if err := os.RemoveAll(path); err != nil {
+			return "", err
+		}
+	}
+
 	// create a checksum.txt and return with the path to release dir.
-	return releasePath, checksum.Sum(releasePath, checksumPath)
+	if err := checksum.Sum(releasePath, checksumPath); err != nil {
+		return "", err
+	}
+
+	if signingKey != nil {
+		if _, err := checksum.Sign(checksumPath, signingKey); err != nil {
+			return "", err
+		}
+	}
+
+	return releasePath, nil
+}
+
+func hasLinuxTarget(targets []string) bool {
+	for _, t := range targets {
+		if goos, _, err := gocmd.ParseTarget(t); err == nil && goos == imageOS {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *Chain) preBuild(ctx context.Context) (buildFlags []string, err error) {
+	conf, err := c.Config()
+	if err != nil {
+		return nil, err
+	}
+
 	chainID, err := c.ID()
 	if err != nil {
 		return nil, err
 	}
 
-	ldflags := gocmd.Ldflags(
+	// ldflags of the config are appended last to be able to override the default ones.
+	ldflags := gocmd.Ldflags(append([]string{
 		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Name=%s", strings.Title(c.app.Name)),
 		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.AppName=%sd", c.app.Name),
 		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Version=%s", c.sourceVersion.tag),
 		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Commit=%s", c.sourceVersion.hash),
 		fmt.Sprintf("-X %s/cmd/%s/cmd.ChainID=%s", c.app.ImportPath, c.app.D(), chainID),
-	)
+	}, conf.Build.LDFlags...)...)
 	buildFlags = []string{
 		gocmd.FlagMod, gocmd.FlagModValueReadOnly,
 		gocmd.FlagLdflags, ldflags,
 	}
+	if len(conf.Build.Tags) > 0 {
+		buildFlags = append(buildFlags, gocmd.FlagTags, strings.Join(conf.Build.Tags, ","))
+	}
 
 	fmt.Fprintln(c.stdLog().out, "📦 Installing dependencies...")
 
-	if err := gocmd.ModTidy(ctx, c.app.Path); err != nil {
+	// the env of the config applies to the dependencies too, like GOPRIVATE.
+	env, err := c.buildEnv()
+	if err != nil {
 		return nil, err
 	}
-	if err := gocmd.ModVerify(ctx, c.app.Path); err != nil {
+	if err := gocmd.ModTidy(ctx, c.app.Path, exec.StepOption(step.Env(env...))); err != nil {
+		return nil, err
+	}
+	if err := gocmd.ModVerify(ctx, c.app.Path, exec.StepOption(step.Env(env...))); err != nil {
+		return nil, err
+	}
+
+	if err := c.runBuildHooks(ctx, "pre_build", conf.Build.PreBuild); err != nil {
 		return nil, err
 	}
 
@@ -194,6 +327,61 @@ func (c *Chain) preBuild(ctx context.Context) (buildFlags []string, err error) {
 	return buildFlags, nil
 }
 
+func (c *Chain) postBuild(ctx context.Context) error {
+	conf, err := c.Config()
+	if err != nil {
+		return err
+	}
+
+	return c.runBuildHooks(ctx, "post_build", conf.Build.PostBuild)
+}
+
+// runBuildHooks runs the shell commands of a build hook one by one in the app's directory.
+func (c *Chain) runBuildHooks(ctx context.Context, name string, hooks []string) error {
+	env, err := c.buildEnv()
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range hooks {
+		fmt.Fprintf(c.stdLog().out, "🪝 Running %s hook: %s\n", name, hook)
+
+		err := exec.Exec(
+			ctx,
+			[]string{"sh", "-c", hook},
+			exec.StepOption(step.Workdir(c.app.Path)),
+			exec.StepOption(step.Env(env...)),
+			exec.IncludeStdLogsToError(),
+		)
+		if err != nil {
+			return errors.Wrapf(err, "%s hook %q failed", name, hook)
+		}
+	}
+
+	return nil
+}
+
+// buildEnv returns the environment variables defined in config.yml>build.env.
+func (c *Chain) buildEnv() ([]string, error) {
+	conf, err := c.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(conf.Build.Env))
+	for name := range conf.Build.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	env := make([]string, len(names))
+	for i, name := range names {
+		env[i] = cmdrunner.Env(name, conf.Build.Env[name])
+	}
+
+	return env, nil
+}
+
 func (c *Chain) discoverMain(path string) (pkgPath string, err error) {
 	conf, err := c.Config()
 	if err != nil {
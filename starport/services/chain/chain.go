@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/gookit/color"
@@ -14,19 +15,27 @@ import (
 	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
 	"github.com/tendermint/starport/starport/pkg/confile"
 	"github.com/tendermint/starport/starport/pkg/cosmosver"
+	"github.com/tendermint/starport/starport/pkg/events"
 	"github.com/tendermint/starport/starport/pkg/repoversion"
 	"github.com/tendermint/starport/starport/pkg/xurl"
 )
 
 var (
-	appBackendSourceWatchPaths = []string{
+	// appBackendCodePaths hold the Go source code of the app.
+	appBackendCodePaths = []string{
 		"app",
 		"cmd",
 		"x",
+	}
+
+	// appBackendProtoPaths hold the proto files of the app.
+	appBackendProtoPaths = []string{
 		"proto",
 		"third_party",
 	}
 
+	appBackendSourceWatchPaths = append(append([]string{}, appBackendCodePaths...), appBackendProtoPaths...)
+
 	errorColor = color.Red.Render
 	infoColor  = color.Yellow.Render
 )
@@ -60,10 +69,28 @@ type Chain struct {
 	serveRefresher chan struct{}
 	served         bool
 
+	// generateMu prevents concurrent code generations, the proto files can be
+	// regenerated while the app is being built.
+	generateMu *sync.Mutex
+
 	// protoBuiltAtLeastOnce indicates that app's proto generation at least made once.
 	protoBuiltAtLeastOnce bool
 
 	stdout, stderr io.Writer
+
+	// ev collects the progress of the chain's operations instead of printing it.
+	ev events.Bus
+
+	// evWriter sends the lines of the logs to ev, it keeps the incomplete lines
+	// between writes.
+	evWriter io.Writer
+
+	// serveEv receives the lifecycle events of the chain while it's served.
+	serveEv events.Bus
+
+	// dashboardHost is the host to serve the dashboard at while the chain is served,
+	// the dashboard is disabled when empty.
+	dashboardHost string
 }
 
 // chainOptions holds user given options that overwrites chain's defaults.
@@ -83,6 +110,9 @@ type chainOptions struct {
 
 	// path of a custom config file
 	ConfigFile string
+
+	// name of the config profile to merge over the base config
+	ConfigProfile string
 }
 
 // Option configures Chain.
@@ -123,6 +153,13 @@ func ConfigFile(configFile string) Option {
 	}
 }
 
+// ConfigProfile selects the profile of the config file to use
+func ConfigProfile(name string) Option {
+	return func(c *Chain) {
+		c.options.ConfigProfile = name
+	}
+}
+
 // EnableThirdPartyModuleCodegen enables code generation for third party modules,
 // including the SDK.
 func EnableThirdPartyModuleCodegen() Option {
@@ -131,6 +168,13 @@ func EnableThirdPartyModuleCodegen() Option {
 	}
 }
 
+// CollectEvents collects the progress of the chain's operations as events.
+func CollectEvents(ev events.Bus) Option {
+	return func(c *Chain) {
+		c.ev = ev
+	}
+}
+
 // New initializes a new Chain with options that its source lives at path.
 func New(path string, options ...Option) (*Chain, error) {
 	app, err := NewAppAt(path)
@@ -142,6 +186,7 @@ func New(path string, options ...Option) (*Chain, error) {
 		app:            app,
 		logLevel:       LogSilent,
 		serveRefresher: make(chan struct{}, 1),
+		generateMu:     &sync.Mutex{},
 		stdout:         io.Discard,
 		stderr:         io.Discard,
 	}
@@ -156,6 +201,10 @@ func New(path string, options ...Option) (*Chain, error) {
 		c.stderr = os.Stderr
 	}
 
+	if c.ev != nil {
+		c.evWriter = events.NewWriter(c.ev)
+	}
+
 	c.sourceVersion, err = c.appVersion()
 	if err != nil && err != git.ErrRepositoryNotExists {
 		return nil, err
@@ -222,7 +271,7 @@ func (c *Chain) Config() (chainconfig.Config, error) {
 	if configPath == "" {
 		return chainconfig.DefaultConf, nil
 	}
-	return chainconfig.ParseFile(configPath)
+	return chainconfig.ParseFile(configPath, chainconfig.Profile(c.options.ConfigProfile))
 }
 
 // ID returns the chain's id.
@@ -400,27 +449,33 @@ func (c *Chain) KeyringBackend() (chaincmd.KeyringBackend, error) {
 
 // Commands returns the runner execute commands on the chain's binary
 func (c *Chain) Commands(ctx context.Context) (chaincmdrunner.Runner, error) {
-	id, err := c.ID()
+	home, err := c.Home()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
 
-	home, err := c.Home()
+	config, err := c.Config()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
 
-	binary, err := c.Binary()
+	return c.commands(ctx, home, config.Host.RPC, c.genPrefix(logAppd))
+}
+
+// commands returns the runner to execute commands on the chain's binary for the node
+// that has its home at home and listens for RPC requests at rpcAddress.
+func (c *Chain) commands(ctx context.Context, home, rpcAddress, daemonLogPrefix string) (chaincmdrunner.Runner, error) {
+	id, err := c.ID()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
 
-	backend, err := c.KeyringBackend()
+	binary, err := c.Binary()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
 
-	config, err := c.Config()
+	backend, err := c.KeyringBackend()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
@@ -429,7 +484,7 @@ func (c *Chain) Commands(ctx context.Context) (chaincmdrunner.Runner, error) {
 		chaincmd.WithChainID(id),
 		chaincmd.WithHome(home),
 		chaincmd.WithVersion(c.Version),
-		chaincmd.WithNodeAddress(xurl.TCP(config.Host.RPC)),
+		chaincmd.WithNodeAddress(xurl.TCP(rpcAddress)),
 		chaincmd.WithKeyringBackend(backend),
 	}
 
@@ -440,7 +495,7 @@ func (c *Chain) Commands(ctx context.Context) (chaincmdrunner.Runner, error) {
 		ccrOptions = append(ccrOptions,
 			chaincmdrunner.Stdout(os.Stdout),
 			chaincmdrunner.Stderr(os.Stderr),
-			chaincmdrunner.DaemonLogPrefix(c.genPrefix(logAppd)),
+			chaincmdrunner.DaemonLogPrefix(daemonLogPrefix),
 		)
 	}
 
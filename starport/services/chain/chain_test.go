@@ -1,6 +1,7 @@
 package chain
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	"github.com/docker/docker/pkg/archive"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/starport/starport/pkg/events"
 )
 
 func TestSourceVersion(t *testing.T) {
@@ -46,3 +48,16 @@ func tempSource(t *testing.T, tarPath string) (path string) {
 
 	return filepath.Join(dir, dirs[0].Name())
 }
+
+func TestStdLogEvents(t *testing.T) {
+	bus := events.NewBus()
+	c, err := New(tempSource(t, "testdata/version/mars.v0.2.tar.gz"), CollectEvents(bus))
+	require.NoError(t, err)
+
+	// a line written in several calls is sent as one event.
+	go func() {
+		fmt.Fprint(c.stdLog().out, "🛠️  Building")
+		fmt.Fprintln(c.stdLog().out, " the blockchain...")
+	}()
+	require.Equal(t, events.New(events.StatusDone, "🛠️  Building the blockchain..."), <-bus)
+}
@@ -0,0 +1,65 @@
+package chain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/cosmosanalysis/module"
+	"github.com/tendermint/starport/starport/pkg/cosmosdashboard"
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+	"github.com/tendermint/starport/starport/pkg/xurl"
+)
+
+// Dashboard returns the dashboard of the chain served with config, it displays the
+// accounts of config and uses the modules of the app to decode the messages.
+func (c *Chain) Dashboard(ctx context.Context, config chainconfig.Config) (cosmosdashboard.Dashboard, error) {
+	id, err := c.ID()
+	if err != nil {
+		return cosmosdashboard.Dashboard{}, err
+	}
+
+	commands, err := c.Commands(ctx)
+	if err != nil {
+		return cosmosdashboard.Dashboard{}, err
+	}
+
+	modules, err := module.Discover(ctx, c.app.Path, config.Build.Proto.Path)
+	if err != nil {
+		return cosmosdashboard.Dashboard{}, err
+	}
+
+	options := []cosmosdashboard.Option{
+		cosmosdashboard.ChainID(id),
+		cosmosdashboard.RPCAddress(xurl.HTTP(config.Host.RPC)),
+		cosmosdashboard.APIAddress(xurl.HTTP(config.Host.API)),
+		cosmosdashboard.Modules(modules),
+	}
+
+	// the accounts without an address are created in the keyring.
+	for _, account := range config.Accounts {
+		address := account.Address
+		if address == "" {
+			key, err := commands.ShowAccount(ctx, account.Name)
+			if err != nil {
+				return cosmosdashboard.Dashboard{}, err
+			}
+			address = key.Address
+		}
+
+		options = append(options, cosmosdashboard.Account(account.Name, address))
+	}
+
+	if config.Faucet.Name != nil {
+		options = append(options, cosmosdashboard.FaucetAddress(xurl.HTTP(chainconfig.FaucetHost(config))))
+	}
+
+	return cosmosdashboard.New(options...), nil
+}
+
+func (c *Chain) runDashboardServer(ctx context.Context, host string, dashboard cosmosdashboard.Dashboard) error {
+	return xhttp.Serve(ctx, &http.Server{
+		Addr:    host,
+		Handler: dashboard,
+	})
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/pkg/errors"
 	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
 	"github.com/tendermint/starport/starport/pkg/cosmoscoin"
 	"github.com/tendermint/starport/starport/pkg/cosmosfaucet"
+	"github.com/tendermint/starport/starport/pkg/cosmosutil"
 	"github.com/tendermint/starport/starport/pkg/xurl"
 )
 
@@ -25,6 +27,14 @@ var (
 	envAPIAddress = os.Getenv("API_ADDRESS")
 )
 
+const (
+	// faucetLimits is the file keeping the transfers made by the faucet to enforce its limits.
+	faucetLimits = "faucet_limits.json"
+
+	// defaultFaucetIPRateLimitWindow is the default timeframe of the faucet's rate limit per IP.
+	defaultFaucetIPRateLimitWindow = time.Hour
+)
+
 // Faucet returns the faucet for the chain or an error if the faucet
 // configuration is wrong or not configured (not enabled) at all.
 func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
@@ -48,7 +58,8 @@ func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
 		return cosmosfaucet.Faucet{}, ErrFaucetIsNotEnabled
 	}
 
-	if _, err := commands.ShowAccount(ctx, *conf.Faucet.Name); err != nil {
+	faucetAccount, err := commands.ShowAccount(ctx, *conf.Faucet.Name)
+	if err != nil {
 		if err == chaincmdrunner.ErrAccountDoesNotExist {
 			return cosmosfaucet.Faucet{}, ErrFaucetAccountDoesNotExist
 		}
@@ -91,8 +102,9 @@ func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
 		faucetOptions = append(faucetOptions, cosmosfaucet.Coin(amount, amountMax, denom))
 	}
 
+	rateLimitWindow := cosmosfaucet.DefaultRefreshWindow
 	if conf.Faucet.RateLimitWindow != "" {
-		rateLimitWindow, err := time.ParseDuration(conf.Faucet.RateLimitWindow)
+		rateLimitWindow, err = time.ParseDuration(conf.Faucet.RateLimitWindow)
 		if err != nil {
 			return cosmosfaucet.Faucet{}, fmt.Errorf("%s: %s", err, conf.Faucet.RateLimitWindow)
 		}
@@ -100,6 +112,62 @@ func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
 		faucetOptions = append(faucetOptions, cosmosfaucet.RefreshWindow(rateLimitWindow))
 	}
 
+	// keep track of the transfers in the chain's save dir so the limits are kept between serves.
+	saveDir, err := c.chainSavePath()
+	if err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+
+	store, err := cosmosfaucet.NewFileLimitStore(filepath.Join(saveDir, faucetLimits))
+	if err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+	if err := store.Prune(time.Now().Add(-rateLimitWindow)); err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+
+	faucetOptions = append(faucetOptions, cosmosfaucet.Store(store))
+
+	if conf.Faucet.BatchWindow != "" {
+		batchWindow, err := time.ParseDuration(conf.Faucet.BatchWindow)
+		if err != nil {
+			return cosmosfaucet.Faucet{}, fmt.Errorf("%s: %s", err, conf.Faucet.BatchWindow)
+		}
+
+		faucetOptions = append(faucetOptions, cosmosfaucet.BatchWindow(batchWindow))
+	}
+
+	if conf.Faucet.PoWDifficulty > 0 {
+		faucetOptions = append(faucetOptions, cosmosfaucet.ProofOfWork(conf.Faucet.PoWDifficulty, 0))
+	}
+
+	if conf.Faucet.IPRateLimit > 0 {
+		ipRateLimitWindow := defaultFaucetIPRateLimitWindow
+		if conf.Faucet.IPRateLimitWindow != "" {
+			ipRateLimitWindow, err = time.ParseDuration(conf.Faucet.IPRateLimitWindow)
+			if err != nil {
+				return cosmosfaucet.Faucet{}, fmt.Errorf("%s: %s", err, conf.Faucet.IPRateLimitWindow)
+			}
+		}
+
+		faucetOptions = append(faucetOptions, cosmosfaucet.IPRateLimit(conf.Faucet.IPRateLimit, ipRateLimitWindow))
+	}
+
+	if conf.Faucet.InProcess {
+		// the accounts of the chain share the address prefix of the faucet account.
+		prefix, err := cosmosutil.GetAddressPrefix(faucetAccount.Address)
+		if err != nil {
+			return cosmosfaucet.Faucet{}, err
+		}
+
+		sender, err := c.faucetSender(conf.Host.RPC, prefix)
+		if err != nil {
+			return cosmosfaucet.Faucet{}, err
+		}
+
+		faucetOptions = append(faucetOptions, cosmosfaucet.Sender(sender))
+	}
+
 	// init the faucet with options and return.
 	return cosmosfaucet.New(ctx, commands, faucetOptions...)
 }
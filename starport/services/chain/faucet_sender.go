@@ -0,0 +1,119 @@
+package chain
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/tendermint/starport/starport/pkg/cosmosaccount"
+	"github.com/tendermint/starport/starport/pkg/cosmosclient"
+	"github.com/tendermint/starport/starport/pkg/cosmosfaucet"
+	"github.com/tendermint/starport/starport/pkg/xurl"
+)
+
+// faucetClientSender sends the faucet's coins by signing and broadcasting txs in-process
+// with a cosmosclient.Client. the client is created on first use since the node is not
+// running yet when the faucet is created.
+type faucetClientSender struct {
+	mu     sync.Mutex
+	client *cosmosclient.Client
+
+	home           string
+	rpcAddress     string
+	addressPrefix  string
+	keyringService string
+	keyringBackend cosmosaccount.KeyringBackend
+}
+
+// faucetSender returns a sender to transfer the faucet's coins in-process to the accounts
+// with addressPrefix.
+func (c *Chain) faucetSender(rpcAddress, addressPrefix string) (*faucetClientSender, error) {
+	home, err := c.Home()
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err := c.KeyringBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	return &faucetClientSender{
+		home:          home,
+		rpcAddress:    rpcAddress,
+		addressPrefix: addressPrefix,
+		// the node's keyring is named after the app name set during build.
+		keyringService: strings.Title(c.app.Name),
+		keyringBackend: cosmosaccount.KeyringBackend(backend),
+	}, nil
+}
+
+// SendCoins implements cosmosfaucet.CoinSender.
+func (s *faucetClientSender) SendCoins(ctx context.Context, fromAccountName, toAddress, amount string) error {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	coins, err := sdktypes.ParseCoinsNormalized(amount)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.BankSend(fromAccountName, toAddress, coins)
+	return err
+}
+
+// MultiSendCoins implements cosmosfaucet.MultiCoinSender.
+func (s *faucetClientSender) MultiSendCoins(ctx context.Context, fromAccountName string, outputs []cosmosfaucet.SendOutput) error {
+	if len(outputs) == 0 {
+		return nil
+	}
+
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	bankOutputs := make([]banktypes.Output, len(outputs))
+	for i, output := range outputs {
+		coins, err := sdktypes.ParseCoinsNormalized(output.Amount)
+		if err != nil {
+			return err
+		}
+
+		bankOutputs[i] = banktypes.Output{
+			Address: output.Address,
+			Coins:   coins,
+		}
+	}
+
+	_, err = client.BankMultiSend(fromAccountName, bankOutputs)
+	return err
+}
+
+func (s *faucetClientSender) getClient(ctx context.Context) (*cosmosclient.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.client != nil {
+		return s.client, nil
+	}
+
+	client, err := cosmosclient.New(
+		ctx,
+		cosmosclient.WithHome(s.home),
+		cosmosclient.WithNodeAddress(xurl.HTTP(s.rpcAddress)),
+		cosmosclient.WithAddressPrefix(s.addressPrefix),
+		cosmosclient.WithKeyringServiceName(s.keyringService),
+		cosmosclient.WithKeyringBackend(s.keyringBackend),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.client = &client
+	return s.client, nil
+}
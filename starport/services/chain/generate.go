@@ -84,6 +84,9 @@ func (c *Chain) Generate(
 	target GenerateTarget,
 	additionalTargets ...GenerateTarget,
 ) error {
+	c.generateMu.Lock()
+	defer c.generateMu.Unlock()
+
 	var targetOptions generateOptions
 
 	for _, apply := range append(additionalTargets, target) {
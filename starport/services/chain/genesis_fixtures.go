@@ -0,0 +1,86 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/imdario/mergo"
+	"github.com/pkg/errors"
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/cosmosanalysis/module"
+)
+
+// genesisFixtures loads the genesis fixtures defined in the config and validates each of them
+// against the GenesisState proto message of its module. the returned changes are meant to be
+// merged into the genesis, each fixture is placed under app_state.<module>.
+func (c *Chain) genesisFixtures(ctx context.Context, conf chainconfig.Config) (map[string]interface{}, error) {
+	if len(conf.GenesisFixtures) == 0 {
+		return nil, nil
+	}
+
+	modules, err := module.Discover(ctx, c.app.Path, conf.Build.Proto.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	appState := make(map[string]interface{})
+
+	for _, fixture := range conf.GenesisFixtures {
+		m, ok := findModule(modules, fixture.Module)
+		if !ok {
+			return nil, fmt.Errorf("genesis fixture %s: module %q cannot be found in the app", fixture.File, fixture.Module)
+		}
+
+		state, err := c.loadGenesisFixture(fixture.File)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := m.ValidateGenesis(ctx, state); err != nil {
+			return nil, errors.Wrapf(err, "genesis fixture %s", fixture.File)
+		}
+
+		// fixtures of the same module are merged in order.
+		if current, ok := appState[fixture.Module].(map[string]interface{}); ok {
+			if err := mergo.Merge(&current, state, mergo.WithOverride); err != nil {
+				return nil, err
+			}
+			state = current
+		}
+		appState[fixture.Module] = state
+	}
+
+	return map[string]interface{}{"app_state": appState}, nil
+}
+
+// loadGenesisFixture reads the JSON or YAML fixture file at path.
+func (c *Chain) loadGenesisFixture(path string) (map[string]interface{}, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(c.app.Path, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read genesis fixture")
+	}
+
+	// JSON is a subset of YAML, both are decoded the same way.
+	var state map[string]interface{}
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return nil, errors.Wrapf(err, "cannot decode genesis fixture %s", path)
+	}
+
+	return state, nil
+}
+
+func findModule(modules []module.Module, name string) (module.Module, bool) {
+	for _, m := range modules {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return module.Module{}, false
+}
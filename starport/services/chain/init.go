@@ -24,6 +24,10 @@ func (c *Chain) Init(ctx context.Context, initAccounts bool) error {
 		return &CannotBuildAppError{err}
 	}
 
+	if initAccounts && conf.IsTestnet() {
+		return c.InitTestnet(ctx)
+	}
+
 	if err := c.InitChain(ctx); err != nil {
 		return err
 	}
@@ -46,6 +50,12 @@ func (c *Chain) InitChain(ctx context.Context) error {
 		return err
 	}
 
+	// fixtures are validated before the persistent data is removed.
+	fixtures, err := c.genesisFixtures(ctx, conf)
+	if err != nil {
+		return &CannotBuildAppError{err}
+	}
+
 	// cleanup persistent data from previous `serve`.
 	home, err := c.Home()
 	if err != nil {
@@ -55,6 +65,15 @@ func (c *Chain) InitChain(ctx context.Context) error {
 		return err
 	}
 
+	// transfers made by the faucet on the previous state are no longer relevant.
+	saveDir, err := c.chainSavePath()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(saveDir, faucetLimits)); err != nil {
+		return err
+	}
+
 	commands, err := c.Commands(ctx)
 	if err != nil {
 		return err
@@ -72,40 +91,51 @@ func (c *Chain) InitChain(ctx context.Context) error {
 		return err
 	}
 
+	// the genesis defined in the config has priority over the fixtures.
+	if fixtures != nil {
+		if conf.Genesis != nil {
+			if err := mergo.Merge(&fixtures, conf.Genesis, mergo.WithOverride); err != nil {
+				return err
+			}
+		}
+		conf.Genesis = fixtures
+	}
+
 	// make sure that chain id given during chain.New() has the most priority.
 	if conf.Genesis != nil {
 		conf.Genesis["chain_id"] = chainID
 	}
 
-	// Initilize app config
-	genesisPath, err := c.GenesisPath()
-	if err != nil {
-		return err
-	}
-	appTOMLPath, err := c.AppTOMLPath()
-	if err != nil {
-		return err
-	}
-	clientTOMLPath, err := c.ClientTOMLPath()
-	if err != nil {
-		return err
-	}
-	configTOMLPath, err := c.ConfigTOMLPath()
-	if err != nil {
-		return err
-	}
+	return c.overwriteNodeConfigs(home, conf)
+}
+
+// configFile is a config file of the node and the changes to overwrite in it.
+type configFile struct {
+	ec      confile.EncodingCreator
+	path    string
+	changes map[string]interface{}
+}
 
-	appconfigs := []struct {
-		ec      confile.EncodingCreator
-		path    string
-		changes map[string]interface{}
-	}{
-		{confile.DefaultJSONEncodingCreator, genesisPath, conf.Genesis},
-		{confile.DefaultTOMLEncodingCreator, appTOMLPath, conf.Init.App},
-		{confile.DefaultTOMLEncodingCreator, clientTOMLPath, conf.Init.Client},
-		{confile.DefaultTOMLEncodingCreator, configTOMLPath, conf.Init.Config},
+// overwriteNodeConfigs overwrites the genesis and the configurations of the node
+// at home with the values defined in Starport's config.yml.
+func (c *Chain) overwriteNodeConfigs(home string, conf chainconfig.Config) error {
+	appconfigs := append([]configFile{
+		{confile.DefaultJSONEncodingCreator, filepath.Join(home, "config/genesis.json"), conf.Genesis},
+	}, nodeTOMLConfigs(home, conf)...)
+
+	return overwriteConfigFiles(appconfigs)
+}
+
+// nodeTOMLConfigs returns the TOML configs of the node at home with their changes from config.yml.
+func nodeTOMLConfigs(home string, conf chainconfig.Config) []configFile {
+	return []configFile{
+		{confile.DefaultTOMLEncodingCreator, filepath.Join(home, "config/app.toml"), conf.Init.App},
+		{confile.DefaultTOMLEncodingCreator, filepath.Join(home, "config/client.toml"), conf.Init.Client},
+		{confile.DefaultTOMLEncodingCreator, filepath.Join(home, "config/config.toml"), conf.Init.Config},
 	}
+}
 
+func overwriteConfigFiles(appconfigs []configFile) error {
 	for _, ac := range appconfigs {
 		cf := confile.New(ac.ec, ac.path)
 		var conf map[string]interface{}
@@ -125,11 +155,29 @@ func (c *Chain) InitChain(ctx context.Context) error {
 
 // InitAccounts initializes the chain accounts and creates validator gentxs
 func (c *Chain) InitAccounts(ctx context.Context, conf chainconfig.Config) error {
+	if _, err := c.initGenesisAccounts(ctx, conf); err != nil {
+		return err
+	}
+
+	validator := conf.ListValidators()[0]
+	_, err := c.IssueGentx(ctx, Validator{
+		Name:          validator.Name,
+		StakingAmount: validator.Staked,
+	})
+	return err
+}
+
+// initGenesisAccounts adds the accounts from config into the keyring and the genesis
+// of the chain. The accounts that have keys in the keyring are returned by their names
+// with their mnemonics set.
+func (c *Chain) initGenesisAccounts(ctx context.Context, conf chainconfig.Config) (map[string]chainconfig.Account, error) {
 	commands, err := c.Commands(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	keys := make(map[string]chainconfig.Account)
+
 	// add accounts from config into genesis
 	for _, account := range conf.Accounts {
 		var generatedAccount chaincmdrunner.Account
@@ -139,14 +187,18 @@ func (c *Chain) InitAccounts(ctx context.Context, conf chainconfig.Config) error
 		if accountAddress == "" {
 			generatedAccount, err = commands.AddAccount(ctx, account.Name, account.Mnemonic, account.CoinType)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			accountAddress = generatedAccount.Address
+
+			key := account
+			key.Mnemonic = generatedAccount.Mnemonic
+			keys[account.Name] = key
 		}
 
 		coins := strings.Join(account.Coins, ",")
 		if err := commands.AddGenesisAccount(ctx, accountAddress, coins); err != nil {
-			return err
+			return nil, err
 		}
 
 		if account.Address == "" {
@@ -167,11 +219,7 @@ func (c *Chain) InitAccounts(ctx context.Context, conf chainconfig.Config) error
 		}
 	}
 
-	_, err = c.IssueGentx(ctx, Validator{
-		Name:          conf.Validator.Name,
-		StakingAmount: conf.Validator.Staked,
-	})
-	return err
+	return keys, nil
 }
 
 // IssueGentx generates a gentx from the validator information in chain config and import it in the chain genesis
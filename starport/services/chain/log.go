@@ -56,6 +56,9 @@ func (c *Chain) stdLog() std {
 		stdout = os.Stdout
 		stderr = os.Stderr
 	}
+	if c.evWriter != nil {
+		stdout = c.evWriter
+	}
 	return std{
 		out: stdout,
 		err: stderr,
@@ -69,3 +72,12 @@ func (c *Chain) genPrefix(logType logType) string {
 		New(prefix.Name, prefixgen.Common(prefixgen.Color(prefix.Color))...).
 		Gen(c.app.Name)
 }
+
+// genNodePrefix generates the daemon log prefix for a secondary node of a local testnet.
+func (c *Chain) genNodePrefix(index int) string {
+	prefix := prefixes[logAppd]
+
+	return prefixgen.
+		New(prefix.Name+" %d", prefixgen.Common(prefixgen.Color(prefix.Color))...).
+		Gen(c.app.Name, index)
+}
@@ -0,0 +1,31 @@
+package chain
+
+import (
+	"github.com/tendermint/starport/starport/chainconfig"
+)
+
+// stargateFortySevenPlugin is the plugin of the chains from Cosmos-SDK v0.47.0.
+// the block broadcast mode is removed from this version on.
+type stargateFortySevenPlugin struct {
+	*stargatePlugin
+}
+
+func newStargateFortySevenPlugin(app App) *stargateFortySevenPlugin {
+	return &stargateFortySevenPlugin{
+		stargatePlugin: newStargatePlugin(app),
+	}
+}
+
+func (p *stargateFortySevenPlugin) Name() string {
+	return "Stargate v0.47"
+}
+
+func (p *stargateFortySevenPlugin) Configure(homePath string, conf chainconfig.Config) error {
+	if err := p.appTOML(homePath, conf); err != nil {
+		return err
+	}
+	if err := p.clientTOML(homePath, "sync"); err != nil {
+		return err
+	}
+	return p.configTOML(homePath, conf)
+}
@@ -49,7 +49,7 @@ func (p *stargatePlugin) Configure(homePath string, conf chainconfig.Config) err
 	if err := p.appTOML(homePath, conf); err != nil {
 		return err
 	}
-	if err := p.clientTOML(homePath); err != nil {
+	if err := p.clientTOML(homePath, "block"); err != nil {
 		return err
 	}
 	return p.configTOML(homePath, conf)
@@ -99,7 +99,7 @@ func (p *stargatePlugin) configTOML(homePath string, conf chainconfig.Config) er
 	return err
 }
 
-func (p *stargatePlugin) clientTOML(homePath string) error {
+func (p *stargatePlugin) clientTOML(homePath, broadcastMode string) error {
 	path := filepath.Join(homePath, "config/client.toml")
 	config, err := toml.LoadFile(path)
 	if os.IsNotExist(err) {
@@ -109,7 +109,7 @@ func (p *stargatePlugin) clientTOML(homePath string) error {
 		return err
 	}
 	config.Set("keyring-backend", "test")
-	config.Set("broadcast-mode", "block")
+	config.Set("broadcast-mode", broadcastMode)
 	file, err := os.OpenFile(path, os.O_RDWR|os.O_TRUNC, 0644)
 	if err != nil {
 		return err
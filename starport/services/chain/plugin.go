@@ -5,6 +5,7 @@ import (
 
 	"github.com/tendermint/starport/starport/chainconfig"
 	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
+	"github.com/tendermint/starport/starport/pkg/cosmosver"
 )
 
 // TODO omit -cli log messages for Stargate.
@@ -26,6 +27,31 @@ type Plugin interface {
 	Home() string
 }
 
+// pluginVersion is a plugin for the chains with an SDK version from minVersion.
+type pluginVersion struct {
+	minVersion cosmosver.Version
+	new        func(App) Plugin
+}
+
+// plugins are the known plugins sorted by their min SDK versions.
+var plugins = []pluginVersion{
+	{cosmosver.StargateFortyVersion, func(app App) Plugin { return newStargatePlugin(app) }},
+	{cosmosver.StargateFortySevenVersion, func(app App) Plugin { return newStargateFortySevenPlugin(app) }},
+}
+
+// pickPlugin picks the plugin with the highest min SDK version that supports the chain.
 func (c *Chain) pickPlugin() Plugin {
-	return newStargatePlugin(c.app)
+	return pickPlugin(c.app, c.Version)
+}
+
+func pickPlugin(app App, version cosmosver.Version) Plugin {
+	// the prereleases of a min version are supported by its plugin.
+	version = version.Release()
+
+	for i := len(plugins) - 1; i > 0; i-- {
+		if version.GTE(plugins[i].minVersion) {
+			return plugins[i].new(app)
+		}
+	}
+	return plugins[0].new(app)
 }
@@ -0,0 +1,134 @@
+package chain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/chaincmd"
+	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
+	"github.com/tendermint/starport/starport/pkg/cosmosver"
+)
+
+// fakeBinary is a chain binary that records the args of its calls in its dir.
+const fakeBinary = `#!/bin/sh
+echo "$@" >> "$(dirname "$0")/calls"
+case " $* " in
+*" gentx "*) echo 'Genesis transaction written to "/gentx.json"' >&2 ;;
+esac
+`
+
+func TestPlugins(t *testing.T) {
+	cases := []struct {
+		version       string
+		name          string
+		broadcastMode string
+		calls         []string
+	}{
+		{
+			version:       "v0.42.9",
+			name:          "Stargate",
+			broadcastMode: "block",
+			calls: []string{
+				"gentx alice 100stake --moniker node --chain-id test --keyring-backend test --home HOME",
+				"collect-gentxs --home HOME",
+				"unsafe-reset-all --home HOME",
+				"start --pruning nothing --grpc.address 0.0.0.0:9090 --home HOME",
+			},
+		},
+		{
+			version:       "v0.44.5",
+			name:          "Stargate",
+			broadcastMode: "block",
+			calls: []string{
+				"gentx alice 100stake --moniker node --chain-id test --keyring-backend test --home HOME",
+				"collect-gentxs --home HOME",
+				"unsafe-reset-all --home HOME",
+				"start --pruning nothing --grpc.address 0.0.0.0:9090 --home HOME",
+			},
+		},
+		{
+			version:       "v0.47.0-rc1",
+			name:          "Stargate v0.47",
+			broadcastMode: "sync",
+			calls: []string{
+				"genesis gentx alice 100stake --moniker node --chain-id test --keyring-backend test --home HOME",
+				"genesis collect-gentxs --home HOME",
+				"tendermint unsafe-reset-all --home HOME",
+				"start --pruning nothing --grpc.address 0.0.0.0:9090 --home HOME",
+			},
+		},
+		{
+			version:       "v0.47.1",
+			name:          "Stargate v0.47",
+			broadcastMode: "sync",
+			calls: []string{
+				"genesis gentx alice 100stake --moniker node --chain-id test --keyring-backend test --home HOME",
+				"genesis collect-gentxs --home HOME",
+				"tendermint unsafe-reset-all --home HOME",
+				"start --pruning nothing --grpc.address 0.0.0.0:9090 --home HOME",
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.version, func(t *testing.T) {
+			ctx := context.Background()
+
+			version, err := cosmosver.Parse(tt.version)
+			require.NoError(t, err)
+
+			plugin := pickPlugin(App{Name: "mars"}, version)
+			require.Equal(t, tt.name, plugin.Name())
+
+			// configure the home of the node.
+			home := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(home, "config"), 0755))
+			for _, name := range []string{"app.toml", "client.toml", "config.toml"} {
+				require.NoError(t, os.WriteFile(filepath.Join(home, "config", name), nil, 0644))
+			}
+			require.NoError(t, plugin.Configure(home, chainconfig.DefaultConf))
+
+			client, err := toml.LoadFile(filepath.Join(home, "config", "client.toml"))
+			require.NoError(t, err)
+			require.Equal(t, tt.broadcastMode, client.Get("broadcast-mode"))
+
+			// run the commands with a fake binary.
+			binDir := t.TempDir()
+			binary := filepath.Join(binDir, "marsd")
+			require.NoError(t, os.WriteFile(binary, []byte(fakeBinary), 0755))
+
+			runner, err := chaincmdrunner.New(ctx, chaincmd.New(
+				binary,
+				chaincmd.WithVersion(version),
+				chaincmd.WithHome(home),
+				chaincmd.WithChainID("test"),
+				chaincmd.WithKeyringBackend(chaincmd.KeyringBackendTest),
+			))
+			require.NoError(t, err)
+
+			gentxPath, err := plugin.Gentx(ctx, runner, Validator{
+				Name:          "alice",
+				Moniker:       "node",
+				StakingAmount: "100stake",
+			})
+			require.NoError(t, err)
+			require.Equal(t, "/gentx.json", gentxPath)
+			require.NoError(t, runner.CollectGentxs(ctx))
+			require.NoError(t, runner.UnsafeReset(ctx))
+
+			// the plugin always reports the stop of the node as an error.
+			var startErr *CannotStartAppError
+			require.ErrorAs(t, plugin.Start(ctx, runner, chainconfig.DefaultConf), &startErr)
+
+			calls, err := os.ReadFile(filepath.Join(binDir, "calls"))
+			require.NoError(t, err)
+			require.Equal(t, tt.calls, strings.Split(strings.TrimSpace(strings.ReplaceAll(string(calls), home, "HOME")), "\n"))
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package chain
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/tendermint/starport/starport/pkg/gocmd"
+	"github.com/tendermint/starport/starport/pkg/gomodule"
+)
+
+const (
+	releaseManifest = "manifest.json"
+
+	// envSourceDateEpoch is the standard env var to set the timestamp of reproducible builds.
+	// see https://reproducible-builds.org/specs/source-date-epoch.
+	envSourceDateEpoch = "SOURCE_DATE_EPOCH"
+)
+
+// ReleaseOption configures a release build.
+type ReleaseOption func(*releaseOptions)
+
+type releaseOptions struct {
+	signingKeyPath string
+	dockerImage    bool
+}
+
+// SigningKey signs the checksum file of the release with the PEM encoded ed25519
+// private key at path.
+func SigningKey(path string) ReleaseOption {
+	return func(o *releaseOptions) {
+		o.signingKeyPath = path
+	}
+}
+
+// DockerImage builds an OCI image of the node for each linux target of the release.
+func DockerImage() ReleaseOption {
+	return func(o *releaseOptions) {
+		o.dockerImage = true
+	}
+}
+
+// ReleaseManifest describes the artifacts of a release and how they were built.
+type ReleaseManifest struct {
+	Name         string              `json:"name"`
+	Version      string              `json:"version"`
+	Commit       string              `json:"commit"`
+	GoVersion    string              `json:"go_version"`
+	BuildFlags   []string            `json:"build_flags"`
+	Targets      []ReleaseTarget     `json:"targets"`
+	Dependencies []ReleaseDependency `json:"dependencies"`
+
+	// PublicKey is the base64 encoded ed25519 public key to verify the signature
+	// of the checksum file, it's empty when the release is not signed.
+	PublicKey string `json:"public_key,omitempty"`
+}
+
+// ReleaseTarget is a GOOS:GOARCH target of a release.
+type ReleaseTarget struct {
+	Target  string `json:"target"`
+	Archive string `json:"archive"`
+	SHA256  string `json:"sha256"`
+
+	// Image is the OCI image tarball of the target, if any.
+	Image string `json:"image,omitempty"`
+}
+
+// ReleaseDependency is a Go module that the app directly depends on.
+type ReleaseDependency struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// releaseManifest creates the manifest of a release with its targets.
+func (c *Chain) releaseManifest(ctx context.Context, buildFlags []string, targets []ReleaseTarget) (ReleaseManifest, error) {
+	goVersion, err := gocmd.Env(ctx, gocmd.EnvGOVERSION)
+	if err != nil {
+		return ReleaseManifest{}, err
+	}
+
+	modFile, err := gomodule.ParseAt(c.app.Path)
+	if err != nil {
+		return ReleaseManifest{}, err
+	}
+	deps, err := gomodule.ResolveDependencies(modFile)
+	if err != nil {
+		return ReleaseManifest{}, err
+	}
+
+	manifest := ReleaseManifest{
+		Name:       c.app.Name,
+		Version:    c.sourceVersion.tag,
+		Commit:     c.sourceVersion.hash,
+		GoVersion:  goVersion,
+		BuildFlags: buildFlags,
+		Targets:    targets,
+	}
+	for _, dep := range deps {
+		manifest.Dependencies = append(manifest.Dependencies, ReleaseDependency{
+			Path:    dep.Path,
+			Version: dep.Version,
+		})
+	}
+
+	return manifest, nil
+}
+
+// setPublicKey sets the public key that verifies the signature of the release.
+func (m *ReleaseManifest) setPublicKey(key []byte) {
+	m.PublicKey = base64.StdEncoding.EncodeToString(key)
+}
+
+// writeReleaseManifest writes the manifest into the release dir.
+func writeReleaseManifest(releasePath string, manifest ReleaseManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(releasePath, releaseManifest), append(data, '\n'), 0666)
+}
+
+// releaseModTime returns the mod time of the files inside release tarballs. it's read
+// from SOURCE_DATE_EPOCH when set and it's the Unix epoch otherwise.
+func releaseModTime() (time.Time, error) {
+	epoch := os.Getenv(envSourceDateEpoch)
+	if epoch == "" {
+		return time.Unix(0, 0), nil
+	}
+
+	sec, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q, it must be a Unix timestamp", envSourceDateEpoch, epoch)
+	}
+	return time.Unix(sec, 0), nil
+}
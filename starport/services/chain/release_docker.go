@@ -0,0 +1,197 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/tendermint/starport/starport/pkg/cmdrunner"
+	"github.com/tendermint/starport/starport/pkg/cmdrunner/exec"
+	"github.com/tendermint/starport/starport/pkg/cmdrunner/step"
+	"github.com/tendermint/starport/starport/pkg/gocmd"
+	"github.com/tendermint/starport/starport/pkg/ociimage"
+)
+
+const (
+	imageOS         = "linux"
+	imageBinDir     = "/usr/local/bin"
+	imageEntrypoint = "entrypoint"
+	imageUserHome   = "/home/nonroot"
+
+	// imageUID is the id of the non root user that runs the node, same as the distroless images.
+	imageUID = 65532
+)
+
+// imagePorts are the default ports of a node.
+var imagePorts = []string{"26656/tcp", "26657/tcp", "1317/tcp", "9090/tcp"}
+
+// entrypointTemplate is the source of the image's entrypoint. the image has no shell,
+// the entrypoint initializes the node's home on the first start and runs the node.
+var entrypointTemplate = template.Must(template.New("entrypoint").Parse(`package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+)
+
+const (
+	binary  = {{ printf "%q" .Binary }}
+	chainID = {{ printf "%q" .ChainID }}
+)
+
+func main() {
+	home := os.Getenv("NODE_HOME")
+	moniker := os.Getenv("NODE_MONIKER")
+	if moniker == "" {
+		moniker = "node"
+	}
+
+	if _, err := os.Stat(filepath.Join(home, "config", "genesis.json")); os.IsNotExist(err) {
+		cmd := exec.Command(binary, "init", moniker, "--chain-id", chainID, "--home", home)
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	if err := syscall.Exec(binary, append([]string{binary}, os.Args[1:]...), os.Environ()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`))
+
+// buildImage writes the OCI image of a linux target to imagePath. the image contains
+// the binary built in binaryDir and an entrypoint that initializes the node's home.
+func (c *Chain) buildImage(ctx context.Context, imagePath, binaryDir, goarch string, env []string, modTime time.Time) error {
+	binary, err := c.Binary()
+	if err != nil {
+		return err
+	}
+
+	chainID, err := c.ID()
+	if err != nil {
+		return err
+	}
+
+	binaryData, err := os.ReadFile(filepath.Join(binaryDir, binary))
+	if err != nil {
+		return err
+	}
+
+	entrypoint, err := c.buildEntrypoint(ctx, path.Join(imageBinDir, binary), chainID, goarch, env)
+	if err != nil {
+		return err
+	}
+
+	var (
+		home   = path.Join(imageUserHome, filepath.Base(c.plugin.Home()))
+		config = ociimage.Config{
+			User:         fmt.Sprintf("%d:%d", imageUID, imageUID),
+			ExposedPorts: make(map[string]struct{}),
+			Env: []string{
+				"PATH=/usr/local/bin:/usr/bin:/bin",
+				"HOME=" + imageUserHome,
+				"NODE_HOME=" + home,
+			},
+			Entrypoint: []string{path.Join(imageBinDir, imageEntrypoint)},
+			Cmd:        []string{"start", "--home", home, "--rpc.laddr", "tcp://0.0.0.0:26657"},
+			WorkingDir: imageUserHome,
+			Labels: map[string]string{
+				"org.opencontainers.image.title":    c.app.Name,
+				"org.opencontainers.image.version":  c.sourceVersion.tag,
+				"org.opencontainers.image.revision": c.sourceVersion.hash,
+			},
+		}
+	)
+	for _, port := range imagePorts {
+		config.ExposedPorts[port] = struct{}{}
+	}
+
+	// a minimal base with a non root user, the binaries are statically linked.
+	files := []ociimage.File{
+		{Path: "/etc", Dir: true, Mode: 0755},
+		{Path: "/etc/passwd", Mode: 0644, Data: []byte(fmt.Sprintf(
+			"root:x:0:0:root:/root:/sbin/nologin\nnonroot:x:%d:%d:nonroot:%s:/sbin/nologin\n",
+			imageUID, imageUID, imageUserHome,
+		))},
+		{Path: "/etc/group", Mode: 0644, Data: []byte(fmt.Sprintf("root:x:0:\nnonroot:x:%d:\n", imageUID))},
+		{Path: "/home", Dir: true, Mode: 0755},
+		{Path: imageUserHome, Dir: true, Mode: 0700, UID: imageUID, GID: imageUID},
+		{Path: "/tmp", Dir: true, Mode: 01777},
+		{Path: "/usr", Dir: true, Mode: 0755},
+		{Path: "/usr/local", Dir: true, Mode: 0755},
+		{Path: imageBinDir, Dir: true, Mode: 0755},
+		{Path: path.Join(imageBinDir, binary), Mode: 0755, Data: binaryData},
+		{Path: path.Join(imageBinDir, imageEntrypoint), Mode: 0755, Data: entrypoint},
+	}
+
+	ref := c.sourceVersion.tag
+	if ref == "" {
+		ref = "latest"
+	}
+
+	f, err := os.Create(imagePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := ociimage.Write(f, ociimage.Image{
+		Ref:          ref,
+		OS:           imageOS,
+		Architecture: goarch,
+		Created:      modTime,
+		Config:       config,
+		Files:        files,
+	}); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// buildEntrypoint compiles the entrypoint of the image for goarch.
+func (c *Chain) buildEntrypoint(ctx context.Context, binaryPath, chainID, goarch string, env []string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	var source bytes.Buffer
+	if err := entrypointTemplate.Execute(&source, struct {
+		Binary, ChainID string
+	}{binaryPath, chainID}); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), source.Bytes(), 0644); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module entrypoint\n\ngo 1.16\n"), 0644); err != nil {
+		return nil, err
+	}
+
+	out := filepath.Join(dir, "out")
+	buildOptions := []exec.Option{
+		exec.StepOption(step.Env(append(
+			env,
+			cmdrunner.Env(gocmd.EnvCGOEnabled, "0"),
+			cmdrunner.Env(gocmd.EnvGOOS, imageOS),
+			cmdrunner.Env(gocmd.EnvGOARCH, goarch),
+		)...)),
+	}
+	if err := gocmd.BuildPath(ctx, out, imageEntrypoint, dir, []string{gocmd.FlagTrimpath}, buildOptions...); err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(filepath.Join(out, imageEntrypoint))
+}
@@ -0,0 +1,27 @@
+package chain
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseModTime(t *testing.T) {
+	defer os.Unsetenv(envSourceDateEpoch)
+
+	os.Unsetenv(envSourceDateEpoch)
+	modTime, err := releaseModTime()
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(0, 0), modTime)
+
+	os.Setenv(envSourceDateEpoch, "1600000000")
+	modTime, err = releaseModTime()
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(1600000000, 0), modTime)
+
+	os.Setenv(envSourceDateEpoch, "yesterday")
+	_, err = releaseModTime()
+	require.Error(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/otiai10/copy"
 	"github.com/pkg/errors"
@@ -16,6 +17,7 @@ import (
 	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
 	"github.com/tendermint/starport/starport/pkg/cosmosfaucet"
 	"github.com/tendermint/starport/starport/pkg/dirchange"
+	"github.com/tendermint/starport/starport/pkg/events"
 	"github.com/tendermint/starport/starport/pkg/localfs"
 	"github.com/tendermint/starport/starport/pkg/xexec"
 	"github.com/tendermint/starport/starport/pkg/xfilepath"
@@ -50,8 +52,10 @@ var (
 )
 
 type serveOptions struct {
-	forceReset bool
-	resetOnce  bool
+	forceReset    bool
+	resetOnce     bool
+	eventsHost    string
+	dashboardHost string
 }
 
 func newServeOption() serveOptions {
@@ -78,6 +82,21 @@ func ServeResetOnce() ServeOption {
 	}
 }
 
+// ServeEvents allows to stream the lifecycle events of the chain as server-sent events
+// to the clients connected at host.
+func ServeEvents(host string) ServeOption {
+	return func(c *serveOptions) {
+		c.eventsHost = host
+	}
+}
+
+// ServeDashboard allows to serve the dashboard of the chain at host.
+func ServeDashboard(host string) ServeOption {
+	return func(c *serveOptions) {
+		c.dashboardHost = host
+	}
+}
+
 // Serve serves an app.
 func (c *Chain) Serve(ctx context.Context, options ...ServeOption) error {
 	serveOptions := newServeOption()
@@ -101,9 +120,29 @@ func (c *Chain) Serve(ctx context.Context, options ...ServeOption) error {
 		return err
 	}
 
+	c.dashboardHost = serveOptions.dashboardHost
+
 	// start serving components.
 	g, ctx := errgroup.WithContext(ctx)
 
+	// stream the lifecycle events if enabled.
+	if serveOptions.eventsHost != "" {
+		c.serveEv = events.NewBus()
+		defer func() {
+			c.serveEv.Shutdown()
+			c.serveEv = nil
+		}()
+
+		stream := events.NewStream()
+		go stream.Run(c.serveEv)
+
+		g.Go(func() error {
+			return c.runEventsServer(ctx, serveOptions.eventsHost, stream)
+		})
+
+		fmt.Fprintf(c.stdLog().out, "📡 Lifecycle events: %s\n", xurl.HTTP(serveOptions.eventsHost))
+	}
+
 	// blockchain node routine
 	g.Go(func() error {
 		c.refreshServe()
@@ -159,6 +198,13 @@ func (c *Chain) Serve(ctx context.Context, options ...ServeOption) error {
 						fmt.Fprintf(c.stdLog().out, "💿 Genesis state saved in %s\n", genesisPath)
 					}
 				case errors.As(err, &buildErr):
+					c.sendServeEvent(
+						events.StatusDone,
+						EventBuildFailed,
+						"Cannot build the app",
+						events.Attribute("error", buildErr.Err.Error()),
+					)
+
 					fmt.Fprintf(c.stdLog().err, "%s\n", errorColor(err.Error()))
 
 					var validationErr *chainconfig.ValidationError
@@ -172,6 +218,17 @@ func (c *Chain) Serve(ctx context.Context, options ...ServeOption) error {
 					// Parse returned error logs
 					parsedErr := startErr.ParseStartError()
 
+					message := parsedErr
+					if message == "" {
+						message = startErr.Error()
+					}
+					c.sendServeEvent(
+						events.StatusDone,
+						EventStartFailed,
+						"Cannot start the app",
+						events.Attribute("error", message),
+					)
+
 					// If empty, we cannot recognized the error
 					// Therefore, the error may be caused by a new logic that is not compatible with the old app state
 					// We suggest the user to eventually reset the app state
@@ -231,11 +288,17 @@ func (c *Chain) watchAppBackend(ctx context.Context) error {
 		watchPaths = append(watchPaths, c.ConfigPath())
 	}
 
+	// changes are classified to only restart the node when needed.
+	checksums, err := c.sourceChecksums()
+	if err != nil {
+		return err
+	}
+
 	return localfs.Watch(
 		ctx,
 		watchPaths,
 		localfs.WatcherWorkdir(c.app.Path),
-		localfs.WatcherOnChange(c.refreshServe),
+		localfs.WatcherOnChange(func() { c.onSourceChange(ctx, &checksums) }),
 		localfs.WatcherIgnoreHidden(),
 		localfs.WatcherIgnoreExt(ignoredExts...),
 	)
@@ -269,19 +332,36 @@ func (c *Chain) serve(ctx context.Context, forceReset bool) error {
 	if err != nil {
 		return err
 	}
+	if isInit && conf.IsTestnet() {
+		// a testnet is initialized only if all of its nodes are
+		isInit, err = c.isTestnetInitialized(conf)
+		if err != nil {
+			return err
+		}
+	}
 	if isInit {
-		configModified := false
+		// the checksums of the config are computed from the resolved config, switching
+		// to another profile is a config modification too.
+		var configModified, nodeConfigModified bool
 		if c.ConfigPath() != "" {
-			configModified, err = dirchange.HasDirChecksumChanged(c.app.Path, []string{c.ConfigPath()}, saveDir, configChecksum)
+			configModified, nodeConfigModified, err = c.hasConfigChanged(saveDir)
 			if err != nil {
-				return err
+				return &CannotBuildAppError{err}
 			}
 		}
 
-		if forceReset || configModified {
+		switch {
+		case forceReset || configModified:
 			// if forceReset is set, we consider the app as being not initialized
 			fmt.Fprintln(c.stdLog().out, "🔄 Resetting the app state...")
 			isInit = false
+		case nodeConfigModified:
+			// the node configs don't affect the state, only the TOML files are rewritten
+			fmt.Fprintln(c.stdLog().out, "🔧 Node configuration changed, updating the config files...")
+
+			if err := c.reconfigureNodes(conf); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -328,19 +408,44 @@ func (c *Chain) serve(ctx context.Context, forceReset bool) error {
 	// build phase
 	if !isInit || appModified {
 		// build the blockchain app
+		c.sendServeEvent(events.StatusOngoing, EventBuildStarted, "Building the blockchain")
+		buildStart := time.Now()
+
 		if err := c.build(ctx, ""); err != nil {
 			return err
 		}
+
+		buildDuration := time.Since(buildStart)
+		c.sendServeEvent(
+			events.StatusDone,
+			EventBuildFinished,
+			"Blockchain built",
+			events.Attribute("duration_ms", buildDuration.Milliseconds()),
+		)
+	}
+
+	// the database is only reset when the modules or the stores of the app changed
+	storeSchemaChanged := true
+	if isInit && appModified && exportGenesisExists {
+		if storeSchemaChanged, err = c.hasStoreSchemaChanged(ctx, saveDir); err != nil {
+			return err
+		}
 	}
 
 	// init phase
 	// nolint:gocritic
 	if !isInit || (appModified && !exportGenesisExists) {
 		fmt.Fprintln(c.stdLog().out, "💿 Initializing the app...")
+		c.sendServeEvent(events.StatusOngoing, EventInitStarted, "Initializing the app")
 
 		if err := c.Init(ctx, true); err != nil {
 			return err
 		}
+
+		c.sendServeEvent(events.StatusDone, EventInitFinished, "App initialized")
+	} else if appModified && !storeSchemaChanged {
+		// the new build of the app can use the existing database
+		fmt.Fprintln(c.stdLog().out, "▶️  Store schema unchanged, restarting the app with the existing data...")
 	} else if appModified {
 		// if the chain is already initialized but the source has been modified
 		// we reset the chain database and import the genesis state
@@ -353,19 +458,30 @@ func (c *Chain) serve(ctx context.Context, forceReset bool) error {
 		if err := c.importChainState(); err != nil {
 			return err
 		}
+
+		c.sendServeEvent(events.StatusDone, EventGenesisImported, "Genesis state imported")
+
+		if conf.IsTestnet() {
+			if err := c.resetTestnetNodes(ctx); err != nil {
+				return err
+			}
+		}
 	} else {
 		fmt.Fprintln(c.stdLog().out, "▶️  Restarting existing app...")
 	}
 
 	// save checksums
 	if c.ConfigPath() != "" {
-		if err := dirchange.SaveDirChecksum(c.app.Path, []string{c.ConfigPath()}, saveDir, configChecksum); err != nil {
+		if err := c.saveConfigChecksums(saveDir); err != nil {
 			return err
 		}
 	}
 	if err := dirchange.SaveDirChecksum(c.app.Path, appBackendSourceWatchPaths, saveDir, sourceChecksum); err != nil {
 		return err
 	}
+	if err := c.saveStoreSchema(ctx, saveDir); err != nil {
+		return err
+	}
 	binaryPath, err = exec.LookPath(binaryName)
 	if err != nil {
 		return err
@@ -389,6 +505,26 @@ func (c *Chain) start(ctx context.Context, config chainconfig.Config) error {
 	// start the blockchain.
 	g.Go(func() error { return c.plugin.Start(ctx, commands, config) })
 
+	// start the other nodes if the chain is served as a local testnet.
+	var nodes []Node
+	if config.IsTestnet() {
+		if nodes, err = c.testnetNodes(); err != nil {
+			return err
+		}
+
+		for _, n := range nodes[1:] {
+			nodeCommands, err := c.nodeCommands(ctx, n)
+			if err != nil {
+				return err
+			}
+
+			nodeConfig := config
+			nodeConfig.Host = n.Host
+
+			g.Go(func() error { return c.plugin.Start(ctx, nodeCommands, nodeConfig) })
+		}
+	}
+
 	// start the faucet if enabled.
 	faucet, err := c.Faucet(ctx)
 	isFaucetEnabled := err != ErrFaucetIsNotEnabled
@@ -409,6 +545,31 @@ func (c *Chain) start(ctx context.Context, config chainconfig.Config) error {
 		})
 	}
 
+	// start the dashboard if enabled, the chain is served even if the dashboard can't be.
+	isDashboardEnabled := c.dashboardHost != ""
+	if isDashboardEnabled {
+		dashboard, err := c.Dashboard(ctx, config)
+		if err != nil {
+			isDashboardEnabled = false
+			fmt.Fprintf(c.stdLog().err, "%s\n", errorColor("Cannot start the dashboard: "+err.Error()))
+		} else {
+			g.Go(func() error {
+				if err := c.runDashboardServer(ctx, c.dashboardHost, dashboard); err != nil {
+					return &CannotBuildAppError{err}
+				}
+				return nil
+			})
+		}
+	}
+
+	// report when the node is ready.
+	if c.serveEv != nil {
+		g.Go(func() error {
+			c.waitNodeReady(ctx, config)
+			return nil
+		})
+	}
+
 	// set the app as being served
 	c.served = true
 
@@ -416,10 +577,25 @@ func (c *Chain) start(ctx context.Context, config chainconfig.Config) error {
 	fmt.Fprintf(c.stdLog().out, "🌍 Tendermint node: %s\n", xurl.HTTP(config.Host.RPC))
 	fmt.Fprintf(c.stdLog().out, "🌍 Blockchain API: %s\n", xurl.HTTP(config.Host.API))
 
+	for i := 1; i < len(nodes); i++ {
+		n := nodes[i]
+		fmt.Fprintf(
+			c.stdLog().out,
+			"🌍 Validator %q node: %s (API: %s)\n",
+			n.Validator,
+			xurl.HTTP(n.Host.RPC),
+			xurl.HTTP(n.Host.API),
+		)
+	}
+
 	if isFaucetEnabled {
 		fmt.Fprintf(c.stdLog().out, "🌍 Token faucet: %s\n", xurl.HTTP(chainconfig.FaucetHost(config)))
 	}
 
+	if isDashboardEnabled {
+		fmt.Fprintf(c.stdLog().out, "🌍 Dashboard: %s\n", xurl.HTTP(c.dashboardHost))
+	}
+
 	return g.Wait()
 }
 
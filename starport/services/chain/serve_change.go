@@ -0,0 +1,187 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tendermint/starport/starport/pkg/cosmosanalysis/app"
+	"github.com/tendermint/starport/starport/pkg/cosmosanalysis/module"
+	"github.com/tendermint/starport/starport/pkg/dirchange"
+)
+
+// storeSchema is the file containing the store schema of the app on the last serve
+const storeSchema = "store_schema.txt"
+
+// sourceChange is the kind of a change in the source of the app, kinds are ordered
+// from the least to the most impactful.
+type sourceChange int
+
+const (
+	// sourceChangeNone is a change that doesn't affect the node, e.g. in the client templates.
+	sourceChangeNone sourceChange = iota
+
+	// sourceChangeProto is a change of the proto files only, the code is regenerated
+	// without restarting the node.
+	sourceChangeProto
+
+	// sourceChangeCode is a change of the Go code, the app is rebuilt and restarted.
+	sourceChangeCode
+
+	// sourceChangeConfig is a change of the config, the app is restarted with a new state.
+	sourceChangeConfig
+)
+
+// sourceChecksums holds the checksums of the source of the app by kind of change.
+type sourceChecksums map[sourceChange][]byte
+
+// classify returns the most impactful kind of change since the prev checksums.
+func (s sourceChecksums) classify(prev sourceChecksums) sourceChange {
+	for _, change := range []sourceChange{sourceChangeConfig, sourceChangeCode, sourceChangeProto} {
+		if !bytes.Equal(s[change], prev[change]) {
+			return change
+		}
+	}
+	return sourceChangeNone
+}
+
+// sourceChecksums computes the checksums of the config, the Go code and the proto files of the app.
+// the generated code and the client templates are not part of the checksums.
+func (c *Chain) sourceChecksums() (sourceChecksums, error) {
+	paths := map[sourceChange][]string{
+		sourceChangeCode:  appBackendCodePaths,
+		sourceChangeProto: appBackendProtoPaths,
+	}
+	if c.ConfigPath() != "" {
+		paths[sourceChangeConfig] = []string{c.ConfigPath()}
+	}
+
+	checksums := make(sourceChecksums)
+	for change, changePaths := range paths {
+		checksum, err := dirchange.ChecksumFromPaths(
+			c.app.Path,
+			changePaths,
+			dirchange.ExcludePaths(c.clientPaths()...),
+			dirchange.ExcludeExts(ignoredExts...),
+		)
+		if err != nil && !errors.Is(err, dirchange.ErrNoFile) {
+			return nil, err
+		}
+		checksums[change] = checksum
+	}
+
+	return checksums, nil
+}
+
+// clientPaths returns the paths of the client templates and of the generated client code.
+func (c *Chain) clientPaths() []string {
+	paths := []string{
+		filepath.Dir(defaultVuexPath),
+		filepath.Dir(defaultDartPath),
+		defaultOpenAPIPath,
+	}
+
+	// the config can be invalid while the source is being edited.
+	if conf, err := c.Config(); err == nil {
+		for _, path := range []string{conf.Client.Vuex.Path, conf.Client.Dart.Path, conf.Client.OpenAPI.Path} {
+			if path != "" {
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	return paths
+}
+
+// onSourceChange handles a change in the source of the app depending on its kind.
+// checksums are the checksums of the source when the last change was handled, they're updated.
+func (c *Chain) onSourceChange(ctx context.Context, checksums *sourceChecksums) {
+	current, err := c.sourceChecksums()
+	if err != nil {
+		// let serve report the error.
+		c.refreshServe()
+		return
+	}
+
+	change := current.classify(*checksums)
+	*checksums = current
+
+	switch change {
+	case sourceChangeNone:
+	case sourceChangeProto:
+		fmt.Fprintln(c.stdLog().out, "🔧 Proto files changed, regenerating the code...")
+
+		if err := c.generateAll(ctx); err != nil {
+			fmt.Fprintf(c.stdLog().err, "%s\n", errorColor(err.Error()))
+			fmt.Fprintf(c.stdLog().out, "%s\n", infoColor("Waiting for a fix before retrying..."))
+			return
+		}
+
+		// the generated Go code is part of the next rebuild.
+		fmt.Fprintln(c.stdLog().out, "🔧 Code regenerated, the node is rebuilt on the next Go change")
+	default:
+		c.refreshServe()
+	}
+}
+
+// currentStoreSchema returns the store schema of the app made of its modules and store keys.
+// the data of the node can be reused by a new build of the app with the same store schema.
+func (c *Chain) currentStoreSchema(ctx context.Context) (string, error) {
+	conf, err := c.Config()
+	if err != nil {
+		return "", err
+	}
+
+	modules, err := module.Discover(ctx, c.app.Path, conf.Build.Proto.Path)
+	if err != nil {
+		return "", err
+	}
+
+	keys, err := app.StoreKeys(filepath.Join(c.app.Path, "app"))
+	if err != nil {
+		return "", err
+	}
+
+	var schema []string
+	for _, m := range modules {
+		schema = append(schema, fmt.Sprintf("module:%s", m.Name))
+	}
+	for _, key := range keys {
+		schema = append(schema, fmt.Sprintf("store:%s", key))
+	}
+
+	sort.Strings(schema)
+	return strings.Join(schema, "\n"), nil
+}
+
+// hasStoreSchemaChanged checks if the store schema is different from the one of the last serve
+func (c *Chain) hasStoreSchemaChanged(ctx context.Context, saveDir string) (bool, error) {
+	saved, err := os.ReadFile(filepath.Join(saveDir, storeSchema))
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	schema, err := c.currentStoreSchema(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return string(saved) != schema, nil
+}
+
+// saveStoreSchema saves the store schema of the served app
+func (c *Chain) saveStoreSchema(ctx context.Context, saveDir string) error {
+	schema, err := c.currentStoreSchema(ctx)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(saveDir, storeSchema), []byte(schema), 0644)
+}
@@ -0,0 +1,48 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceChecksumsClassify(t *testing.T) {
+	prev := sourceChecksums{
+		sourceChangeConfig: []byte("config"),
+		sourceChangeCode:   []byte("code"),
+		sourceChangeProto:  []byte("proto"),
+	}
+
+	tests := []struct {
+		name    string
+		current sourceChecksums
+		want    sourceChange
+	}{
+		{
+			name:    "no change",
+			current: sourceChecksums{sourceChangeConfig: []byte("config"), sourceChangeCode: []byte("code"), sourceChangeProto: []byte("proto")},
+			want:    sourceChangeNone,
+		},
+		{
+			name:    "proto only",
+			current: sourceChecksums{sourceChangeConfig: []byte("config"), sourceChangeCode: []byte("code"), sourceChangeProto: []byte("new")},
+			want:    sourceChangeProto,
+		},
+		{
+			name:    "proto and code",
+			current: sourceChecksums{sourceChangeConfig: []byte("config"), sourceChangeCode: []byte("new"), sourceChangeProto: []byte("new")},
+			want:    sourceChangeCode,
+		},
+		{
+			name:    "config",
+			current: sourceChecksums{sourceChangeConfig: []byte("new"), sourceChangeCode: []byte("new"), sourceChangeProto: []byte("proto")},
+			want:    sourceChangeConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.current.classify(prev))
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package chain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/dirchange"
+)
+
+// nodeConfigChecksum is the file containing the checksum to detect modification of
+// the node configs defined in config.yml.
+const nodeConfigChecksum = "node_config_checksum.txt"
+
+// legacyConfigProfile is the file containing the name of the config profile used on the last
+// serve by the versions that saved the checksum of the config file.
+const legacyConfigProfile = "config_profile.txt"
+
+// keyringBackendKey is the client config that selects the keyring holding the accounts.
+const keyringBackendKey = "keyring-backend"
+
+// configChecksums computes the checksum of the config that the state of the chain depends on
+// and the checksum of the node configs overwritten by host, init.app, init.config and init.client.
+// a change of the node configs only requires to rewrite the TOML files of the nodes.
+func (c *Chain) configChecksums() (state, node []byte, err error) {
+	conf, err := c.Config()
+	if err != nil {
+		return nil, nil, err
+	}
+	return checksumConfig(conf)
+}
+
+func checksumConfig(conf chainconfig.Config) (state, node []byte, err error) {
+	// the node configs, the faucet and the client code generation don't affect the state,
+	// any other change of the config resets the state.
+	stateConf := conf
+	stateConf.Host = chainconfig.Host{}
+	stateConf.Faucet = chainconfig.Faucet{}
+	stateConf.Client = chainconfig.Client{}
+	stateConf.Init.App = nil
+	stateConf.Init.Config = nil
+	stateConf.Init.Client = nil
+
+	// the accounts are created in the keyring selected by the client config.
+	if backend, ok := conf.Init.Client[keyringBackendKey]; ok {
+		stateConf.Init.Client = map[string]interface{}{keyringBackendKey: backend}
+	}
+
+	if state, err = checksumOf(stateConf); err != nil {
+		return nil, nil, err
+	}
+	node, err = checksumOf(map[string]interface{}{
+		"host":   conf.Host,
+		"app":    conf.Init.App,
+		"config": conf.Init.Config,
+		"client": conf.Init.Client,
+	})
+	return state, node, err
+}
+
+// hasConfigChanged checks if the config changed since the checksums were saved in dir.
+// stateChanged reports a change that requires to reset the state, nodeChanged reports
+// a change of the node configs only.
+func (c *Chain) hasConfigChanged(dir string) (stateChanged, nodeChanged bool, err error) {
+	// the chains served by a previous version only have the checksum of the config file.
+	if _, err := os.Stat(filepath.Join(dir, nodeConfigChecksum)); os.IsNotExist(err) {
+		stateChanged, err = c.hasLegacyConfigChanged(dir)
+		return stateChanged, false, err
+	}
+
+	state, node, err := c.configChecksums()
+	if err != nil {
+		return false, false, err
+	}
+
+	stateChanged, err = hasChecksumChanged(filepath.Join(dir, configChecksum), state)
+	if err != nil {
+		return false, false, err
+	}
+	nodeChanged, err = hasChecksumChanged(filepath.Join(dir, nodeConfigChecksum), node)
+	return stateChanged, nodeChanged, err
+}
+
+// hasLegacyConfigChanged checks if the config file or the config profile changed since
+// their checksum and name were saved in dir by a previous version, the checksums are
+// replaced by the current ones once the chain is served.
+func (c *Chain) hasLegacyConfigChanged(dir string) (bool, error) {
+	changed, err := dirchange.HasDirChecksumChanged(c.app.Path, []string{c.ConfigPath()}, dir, configChecksum)
+	if err != nil || changed {
+		return changed, err
+	}
+
+	profile, err := os.ReadFile(filepath.Join(dir, legacyConfigProfile))
+	if os.IsNotExist(err) {
+		return c.options.ConfigProfile != "", nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return string(profile) != c.options.ConfigProfile, nil
+}
+
+// saveConfigChecksums saves the checksums of the config in dir.
+func (c *Chain) saveConfigChecksums(dir string) error {
+	state, node, err := c.configChecksums()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, configChecksum), state, 0644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, nodeConfigChecksum), node, 0644); err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(dir, legacyConfigProfile)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// reconfigureNodes rewrites the TOML configs of the nodes with the values defined in
+// config.yml, the state of the nodes is kept.
+func (c *Chain) reconfigureNodes(conf chainconfig.Config) error {
+	home, err := c.Home()
+	if err != nil {
+		return err
+	}
+
+	nodes := []Node{{Home: home}}
+	if conf.IsTestnet() {
+		if nodes, err = c.testnetNodes(); err != nil {
+			return err
+		}
+	}
+
+	for i, n := range nodes {
+		nodeConf := conf
+		if i > 0 {
+			nodeConf.Host = n.Host
+		}
+
+		if err := c.plugin.Configure(n.Home, nodeConf); err != nil {
+			return err
+		}
+		if err := overwriteConfigFiles(nodeTOMLConfigs(n.Home, nodeConf)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checksumOf(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	return []byte(hex.EncodeToString(sum[:])), nil
+}
+
+// hasChecksumChanged checks if checksum is different from the one saved at path,
+// it's considered changed when there is no saved checksum.
+func hasChecksumChanged(path string, checksum []byte) (bool, error) {
+	saved, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !bytes.Equal(saved, checksum), nil
+}
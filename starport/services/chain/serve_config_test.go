@@ -0,0 +1,155 @@
+package chain
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/dirchange"
+)
+
+func TestChecksumConfig(t *testing.T) {
+	const base = `
+accounts:
+  - name: alice
+    coins: ["100token"]
+validator:
+  name: alice
+  staked: "100token"
+init:
+  app:
+    minimum-gas-prices: "0stake"
+  config:
+    consensus:
+      timeout_commit: "1s"
+`
+
+	tests := []struct {
+		name         string
+		config       string
+		stateChanged bool
+		nodeChanged  bool
+	}{
+		{
+			name:   "no change",
+			config: base,
+		},
+		{
+			name:        "node config",
+			config:      strings.Replace(base, `timeout_commit: "1s"`, `timeout_commit: "5s"`, 1),
+			nodeChanged: true,
+		},
+		{
+			name:        "client config",
+			config:      base + "  client:\n    broadcast-mode: sync\n",
+			nodeChanged: true,
+		},
+		{
+			name:         "keyring backend",
+			config:       base + "  client:\n    keyring-backend: os\n",
+			stateChanged: true,
+			nodeChanged:  true,
+		},
+		{
+			name:         "accounts",
+			config:       strings.Replace(base, "100token", "200token", 1),
+			stateChanged: true,
+		},
+		{
+			name:         "genesis",
+			config:       base + "genesis:\n  chain_id: mars\n",
+			stateChanged: true,
+		},
+		{
+			name:         "validators",
+			config:       strings.Replace(base, `staked: "100token"`, `staked: "50token"`, 1),
+			stateChanged: true,
+		},
+		{
+			name:   "faucet",
+			config: base + "faucet:\n  name: alice\n  coins: [\"5token\"]\n",
+		},
+		{
+			name:         "build",
+			config:       base + "build:\n  binary: marsd\n",
+			stateChanged: true,
+		},
+		{
+			name:         "build flags",
+			config:       base + "build:\n  ldflags: [\"-s -w\"]\n  tags: [\"ledger\"]\n",
+			stateChanged: true,
+		},
+		{
+			name:         "init home",
+			config:       strings.Replace(base, "init:\n", "init:\n  home: \"$HOME/.mars\"\n", 1),
+			stateChanged: true,
+		},
+		{
+			name:   "client",
+			config: base + "client:\n  vuex:\n    path: vue/src/store\n",
+		},
+		{
+			name:        "host",
+			config:      base + "host:\n  rpc: \":26660\"\n",
+			nodeChanged: true,
+		},
+		{
+			name:        "host api",
+			config:      base + "host:\n  api: \":1318\"\n",
+			nodeChanged: true,
+		},
+	}
+
+	parse := func(config string) chainconfig.Config {
+		conf, err := chainconfig.Parse(strings.NewReader(config))
+		require.NoError(t, err)
+		return conf
+	}
+
+	prevState, prevNode, err := checksumConfig(parse(base))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, node, err := checksumConfig(parse(tt.config))
+			require.NoError(t, err)
+			require.Equal(t, tt.stateChanged, string(state) != string(prevState))
+			require.Equal(t, tt.nodeChanged, string(node) != string(prevNode))
+		})
+	}
+}
+
+func TestHasConfigChangedLegacy(t *testing.T) {
+	appPath := t.TempDir()
+	saveDir := t.TempDir()
+	configPath := filepath.Join(appPath, "config.yml")
+	const config = "accounts:\n  - name: alice\n    coins: [\"100token\"]\nvalidator:\n  name: alice\n  staked: \"100token\"\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0644))
+
+	c := &Chain{app: App{Path: appPath}, options: chainOptions{ConfigFile: configPath}}
+
+	// the config is unchanged since the checksum of the file was saved by a previous version.
+	require.NoError(t, dirchange.SaveDirChecksum(appPath, []string{configPath}, saveDir, configChecksum))
+	stateChanged, nodeChanged, err := c.hasConfigChanged(saveDir)
+	require.NoError(t, err)
+	require.False(t, stateChanged)
+	require.False(t, nodeChanged)
+
+	// the current checksums replace the legacy one.
+	require.NoError(t, c.saveConfigChecksums(saveDir))
+	stateChanged, nodeChanged, err = c.hasConfigChanged(saveDir)
+	require.NoError(t, err)
+	require.False(t, stateChanged)
+	require.False(t, nodeChanged)
+
+	// a modification of the config file since the legacy checksum is a state change.
+	legacyDir := t.TempDir()
+	require.NoError(t, dirchange.SaveDirChecksum(appPath, []string{configPath}, legacyDir, configChecksum))
+	require.NoError(t, os.WriteFile(configPath, []byte(strings.Replace(config, "100token", "200token", 1)), 0644))
+	stateChanged, _, err = c.hasConfigChanged(legacyDir)
+	require.NoError(t, err)
+	require.True(t, stateChanged)
+}
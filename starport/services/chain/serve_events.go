@@ -0,0 +1,72 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/pkg/tendermintrpc"
+	"github.com/tendermint/starport/starport/pkg/xhttp"
+	"github.com/tendermint/starport/starport/pkg/xurl"
+)
+
+// Kinds of the lifecycle events sent while the chain is served.
+const (
+	EventBuildStarted    = "build-started"
+	EventBuildFinished   = "build-finished"
+	EventBuildFailed     = "build-failed"
+	EventInitStarted     = "init-started"
+	EventInitFinished    = "init-finished"
+	EventGenesisImported = "genesis-imported"
+	EventNodeReady       = "node-ready"
+	EventStartFailed     = "start-failed"
+)
+
+// nodeReadyCheckInterval is the interval to check if the node produces blocks.
+const nodeReadyCheckInterval = time.Second
+
+// sendServeEvent sends a lifecycle event of kind when the events of the chain are served.
+func (c *Chain) sendServeEvent(status events.Status, kind, description string, options ...events.Option) {
+	c.serveEv.Send(events.New(status, description, append(options, events.Kind(kind))...))
+}
+
+// runEventsServer streams the lifecycle events to the clients connected at host.
+func (c *Chain) runEventsServer(ctx context.Context, host string, stream *events.Stream) error {
+	return xhttp.Serve(ctx, &http.Server{
+		Addr:    host,
+		Handler: stream,
+	})
+}
+
+// waitNodeReady sends a node ready event once the node produced its first block.
+func (c *Chain) waitNodeReady(ctx context.Context, config chainconfig.Config) {
+	client := tendermintrpc.New(xurl.HTTP(config.Host.RPC))
+
+	ticker := time.NewTicker(nodeReadyCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			// the node is not ready while its RPC server is not started.
+			info, err := client.GetSyncInfo(ctx)
+			if err != nil || info.LatestBlockHeight == 0 {
+				continue
+			}
+
+			c.sendServeEvent(
+				events.StatusDone,
+				EventNodeReady,
+				fmt.Sprintf("Node is ready at height %d", info.LatestBlockHeight),
+				events.Attribute("height", info.LatestBlockHeight),
+			)
+			return
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/events"
+	"github.com/tendermint/starport/starport/pkg/tendermintrpc/tendermintrpctest"
+)
+
+func TestWaitNodeReady(t *testing.T) {
+	// the node produces its first block at the second status request.
+	var requests int32
+	rpc := tendermintrpctest.NewServer()
+	defer rpc.Close()
+
+	rpc.Handle("status", func(json.RawMessage) (interface{}, error) {
+		height := "0"
+		if atomic.AddInt32(&requests, 1) > 1 {
+			height = "5"
+		}
+		return map[string]interface{}{
+			"sync_info": map[string]interface{}{"latest_block_height": height},
+		}, nil
+	})
+
+	conf := chainconfig.DefaultConf
+	conf.Host.RPC = strings.TrimPrefix(rpc.URL, "http://")
+
+	c := &Chain{serveEv: events.NewBus()}
+	go func() {
+		c.waitNodeReady(context.Background(), conf)
+		c.serveEv.Shutdown()
+	}()
+
+	var got []events.Event
+	for e := range c.serveEv {
+		got = append(got, e)
+	}
+	require.Equal(t, []events.Event{
+		events.New(
+			events.StatusDone,
+			"Node is ready at height 5",
+			events.Attribute("height", int64(5)),
+			events.Kind(EventNodeReady),
+		),
+	}, got)
+}
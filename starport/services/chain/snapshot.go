@@ -0,0 +1,326 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/otiai10/copy"
+	"github.com/pkg/errors"
+)
+
+const (
+	// snapshotsDir is the directory in the chain's save path where snapshots are stored.
+	snapshotsDir = "snapshots"
+
+	// snapshotMetadata is the file that holds info about a snapshot.
+	snapshotMetadata = "snapshot.json"
+)
+
+var (
+	// ErrSnapshotNotFound is returned when a snapshot with the given name doesn't exist.
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+
+	// ErrSnapshotExists is returned when a snapshot with the given name already exists.
+	ErrSnapshotExists = errors.New("snapshot already exists")
+
+	// ErrSnapshotConfigChanged is returned when restoring a snapshot saved with a different config.
+	ErrSnapshotConfigChanged = errors.New("config.yml has changed since the snapshot was saved")
+
+	// ErrChainNotInitialized is returned when an operation requires an initialized chain.
+	ErrChainNotInitialized = errors.New("chain is not initialized, serve it at least once")
+
+	snapshotNameRe = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+)
+
+// Snapshot holds info about a named snapshot of the chain state.
+type Snapshot struct {
+	// Name of the snapshot.
+	Name string `json:"name"`
+
+	// CreatedAt is the time the snapshot was saved.
+	CreatedAt time.Time `json:"created_at"`
+
+	// Version is the version of the chain's source when the snapshot was saved.
+	Version string `json:"version,omitempty"`
+}
+
+// SaveSnapshot exports the current state of the chain and saves it with the checksums
+// of the config and source under name. the chain must not be running.
+func (c *Chain) SaveSnapshot(ctx context.Context, name string, overwrite bool) (Snapshot, error) {
+	isInit, err := c.IsInitialized()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if !isInit {
+		return Snapshot{}, ErrChainNotInitialized
+	}
+
+	path, err := c.snapshotPath(name)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		if !overwrite {
+			return Snapshot{}, errors.Wrap(ErrSnapshotExists, name)
+		}
+	} else if !os.IsNotExist(err) {
+		return Snapshot{}, err
+	}
+
+	// the snapshot is saved to a temporary directory first so a failed save doesn't leave
+	// a partial snapshot nor remove the one being overwritten.
+	saveDir, err := c.chainSavePath()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if err := os.MkdirAll(saveDir, 0700); err != nil {
+		return Snapshot{}, err
+	}
+	tmpPath, err := os.MkdirTemp(saveDir, "snapshot-")
+	if err != nil {
+		return Snapshot{}, err
+	}
+	defer os.RemoveAll(tmpPath)
+
+	snapshot := Snapshot{
+		Name:      name,
+		CreatedAt: time.Now(),
+		Version:   c.sourceVersion.tag,
+	}
+
+	if err := c.writeSnapshot(ctx, tmpPath, snapshot); err != nil {
+		return Snapshot{}, err
+	}
+
+	if err := os.RemoveAll(path); err != nil {
+		return Snapshot{}, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return Snapshot{}, err
+	}
+
+	return snapshot, os.Rename(tmpPath, path)
+}
+
+// writeSnapshot exports the current state of the chain to the path directory with the
+// checksums of the config and source and the metadata of snapshot.
+func (c *Chain) writeSnapshot(ctx context.Context, path string, snapshot Snapshot) error {
+	commands, err := c.Commands(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := commands.Export(ctx, filepath.Join(path, exportedGenesis)); err != nil {
+		return errors.Wrap(err, "cannot export the chain state, make sure that the chain is not running")
+	}
+
+	// keep the checksums of the config and source the state was created with.
+	saveDir, err := c.chainSavePath()
+	if err != nil {
+		return err
+	}
+	if c.ConfigPath() != "" {
+		if err := c.saveConfigChecksums(path); err != nil {
+			return err
+		}
+	}
+	if err := copyIfExists(filepath.Join(saveDir, sourceChecksum), filepath.Join(path, sourceChecksum)); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(path, snapshotMetadata), data, 0644)
+}
+
+// Snapshots returns the snapshots saved for the chain sorted by creation time.
+func (c *Chain) Snapshots() ([]Snapshot, error) {
+	saveDir, err := c.chainSavePath()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(saveDir, snapshotsDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []Snapshot
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		snapshot, err := c.Snapshot(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.Before(snapshots[j].CreatedAt)
+	})
+
+	return snapshots, nil
+}
+
+// Snapshot returns the snapshot saved under name.
+func (c *Chain) Snapshot(name string) (Snapshot, error) {
+	path, err := c.snapshotPath(name)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(path, snapshotMetadata))
+	if os.IsNotExist(err) {
+		return Snapshot{}, errors.Wrap(ErrSnapshotNotFound, name)
+	}
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return Snapshot{}, errors.Wrapf(err, "cannot read snapshot %s", name)
+	}
+
+	return snapshot, nil
+}
+
+// RestoreSnapshot resets the chain state to the state saved under name. the state is used
+// as the exported genesis and imported into the node so the next serve starts from it.
+// the chain must not be running. unless force is set, the snapshot is not restored when
+// the config changed since it was saved because serve would reset the state.
+func (c *Chain) RestoreSnapshot(ctx context.Context, name string, force bool) error {
+	if _, err := c.Snapshot(name); err != nil {
+		return err
+	}
+
+	isInit, err := c.IsInitialized()
+	if err != nil {
+		return err
+	}
+	if !isInit {
+		return ErrChainNotInitialized
+	}
+
+	path, err := c.snapshotPath(name)
+	if err != nil {
+		return err
+	}
+
+	saveDir, err := c.chainSavePath()
+	if err != nil {
+		return err
+	}
+
+	if c.ConfigPath() != "" {
+		// the node configs of the snapshot are not part of its state.
+		configModified, _, err := c.hasConfigChanged(path)
+		if err != nil {
+			return err
+		}
+		if configModified && !force {
+			return ErrSnapshotConfigChanged
+		}
+
+		// the state is restored on purpose, the config must not trigger a reset on the next serve.
+		// the node configs are still the ones of the last serve.
+		state, _, err := c.configChecksums()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(saveDir, configChecksum), state, 0644); err != nil {
+			return err
+		}
+	}
+
+	exportedGenesisPath, err := c.exportedGenesisPath()
+	if err != nil {
+		return err
+	}
+	if err := copy.Copy(filepath.Join(path, exportedGenesis), exportedGenesisPath); err != nil {
+		return err
+	}
+
+	// reset the node database and import the snapshot state.
+	commands, err := c.Commands(ctx)
+	if err != nil {
+		return err
+	}
+	if err := commands.UnsafeReset(ctx); err != nil {
+		return err
+	}
+	if err := c.importChainState(); err != nil {
+		return err
+	}
+
+	conf, err := c.Config()
+	if err != nil {
+		return err
+	}
+	if conf.IsTestnet() {
+		return c.resetTestnetNodes(ctx)
+	}
+
+	return nil
+}
+
+// DeleteSnapshot deletes the snapshot saved under name.
+func (c *Chain) DeleteSnapshot(name string) error {
+	if _, err := c.Snapshot(name); err != nil {
+		return err
+	}
+
+	path, err := c.snapshotPath(name)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(path)
+}
+
+// snapshotPath returns the path of the directory of the snapshot saved under name.
+func (c *Chain) snapshotPath(name string) (string, error) {
+	if err := validateSnapshotName(name); err != nil {
+		return "", err
+	}
+
+	saveDir, err := c.chainSavePath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(saveDir, snapshotsDir, name), nil
+}
+
+// validateSnapshotName checks that name can be safely used as a directory name.
+func validateSnapshotName(name string) error {
+	if !snapshotNameRe.MatchString(name) || name == "." || name == ".." {
+		return fmt.Errorf("invalid snapshot name %q, only letters, digits, '.', '_' and '-' are allowed", name)
+	}
+	return nil
+}
+
+// copyIfExists copies src to dst when src exists.
+func copyIfExists(src, dst string) error {
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		return nil
+	}
+	return copy.Copy(src, dst)
+}
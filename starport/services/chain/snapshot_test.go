@@ -0,0 +1,32 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSnapshotName(t *testing.T) {
+	cases := []struct {
+		name  string
+		valid bool
+	}{
+		{"genesis", true},
+		{"before-upgrade_v0.2", true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"../other", false},
+		{"with space", false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSnapshotName(tt.name)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/otiai10/copy"
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+	"github.com/tendermint/starport/starport/chainconfig"
+	"github.com/tendermint/starport/starport/pkg/availableport"
+	chaincmdrunner "github.com/tendermint/starport/starport/pkg/chaincmd/runner"
+)
+
+const (
+	// testnetNodesFile is the file containing the homes and the addresses of the nodes
+	// of a local testnet.
+	testnetNodesFile = "testnet_nodes.json"
+
+	// testnetNodePorts is the number of ports that need to be allocated for each node.
+	testnetNodePorts = 6
+)
+
+// Node holds info about a node of a local testnet.
+type Node struct {
+	// Index is the position of the node in the testnet, 0 is the primary node.
+	Index int `json:"index"`
+
+	// Validator is the name of the account validating with this node.
+	Validator string `json:"validator"`
+
+	// Home is the home directory of the node.
+	Home string `json:"home"`
+
+	// Host keeps the addresses the node listens at.
+	Host chainconfig.Host `json:"host"`
+}
+
+// InitTestnet initializes a local testnet with a node for each validator defined in
+// the config. The primary node uses the chain's home and the addresses from the config,
+// the other nodes get their own home and auto-allocated ports.
+func (c *Chain) InitTestnet(ctx context.Context) error {
+	conf, err := c.Config()
+	if err != nil {
+		return &CannotBuildAppError{err}
+	}
+
+	nodes, err := c.allocateTestnetNodes(conf)
+	if err != nil {
+		return err
+	}
+
+	// init the primary node and create the genesis accounts in it.
+	if err := c.InitChain(ctx); err != nil {
+		return err
+	}
+
+	keys, err := c.initGenesisAccounts(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	primary, err := c.Commands(ctx)
+	if err != nil {
+		return err
+	}
+
+	genesisPath, err := c.GenesisPath()
+	if err != nil {
+		return err
+	}
+
+	gentxsPath, err := c.GentxsPath()
+	if err != nil {
+		return err
+	}
+
+	validators := conf.ListValidators()
+
+	// init the other nodes and issue a gentx from each of them.
+	for _, n := range nodes[1:] {
+		commands, err := c.nodeCommands(ctx, n)
+		if err != nil {
+			return err
+		}
+
+		if err := c.initTestnetNode(ctx, commands, conf, n); err != nil {
+			return err
+		}
+
+		if err := copy.Copy(genesisPath, filepath.Join(n.Home, "config/genesis.json")); err != nil {
+			return err
+		}
+
+		key, ok := keys[n.Validator]
+		if !ok {
+			return &CannotBuildAppError{fmt.Errorf("validator %q must be an account without an address in the config", n.Validator)}
+		}
+		if _, err := commands.AddAccount(ctx, key.Name, key.Mnemonic, key.CoinType); err != nil {
+			return err
+		}
+
+		gentxPath, err := c.plugin.Gentx(ctx, commands, Validator{
+			Name:          n.Validator,
+			Moniker:       nodeMoniker(n),
+			StakingAmount: validators[n.Index].Staked,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := copy.Copy(gentxPath, filepath.Join(gentxsPath, filepath.Base(gentxPath))); err != nil {
+			return err
+		}
+	}
+
+	// issue the gentx of the primary node and collect all the gentxs into the genesis.
+	if _, err := c.IssueGentx(ctx, Validator{
+		Name:          validators[0].Name,
+		StakingAmount: validators[0].Staked,
+	}); err != nil {
+		return err
+	}
+
+	for _, n := range nodes[1:] {
+		if err := copy.Copy(genesisPath, filepath.Join(n.Home, "config/genesis.json")); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(c.stdLog().out, "🌐 Initialized a local testnet with %d validators\n", len(nodes))
+
+	return c.connectTestnetNodes(ctx, primary, nodes)
+}
+
+// initTestnetNode initializes a secondary node of the testnet.
+func (c *Chain) initTestnetNode(ctx context.Context, commands chaincmdrunner.Runner, conf chainconfig.Config, n Node) error {
+	if err := os.RemoveAll(n.Home); err != nil {
+		return err
+	}
+
+	if err := commands.Init(ctx, nodeMoniker(n)); err != nil {
+		return err
+	}
+
+	conf.Host = n.Host
+
+	if err := c.plugin.Configure(n.Home, conf); err != nil {
+		return err
+	}
+
+	return c.overwriteNodeConfigs(n.Home, conf)
+}
+
+// connectTestnetNodes sets every node of the testnet as a persistent peer of the others.
+func (c *Chain) connectTestnetNodes(ctx context.Context, primary chaincmdrunner.Runner, nodes []Node) error {
+	peers := make([]string, len(nodes))
+	for i, n := range nodes {
+		commands := primary
+		if i > 0 {
+			var err error
+			if commands, err = c.nodeCommands(ctx, n); err != nil {
+				return err
+			}
+		}
+
+		nodeID, err := commands.ShowNodeID(ctx)
+		if err != nil {
+			return err
+		}
+
+		peers[i] = fmt.Sprintf("%s@%s", nodeID, localAddress(n.Host.P2P))
+	}
+
+	for i, n := range nodes {
+		var nodePeers []string
+		for j, peer := range peers {
+			if i != j {
+				nodePeers = append(nodePeers, peer)
+			}
+		}
+
+		path := filepath.Join(n.Home, "config/config.toml")
+		config, err := toml.LoadFile(path)
+		if err != nil {
+			return err
+		}
+		config.Set("p2p.persistent_peers", strings.Join(nodePeers, ","))
+		config.Set("p2p.allow_duplicate_ip", true)
+		config.Set("p2p.addr_book_strict", false)
+
+		file, err := os.OpenFile(path, os.O_RDWR|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+		_, err = config.WriteTo(file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resetTestnetNodes resets the databases of the secondary nodes of the testnet and imports
+// the exported genesis into them.
+func (c *Chain) resetTestnetNodes(ctx context.Context) error {
+	nodes, err := c.testnetNodes()
+	if err != nil {
+		return err
+	}
+
+	exportGenesisPath, err := c.exportedGenesisPath()
+	if err != nil {
+		return err
+	}
+
+	for _, n := range nodes[1:] {
+		commands, err := c.nodeCommands(ctx, n)
+		if err != nil {
+			return err
+		}
+
+		if err := commands.UnsafeReset(ctx); err != nil {
+			return err
+		}
+
+		if err := copy.Copy(exportGenesisPath, filepath.Join(n.Home, "config/genesis.json")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// allocateTestnetNodes assigns a home and free ports to each node of the testnet
+// and saves them to be reused on the next serve.
+func (c *Chain) allocateTestnetNodes(conf chainconfig.Config) ([]Node, error) {
+	home, err := c.Home()
+	if err != nil {
+		return nil, err
+	}
+
+	validators := conf.ListValidators()
+
+	ports, err := availableport.Find(testnetNodePorts * (len(validators) - 1))
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []Node{{
+		Index:     0,
+		Validator: validators[0].Name,
+		Home:      home,
+		Host:      conf.Host,
+	}}
+
+	for i, validator := range validators[1:] {
+		p := ports[i*testnetNodePorts:]
+		nodes = append(nodes, Node{
+			Index:     i + 1,
+			Validator: validator.Name,
+			Home:      fmt.Sprintf("%s-node%d", home, i+1),
+			Host: chainconfig.Host{
+				RPC:     fmt.Sprintf("0.0.0.0:%d", p[0]),
+				P2P:     fmt.Sprintf("0.0.0.0:%d", p[1]),
+				Prof:    fmt.Sprintf("0.0.0.0:%d", p[2]),
+				GRPC:    fmt.Sprintf("0.0.0.0:%d", p[3]),
+				GRPCWeb: fmt.Sprintf("0.0.0.0:%d", p[4]),
+				API:     fmt.Sprintf("0.0.0.0:%d", p[5]),
+			},
+		})
+	}
+
+	path, err := c.testnetNodesPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(nodes, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes, os.WriteFile(path, data, 0644)
+}
+
+// testnetNodes returns the nodes of the testnet allocated during initialization.
+func (c *Chain) testnetNodes() ([]Node, error) {
+	path, err := c.testnetNodesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []Node
+	if err := json.Unmarshal(data, &nodes); err != nil {
+		return nil, errors.Wrap(err, "cannot read the testnet nodes")
+	}
+
+	return nodes, nil
+}
+
+// isTestnetInitialized checks if the nodes of the testnet are allocated
+// for the validators defined in the config.
+func (c *Chain) isTestnetInitialized(conf chainconfig.Config) (bool, error) {
+	nodes, err := c.testnetNodes()
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	validators := conf.ListValidators()
+	if len(nodes) != len(validators) {
+		return false, nil
+	}
+
+	for i, n := range nodes {
+		if n.Validator != validators[i].Name {
+			return false, nil
+		}
+		if _, err := os.Stat(filepath.Join(n.Home, "config/gentx")); os.IsNotExist(err) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// testnetNodesPath returns the path of the file that keeps the nodes of the testnet.
+func (c *Chain) testnetNodesPath() (string, error) {
+	savePath, err := c.chainSavePath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(savePath, testnetNodesFile), nil
+}
+
+// nodeCommands returns the runner to execute commands on a node of the testnet.
+func (c *Chain) nodeCommands(ctx context.Context, n Node) (chaincmdrunner.Runner, error) {
+	prefix := c.genPrefix(logAppd)
+	if n.Index > 0 {
+		prefix = c.genNodePrefix(n.Index)
+	}
+
+	return c.commands(ctx, n.Home, n.Host.RPC, prefix)
+}
+
+func nodeMoniker(n Node) string {
+	if n.Index == 0 {
+		return moniker
+	}
+	return fmt.Sprintf("%s%d", moniker, n.Index)
+}
+
+// localAddress replaces the unspecified host in address with the loopback address
+// so it can be dialed by the other nodes.
+func localAddress(address string) string {
+	if strings.HasPrefix(address, ":") {
+		return "127.0.0.1" + address
+	}
+	return strings.Replace(address, "0.0.0.0", "127.0.0.1", 1)
+}
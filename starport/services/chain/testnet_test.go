@@ -0,0 +1,23 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalAddress(t *testing.T) {
+	cases := []struct {
+		address  string
+		expected string
+	}{
+		{"0.0.0.0:26656", "127.0.0.1:26656"},
+		{":26656", "127.0.0.1:26656"},
+		{"192.168.1.2:26656", "192.168.1.2:26656"},
+	}
+	for _, tt := range cases {
+		t.Run(tt.address, func(t *testing.T) {
+			require.Equal(t, tt.expected, localAddress(tt.address))
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/tendermint/starport/starport/pkg/multiformatname"
 	"github.com/tendermint/starport/starport/pkg/protoanalysis"
+	"github.com/tendermint/starport/starport/templates/field"
 	"github.com/tendermint/starport/starport/templates/field/datatype"
 )
 
@@ -210,16 +211,12 @@ func checkForbiddenOracleFieldName(name string) error {
 // checkCustomTypes returns error if one of the types is invalid
 func checkCustomTypes(ctx context.Context, path, module string, fields []string) error {
 	protoPath := filepath.Join(path, protoFolder, module)
-	customFields := make([]string, 0)
-	for _, name := range fields {
-		fieldSplit := strings.Split(name, datatype.Separator)
-		if len(fieldSplit) <= 1 {
-			continue
-		}
-		fieldType := datatype.Name(fieldSplit[1])
-		if _, ok := datatype.SupportedTypes[fieldType]; !ok {
-			customFields = append(customFields, string(fieldType))
-		}
+	parsedFields, err := field.ParseFields(fields, func(string) error { return nil })
+	if err != nil {
+		return err
+	}
+	if err := protoanalysis.HasMessages(ctx, protoPath, parsedFields.CustomTypes()...); err != nil {
+		return err
 	}
-	return protoanalysis.HasMessages(ctx, protoPath, customFields...)
+	return protoanalysis.HasEnums(ctx, protoPath, parsedFields.Enums()...)
 }
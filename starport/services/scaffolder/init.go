@@ -2,6 +2,8 @@ package scaffolder
 
 import (
 	"context"
+	"io/fs"
+	"os"
 	"path/filepath"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/tendermint/starport/starport/pkg/gomodulepath"
 	"github.com/tendermint/starport/starport/pkg/localfs"
 	"github.com/tendermint/starport/starport/pkg/placeholder"
+	"github.com/tendermint/starport/starport/pkg/xgenny"
 	"github.com/tendermint/starport/starport/templates/app"
 	modulecreate "github.com/tendermint/starport/starport/templates/module/create"
 	"github.com/tendermint/vue"
@@ -28,33 +31,43 @@ var (
 )
 
 // Init initializes a new app with name and given options.
-func Init(tracer *placeholder.Tracer, root, name, addressPrefix string, noDefaultModule bool) (path string, err error) {
+// When dryRun is true, the app is not created and the returned source modification
+// documents the content of the files that would be created.
+func Init(
+	tracer *placeholder.Tracer,
+	root,
+	name,
+	addressPrefix string,
+	noDefaultModule,
+	dryRun bool,
+) (path string, sm xgenny.SourceModification, err error) {
 	if root, err = filepath.Abs(root); err != nil {
-		return "", err
+		return "", sm, err
 	}
 
 	pathInfo, err := gomodulepath.Parse(name)
 	if err != nil {
-		return "", err
+		return "", sm, err
 	}
 
 	path = filepath.Join(root, pathInfo.Root)
 
 	// create the project
-	if err := generate(tracer, pathInfo, addressPrefix, path, noDefaultModule); err != nil {
-		return "", err
+	sm, err = generate(tracer, pathInfo, addressPrefix, path, noDefaultModule, dryRun)
+	if err != nil || dryRun {
+		return path, sm, err
 	}
 
 	if err := finish(path, pathInfo.RawPath); err != nil {
-		return "", err
+		return "", sm, err
 	}
 
 	// initialize git repository and perform the first commit
 	if err := initGit(path); err != nil {
-		return "", err
+		return "", sm, err
 	}
 
-	return path, nil
+	return path, sm, nil
 }
 
 //nolint:interfacer
@@ -63,11 +76,12 @@ func generate(
 	pathInfo gomodulepath.Path,
 	addressPrefix,
 	absRoot string,
-	noDefaultModule bool,
-) error {
+	noDefaultModule,
+	dryRun bool,
+) (sm xgenny.SourceModification, err error) {
 	gu, err := giturl.Parse(pathInfo.RawPath)
 	if err != nil {
-		return err
+		return sm, err
 	}
 
 	g, err := app.New(&app.Options{
@@ -81,17 +95,9 @@ func generate(
 		AddressPrefix:    addressPrefix,
 	})
 	if err != nil {
-		return err
-	}
-
-	run := func(runner *genny.Runner, gen *genny.Generator) error {
-		runner.With(gen)
-		runner.Root = absRoot
-		return runner.Run()
-	}
-	if err := run(genny.WetRunner(context.Background()), g); err != nil {
-		return err
+		return sm, err
 	}
+	gens := []*genny.Generator{g}
 
 	// generate module template
 	if !noDefaultModule {
@@ -105,30 +111,79 @@ func generate(
 		}
 		g, err = modulecreate.NewStargate(opts)
 		if err != nil {
-			return err
+			return sm, err
 		}
-		if err := run(genny.WetRunner(context.Background()), g); err != nil {
-			return err
+		gens = append(gens, g, modulecreate.NewStargateAppModify(tracer, opts))
+	}
+
+	sm = xgenny.NewSourceModification()
+	if dryRun {
+		if sm, err = xgenny.DryRun(tracer, gens...); err != nil {
+			return sm, err
 		}
-		g = modulecreate.NewStargateAppModify(tracer, opts)
-		if err := run(genny.WetRunner(context.Background()), g); err != nil {
-			return err
+	} else {
+		for _, g := range gens {
+			runner := genny.WetRunner(context.Background())
+			runner.With(g)
+			runner.Root = absRoot
+			if err := runner.Run(); err != nil {
+				return sm, err
+			}
 		}
-
 	}
 
 	// generate the vue app.
-	return Vue(filepath.Join(absRoot, "vue"))
+	vueSm, err := Vue(filepath.Join(absRoot, "vue"), dryRun)
+	if err != nil {
+		return sm, err
+	}
+	sm.Merge(vueSm)
+	return sm, nil
+}
+
+// Vue scaffolds a Vue.js app for a chain, the app is not saved when dryRun is true.
+func Vue(path string, dryRun bool) (xgenny.SourceModification, error) {
+	return saveBoilerplate(vue.Boilerplate(), path, dryRun)
 }
 
-// Vue scaffolds a Vue.js app for a chain.
-func Vue(path string) error {
-	return localfs.Save(vue.Boilerplate(), path)
+// Flutter scaffolds a Flutter app for a chain, the app is not saved when dryRun is true.
+func Flutter(path string, dryRun bool) (xgenny.SourceModification, error) {
+	return saveBoilerplate(flutter.Boilerplate(), path, dryRun)
 }
 
-// Flutter scaffolds a Flutter app for a chain.
-func Flutter(path string) error {
-	return localfs.Save(flutter.Boilerplate(), path)
+// saveBoilerplate saves the files of a boilerplate to path and returns the source
+// modification, the content of the files is only documented when dryRun is true.
+func saveBoilerplate(boilerplate fs.FS, path string, dryRun bool) (sm xgenny.SourceModification, err error) {
+	if path, err = filepath.Abs(path); err != nil {
+		return sm, err
+	}
+
+	sm = xgenny.NewSourceModification()
+	err = fs.WalkDir(boilerplate, ".", func(wpath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		file := filepath.Join(path, wpath)
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			sm.AppendCreatedFiles(file)
+		} else if err != nil {
+			return err
+		} else {
+			sm.AppendModifiedFiles(file)
+		}
+		if dryRun {
+			content, err := fs.ReadFile(boilerplate, wpath)
+			if err != nil {
+				return err
+			}
+			sm.SetContent(file, string(content))
+		}
+		return nil
+	})
+	if err != nil || dryRun {
+		return sm, err
+	}
+	return sm, localfs.Save(boilerplate, path)
 }
 
 func initGit(path string) error {
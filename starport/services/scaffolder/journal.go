@@ -0,0 +1,54 @@
+package scaffolder
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tendermint/starport/starport/pkg/xgenny"
+)
+
+// journalPath is the path inside the app of the journal of the scaffold commands.
+var journalPath = filepath.Join(".starport", "scaffold.log")
+
+// journalEntry is a line of the journal, it records a scaffold command and the
+// modification of the source it made.
+type journalEntry struct {
+	Time    time.Time `json:"time"`
+	Command []string  `json:"command"`
+	sourceRecord
+}
+
+// appendJournal appends the scaffold command and its source modification to the
+// journal of the app, nothing is recorded when the command isn't known.
+func (s Scaffolder) appendJournal(sm xgenny.SourceModification) error {
+	if len(s.command) == 0 {
+		return nil
+	}
+	record, err := newSourceRecord(s.path, sm)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(journalEntry{
+		Time:         time.Now().UTC(),
+		Command:      s.command,
+		sourceRecord: record,
+	})
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.path, journalPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
@@ -152,12 +152,11 @@ func (s Scaffolder) AddMessage(
 	if err != nil {
 		return sm, err
 	}
-	gens = append(gens, g)
-	sm, err = xgenny.RunWithValidation(tracer, gens...)
+	sm, err = s.runComponentGenerators(tracer, ComponentMessage, moduleName, name, g, gens...)
 	if err != nil {
 		return sm, err
 	}
-	return sm, finish(opts.AppPath, s.modpath.RawPath)
+	return sm, s.complete(sm)
 }
 
 // checkForbiddenMessageField returns true if the name is forbidden as a message name
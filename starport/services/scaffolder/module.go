@@ -211,20 +211,20 @@ func (s Scaffolder) CreateModule(
 		}
 		gens = append(gens, g)
 	}
-	sm, err = xgenny.RunWithValidation(tracer, gens...)
+	sm, err = s.run(tracer, gens...)
 	if err != nil {
 		return sm, err
 	}
 
 	// Modify app.go to register the module
-	newSourceModification, runErr := xgenny.RunWithValidation(tracer, modulecreate.NewStargateAppModify(tracer, opts))
+	newSourceModification, runErr := s.run(tracer, modulecreate.NewStargateAppModify(tracer, opts))
 	sm.Merge(newSourceModification)
 	var validationErr validation.Error
 	if runErr != nil && !errors.As(runErr, &validationErr) {
 		return sm, runErr
 	}
 
-	return sm, finish(opts.AppPath, s.modpath.RawPath)
+	return sm, s.complete(sm)
 }
 
 // ImportModule imports specified module with name to the scaffolded app.
@@ -253,7 +253,7 @@ func (s Scaffolder) ImportModule(tracer *placeholder.Tracer, name string) (sm xg
 		return sm, err
 	}
 
-	sm, err = xgenny.RunWithValidation(tracer, g)
+	sm, err = s.run(tracer, g)
 	if err != nil {
 		var validationErr validation.Error
 		if errors.As(err, &validationErr) {
@@ -265,11 +265,13 @@ func (s Scaffolder) ImportModule(tracer *placeholder.Tracer, name string) (sm xg
 
 	// import a specific version of ComsWasm
 	// NOTE(dshulyak) it must be installed after validation
-	if err := s.installWasm(); err != nil {
-		return sm, err
+	if !s.dryRun {
+		if err := s.installWasm(); err != nil {
+			return sm, err
+		}
 	}
 
-	return sm, finish(s.path, s.modpath.RawPath)
+	return sm, s.complete(sm)
 }
 
 // moduleExists checks if the module exists in the app
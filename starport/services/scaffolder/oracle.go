@@ -47,8 +47,10 @@ func (s *Scaffolder) AddOracle(
 	queryName string,
 	options ...OracleOption,
 ) (sm xgenny.SourceModification, err error) {
-	if err := s.installBandPacket(); err != nil {
-		return sm, err
+	if !s.dryRun {
+		if err := s.installBandPacket(); err != nil {
+			return sm, err
+		}
 	}
 
 	o := newOracleOptions()
@@ -102,11 +104,11 @@ func (s *Scaffolder) AddOracle(
 	if err != nil {
 		return sm, err
 	}
-	sm, err = xgenny.RunWithValidation(tracer, g)
+	sm, err = s.run(tracer, g)
 	if err != nil {
 		return sm, err
 	}
-	return sm, finish(opts.AppPath, s.modpath.RawPath)
+	return sm, s.complete(sm)
 }
 
 func (s Scaffolder) installBandPacket() error {
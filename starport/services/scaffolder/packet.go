@@ -137,11 +137,11 @@ func (s Scaffolder) AddPacket(
 	if err != nil {
 		return sm, err
 	}
-	sm, err = xgenny.RunWithValidation(tracer, g)
+	sm, err = s.runComponentGenerators(tracer, ComponentPacket, moduleName, name, g)
 	if err != nil {
 		return sm, err
 	}
-	return sm, finish(opts.AppPath, s.modpath.RawPath)
+	return sm, s.complete(sm)
 }
 
 // isIBCModule returns true if the provided module implements the IBC module interface
@@ -80,9 +80,9 @@ func (s Scaffolder) AddQuery(
 	if err != nil {
 		return sm, err
 	}
-	sm, err = xgenny.RunWithValidation(tracer, g)
+	sm, err = s.runComponentGenerators(tracer, ComponentQuery, moduleName, name, g)
 	if err != nil {
 		return sm, err
 	}
-	return sm, finish(opts.AppPath, s.modpath.RawPath)
+	return sm, s.complete(sm)
 }
@@ -0,0 +1,176 @@
+package scaffolder
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tendermint/starport/starport/pkg/xgenny"
+)
+
+// sourceRecord records a source modification with the paths relative to the app.
+type sourceRecord struct {
+	Created  []string                 `json:"created,omitempty"`
+	Modified map[string][]xgenny.Hunk `json:"modified,omitempty"`
+	Deleted  []string                 `json:"deleted,omitempty"`
+
+	// Shared contains the hunks of the modified files inserting code that can be
+	// shared with other modifications, like imports.
+	Shared map[string][]xgenny.Hunk `json:"shared,omitempty"`
+}
+
+// newSourceRecord returns the record of a source modification of the app at appPath.
+func newSourceRecord(appPath string, sm xgenny.SourceModification) (record sourceRecord, err error) {
+	rel := func(files []string) ([]string, error) {
+		var paths []string
+		for _, file := range files {
+			path, err := filepath.Rel(appPath, file)
+			if err != nil {
+				return nil, err
+			}
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		return paths, nil
+	}
+
+	if record.Created, err = rel(sm.CreatedFiles()); err != nil {
+		return record, err
+	}
+	if record.Deleted, err = rel(sm.DeletedFiles()); err != nil {
+		return record, err
+	}
+	record.Modified = make(map[string][]xgenny.Hunk)
+	for _, modified := range sm.ModifiedFiles() {
+		path, err := filepath.Rel(appPath, modified)
+		if err != nil {
+			return record, err
+		}
+		record.Modified[path] = sm.Hunks(modified)
+	}
+	return record, nil
+}
+
+// sourceModification returns the source modification recorded for the app at appPath.
+func (r sourceRecord) sourceModification(appPath string) xgenny.SourceModification {
+	sm := xgenny.NewSourceModification()
+	for _, created := range r.Created {
+		sm.AppendCreatedFiles(filepath.Join(appPath, created))
+	}
+	for _, deleted := range r.Deleted {
+		sm.AppendDeletedFiles(filepath.Join(appPath, deleted))
+	}
+	for modified, hunks := range r.Modified {
+		path := filepath.Join(appPath, modified)
+		sm.AppendModifiedFiles(path)
+		sm.AppendHunks(path, hunks...)
+	}
+	return sm
+}
+
+// modifies returns true if the record modifies the file at path.
+func (r sourceRecord) modifies(path string) bool {
+	_, modified := r.Modified[path]
+	_, shared := r.Shared[path]
+	return modified || shared
+}
+
+// separateShared moves to Shared the hunks of code that can be shared: the once
+// replacements and the proto imports of files not created by the record. The hunks
+// replacing lines are only shared when all their code is.
+func (r *sourceRecord) separateShared(onceReplacements []string) {
+	once := make(map[string]bool)
+	for _, replacement := range onceReplacements {
+		for _, line := range strings.Split(replacement, "\n") {
+			once[compactLine(line)] = true
+		}
+	}
+	isShared := func(line string) bool {
+		line = compactLine(line)
+		if once[line] {
+			return true
+		}
+		if !strings.HasPrefix(line, `import"`) || !strings.HasSuffix(line, `";`) {
+			return false
+		}
+		imported := strings.TrimSuffix(strings.TrimPrefix(line, `import"`), `";`)
+		for _, created := range r.Created {
+			if strings.HasSuffix(filepath.ToSlash(created), "/"+imported) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for path, hunks := range r.Modified {
+		var own, shared []xgenny.Hunk
+		for _, hunk := range hunks {
+			if len(hunk.Before) > 0 {
+				if ownHunks, _ := splitInsertion(hunk, isShared); len(ownHunks) == 0 {
+					shared = append(shared, hunk)
+				} else {
+					own = append(own, hunk)
+				}
+				continue
+			}
+			ownHunks, sharedHunks := splitInsertion(hunk, isShared)
+			own = append(own, ownHunks...)
+			shared = append(shared, sharedHunks...)
+		}
+		if len(shared) == 0 {
+			continue
+		}
+		if r.Shared == nil {
+			r.Shared = make(map[string][]xgenny.Hunk)
+		}
+		r.Modified[path] = own
+		r.Shared[path] = shared
+	}
+}
+
+// splitInsertion splits the lines inserted by hunk in consecutive hunks of own and
+// shared lines, the blank lines go with the previous lines.
+func splitInsertion(hunk xgenny.Hunk, isShared func(line string) bool) (own, shared []xgenny.Hunk) {
+	lineShared := make([]bool, len(hunk.After))
+	for i, line := range hunk.After {
+		if strings.TrimSpace(line) != "" {
+			lineShared[i] = isShared(line)
+		} else if i > 0 {
+			lineShared[i] = lineShared[i-1]
+		}
+	}
+	// the leading blank lines go with the first lines
+	for i := range hunk.After {
+		if strings.TrimSpace(hunk.After[i]) != "" {
+			for j := 0; j < i; j++ {
+				lineShared[j] = lineShared[i]
+			}
+			break
+		}
+	}
+
+	start := 0
+	for i := 1; i <= len(hunk.After); i++ {
+		if i < len(hunk.After) && lineShared[i] == lineShared[start] {
+			continue
+		}
+		part := xgenny.Hunk{Line: hunk.Line + start, Before: []string{}, After: hunk.After[start:i]}
+		if lineShared[start] {
+			shared = append(shared, part)
+		} else {
+			own = append(own, part)
+		}
+		start = i
+	}
+	return own, shared
+}
+
+// sortHunks sorts hunks by line, the hunks are reverted from the last one.
+func sortHunks(hunks []xgenny.Hunk) []xgenny.Hunk {
+	sort.SliceStable(hunks, func(i, j int) bool { return hunks[i].Line < hunks[j].Line })
+	return hunks
+}
+
+func compactLine(line string) string {
+	return strings.Join(strings.Fields(line), "")
+}
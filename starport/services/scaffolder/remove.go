@@ -0,0 +1,259 @@
+package scaffolder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gobuffalo/genny"
+	"github.com/tendermint/starport/starport/pkg/multiformatname"
+	"github.com/tendermint/starport/starport/pkg/placeholder"
+	"github.com/tendermint/starport/starport/pkg/xgenny"
+)
+
+// ComponentKind is the kind of a scaffolded component that can be removed.
+type ComponentKind string
+
+const (
+	ComponentList      ComponentKind = "list"
+	ComponentMap       ComponentKind = "map"
+	ComponentSingleton ComponentKind = "single"
+	ComponentMessage   ComponentKind = "message"
+	ComponentQuery     ComponentKind = "query"
+	ComponentPacket    ComponentKind = "packet"
+)
+
+// componentRecordsPath is the path inside the app of the records of the
+// scaffolded components.
+var componentRecordsPath = filepath.Join(".starport", "scaffold")
+
+// runComponentGenerators runs the generators supporting a component and then the
+// generator of the component, the source modification of the component is
+// recorded when kind is not empty so the component can be removed later. The
+// generators are checked together in dry run mode since they depend on each other.
+func (s Scaffolder) runComponentGenerators(
+	tracer *placeholder.Tracer,
+	kind ComponentKind,
+	moduleName string,
+	name multiformatname.Name,
+	g *genny.Generator,
+	supportGens ...*genny.Generator,
+) (sm xgenny.SourceModification, err error) {
+	if s.dryRun {
+		return s.run(tracer, append(supportGens, g)...)
+	}
+	sm, err = s.run(tracer, supportGens...)
+	if err != nil {
+		return sm, err
+	}
+	componentSm, err := s.run(tracer, g)
+	if err != nil {
+		return sm, err
+	}
+	sm.Merge(componentSm)
+	if kind == "" {
+		return sm, nil
+	}
+	return sm, s.saveComponentRecord(tracer, kind, moduleName, name, componentSm)
+}
+
+// RemoveComponent removes a component previously scaffolded in a module, the
+// files created for the component are deleted and the code added to the
+// modified files is removed.
+func (s Scaffolder) RemoveComponent(
+	ctx context.Context,
+	tracer *placeholder.Tracer,
+	kind ComponentKind,
+	moduleName,
+	componentName string,
+) (sm xgenny.SourceModification, err error) {
+	sm, err = s.removeComponent(tracer, kind, moduleName, componentName)
+	if err != nil {
+		return sm, err
+	}
+	return sm, s.complete(sm)
+}
+
+func (s Scaffolder) removeComponent(
+	tracer *placeholder.Tracer,
+	kind ComponentKind,
+	moduleName,
+	componentName string,
+) (sm xgenny.SourceModification, err error) {
+	// If no module is provided, we remove the component from the app's module
+	if moduleName == "" {
+		moduleName = s.modpath.Package
+	}
+	mfName, err := multiformatname.NewName(moduleName, multiformatname.NoNumber)
+	if err != nil {
+		return sm, err
+	}
+	moduleName = mfName.LowerCase
+
+	name, err := multiformatname.NewName(componentName)
+	if err != nil {
+		return sm, err
+	}
+
+	recordPath := s.componentRecordPath(kind, moduleName, name)
+	record, err := readComponentRecord(recordPath)
+	if os.IsNotExist(err) {
+		return sm, fmt.Errorf(
+			"no %s %s has been scaffolded in the module %s, only the components scaffolded by this version of Starport can be removed",
+			kind,
+			name.Original,
+			moduleName,
+		)
+	}
+	if err != nil {
+		return sm, err
+	}
+
+	// the shared code still used by other components is kept
+	shared, err := s.transferSharedHunks(recordPath, record)
+	if err != nil {
+		return sm, err
+	}
+	for path, hunks := range shared {
+		if record.Modified == nil {
+			record.Modified = make(map[string][]xgenny.Hunk)
+		}
+		record.Modified[path] = sortHunks(append(record.Modified[path], hunks...))
+	}
+
+	removed := record.sourceModification(s.path)
+	for _, created := range record.Created {
+		// the code generated from the proto file of the component is stale
+		if filepath.Ext(created) == ".proto" {
+			base := strings.TrimSuffix(filepath.Base(created), ".proto")
+			generated, err := filepath.Glob(filepath.Join(s.path, "x", moduleName, "types", base+".pb*.go"))
+			if err != nil {
+				return sm, err
+			}
+			removed.AppendCreatedFiles(generated...)
+		}
+	}
+
+	sm, err = s.run(tracer, xgenny.NewRevertGenerator(removed))
+	if err != nil || s.dryRun {
+		return sm, err
+	}
+	return sm, os.Remove(recordPath)
+}
+
+// transferSharedHunks transfers the shared hunks of the component recorded at recordPath
+// to another component modifying the same file, so the shared code is removed with the
+// last component relying on it. The shared hunks of the files no other component
+// modifies are returned to be reverted.
+func (s Scaffolder) transferSharedHunks(recordPath string, record sourceRecord) (map[string][]xgenny.Hunk, error) {
+	others, err := s.componentRecords()
+	if err != nil {
+		return nil, err
+	}
+	delete(others, recordPath)
+	var otherPaths []string
+	for path := range others {
+		otherPaths = append(otherPaths, path)
+	}
+	sort.Strings(otherPaths)
+
+	reverted := make(map[string][]xgenny.Hunk)
+	updated := make(map[string]struct{})
+	for file, hunks := range record.Shared {
+		owner := ""
+		for _, path := range otherPaths {
+			if others[path].modifies(file) {
+				owner = path
+				break
+			}
+		}
+		if owner == "" {
+			reverted[file] = hunks
+			continue
+		}
+		other := others[owner]
+		if other.Shared == nil {
+			other.Shared = make(map[string][]xgenny.Hunk)
+		}
+		other.Shared[file] = sortHunks(append(other.Shared[file], hunks...))
+		others[owner] = other
+		updated[owner] = struct{}{}
+	}
+
+	if s.dryRun {
+		return reverted, nil
+	}
+	for path := range updated {
+		if err := writeComponentRecord(path, others[path]); err != nil {
+			return nil, err
+		}
+	}
+	return reverted, nil
+}
+
+// componentRecords returns the records of the scaffolded components by path.
+func (s Scaffolder) componentRecords() (map[string]sourceRecord, error) {
+	records := make(map[string]sourceRecord)
+	err := filepath.Walk(filepath.Join(s.path, componentRecordsPath), func(path string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			return filepath.SkipDir
+		}
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		record, err := readComponentRecord(path)
+		if err != nil {
+			return err
+		}
+		records[path] = record
+		return nil
+	})
+	return records, err
+}
+
+func (s Scaffolder) componentRecordPath(kind ComponentKind, moduleName string, name multiformatname.Name) string {
+	return filepath.Join(s.path, componentRecordsPath, moduleName, fmt.Sprintf("%s-%s.json", kind, name.Snake))
+}
+
+// saveComponentRecord records the source modification of a scaffolded component,
+// the code inserted with the once replacements of tracer is recorded as shared.
+func (s Scaffolder) saveComponentRecord(
+	tracer *placeholder.Tracer,
+	kind ComponentKind,
+	moduleName string,
+	name multiformatname.Name,
+	sm xgenny.SourceModification,
+) error {
+	record, err := newSourceRecord(s.path, sm)
+	if err != nil {
+		return err
+	}
+	record.separateShared(tracer.OnceReplacements())
+	return writeComponentRecord(s.componentRecordPath(kind, moduleName, name), record)
+}
+
+func writeComponentRecord(path string, record sourceRecord) error {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func readComponentRecord(path string) (record sourceRecord, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return record, err
+	}
+	return record, json.Unmarshal(data, &record)
+}
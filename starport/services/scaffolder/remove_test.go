@@ -0,0 +1,122 @@
+package scaffolder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gobuffalo/genny"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/starport/starport/pkg/gomodulepath"
+	"github.com/tendermint/starport/starport/pkg/multiformatname"
+	"github.com/tendermint/starport/starport/pkg/placeholder"
+	"github.com/tendermint/starport/starport/templates/typed"
+)
+
+const testCodec = `package types
+
+import (
+	// this line is used by starport scaffolding # 1
+)
+
+func RegisterCodec(cdc *codec.LegacyAmino) {
+	// this line is used by starport scaffolding # 2
+}
+`
+
+const testGenesisProto = `syntax = "proto3";
+package mars.mars;
+
+// this line is used by starport scaffolding # genesis/proto/import
+
+message GenesisState {
+  // this line is used by starport scaffolding # genesis/proto/state
+}
+`
+
+// testComponentGenerator returns a generator modifying the app like the list
+// templates: the codec import and the gogo.proto import are shared.
+func testComponentGenerator(replacer placeholder.Replacer, appPath string, name multiformatname.Name) *genny.Generator {
+	g := genny.New()
+	g.RunFn(func(r *genny.Runner) error {
+		typesPath := filepath.Join(appPath, "x", "mars", "types", name.Snake+".go")
+		if err := r.File(genny.NewFileS(typesPath, "package types\n")); err != nil {
+			return err
+		}
+		protoPath := filepath.Join(appPath, "proto", "mars", name.Snake+".proto")
+		if err := r.File(genny.NewFileS(protoPath, "syntax = \"proto3\";\n")); err != nil {
+			return err
+		}
+
+		codecPath := filepath.Join(appPath, "x", "mars", "types", "codec.go")
+		f, err := r.Disk.Find(codecPath)
+		if err != nil {
+			return err
+		}
+		content := replacer.ReplaceOnce(f.String(), typed.Placeholder, `sdk "github.com/cosmos/cosmos-sdk/types"`)
+		replacement := fmt.Sprintf("cdc.RegisterConcrete(&Msg%[2]v{}, \"mars/%[2]v\", nil)\n%[1]v", typed.Placeholder2, name.UpperCamel)
+		content = replacer.Replace(content, typed.Placeholder2, replacement)
+		if err := r.File(genny.NewFileS(codecPath, content)); err != nil {
+			return err
+		}
+
+		genesisPath := filepath.Join(appPath, "proto", "mars", "genesis.proto")
+		if f, err = r.Disk.Find(genesisPath); err != nil {
+			return err
+		}
+		replacement = fmt.Sprintf("%[1]v\nimport \"mars/%[2]v.proto\";", typed.PlaceholderGenesisProtoImport, name.Snake)
+		content = replacer.Replace(f.String(), typed.PlaceholderGenesisProtoImport, replacement)
+		replacement = typed.EnsureGogoProtoImported(genesisPath, typed.PlaceholderGenesisProtoImport)
+		content = replacer.Replace(content, typed.PlaceholderGenesisProtoImport, replacement)
+		return r.File(genny.NewFileS(genesisPath, content))
+	})
+	return g
+}
+
+func TestRemoveComponentKeepsSharedCode(t *testing.T) {
+	appPath := t.TempDir()
+	codecPath := filepath.Join(appPath, "x", "mars", "types", "codec.go")
+	genesisPath := filepath.Join(appPath, "proto", "mars", "genesis.proto")
+	require.NoError(t, os.MkdirAll(filepath.Dir(codecPath), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Dir(genesisPath), 0755))
+	require.NoError(t, os.WriteFile(codecPath, []byte(testCodec), 0644))
+	require.NoError(t, os.WriteFile(genesisPath, []byte(testGenesisProto), 0644))
+
+	s := Scaffolder{path: appPath, modpath: gomodulepath.Path{Package: "mars"}}
+	for _, component := range []string{"foo", "bar"} {
+		name, err := multiformatname.NewName(component)
+		require.NoError(t, err)
+		tracer := placeholder.New()
+		_, err = s.runComponentGenerators(tracer, ComponentList, "mars", name, testComponentGenerator(tracer, appPath, name))
+		require.NoError(t, err)
+	}
+
+	// the shared code is still used by bar
+	_, err := s.removeComponent(placeholder.New(), ComponentList, "", "foo")
+	require.NoError(t, err)
+	require.NoFileExists(t, filepath.Join(appPath, "x", "mars", "types", "foo.go"))
+
+	codec, err := os.ReadFile(codecPath)
+	require.NoError(t, err)
+	require.Contains(t, string(codec), `sdk "github.com/cosmos/cosmos-sdk/types"`)
+	require.Contains(t, string(codec), "MsgBar")
+	require.NotContains(t, string(codec), "MsgFoo")
+
+	genesis, err := os.ReadFile(genesisPath)
+	require.NoError(t, err)
+	require.Contains(t, string(genesis), `import "gogoproto/gogo.proto";`)
+	require.Contains(t, string(genesis), `import "mars/bar.proto";`)
+	require.NotContains(t, string(genesis), `import "mars/foo.proto";`)
+
+	// the shared code is removed with the last component
+	_, err = s.removeComponent(placeholder.New(), ComponentList, "", "bar")
+	require.NoError(t, err)
+
+	codec, err = os.ReadFile(codecPath)
+	require.NoError(t, err)
+	require.Equal(t, testCodec, string(codec))
+	genesis, err = os.ReadFile(genesisPath)
+	require.NoError(t, err)
+	require.Equal(t, testGenesisProto, string(genesis))
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/gobuffalo/genny"
 	"github.com/tendermint/starport/starport/chainconfig"
 	sperrors "github.com/tendermint/starport/starport/errors"
 	"github.com/tendermint/starport/starport/pkg/cmdrunner"
@@ -20,6 +21,8 @@ import (
 	"github.com/tendermint/starport/starport/pkg/gocmd"
 	"github.com/tendermint/starport/starport/pkg/gomodule"
 	"github.com/tendermint/starport/starport/pkg/gomodulepath"
+	"github.com/tendermint/starport/starport/pkg/placeholder"
+	"github.com/tendermint/starport/starport/pkg/xgenny"
 )
 
 // Scaffolder is Starport app scaffolder.
@@ -32,10 +35,34 @@ type Scaffolder struct {
 
 	// Version of the chain
 	Version cosmosver.Version
+
+	// dryRun only checks the modifications of the source.
+	dryRun bool
+
+	// command is the scaffold command recorded in the journal of the app.
+	command []string
+}
+
+// Option configures the scaffolder.
+type Option func(*Scaffolder)
+
+// WithDryRun checks the generators with a dry run without modifying the source of the app,
+// the source modifications returned by the scaffolder document the content of the files.
+func WithDryRun() Option {
+	return func(s *Scaffolder) {
+		s.dryRun = true
+	}
+}
+
+// WithCommand records command in the journal of the app after each scaffolding.
+func WithCommand(command []string) Option {
+	return func(s *Scaffolder) {
+		s.command = command
+	}
 }
 
 // App creates a new scaffolder for an existent app.
-func App(path string) (Scaffolder, error) {
+func App(path string, options ...Option) (Scaffolder, error) {
 	path, err := filepath.Abs(path)
 	if err != nil {
 		return Scaffolder{}, err
@@ -67,10 +94,33 @@ func App(path string) (Scaffolder, error) {
 		modpath: modpath,
 		Version: version,
 	}
+	for _, apply := range options {
+		apply(&s)
+	}
 
 	return s, nil
 }
 
+// run runs the generators or only checks them when the scaffolder is in dry run mode.
+func (s Scaffolder) run(tracer *placeholder.Tracer, gens ...*genny.Generator) (xgenny.SourceModification, error) {
+	if s.dryRun {
+		return xgenny.DryRun(tracer, gens...)
+	}
+	return xgenny.RunWithValidation(tracer, gens...)
+}
+
+// complete records the scaffolding of sm in the journal of the app and finishes it,
+// nothing is done in dry run mode.
+func (s Scaffolder) complete(sm xgenny.SourceModification) error {
+	if s.dryRun {
+		return nil
+	}
+	if err := s.appendJournal(sm); err != nil {
+		return err
+	}
+	return finish(s.path, s.modpath.RawPath)
+}
+
 func owner(modulePath string) string {
 	return strings.Split(modulePath, "/")[1]
 }
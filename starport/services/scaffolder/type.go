@@ -3,14 +3,18 @@ package scaffolder
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/gobuffalo/genny"
+	appanalysis "github.com/tendermint/starport/starport/pkg/cosmosanalysis/app"
 	"github.com/tendermint/starport/starport/pkg/multiformatname"
 	"github.com/tendermint/starport/starport/pkg/placeholder"
 	"github.com/tendermint/starport/starport/pkg/xgenny"
 	"github.com/tendermint/starport/starport/templates/field"
 	"github.com/tendermint/starport/starport/templates/field/datatype"
+	"github.com/tendermint/starport/starport/templates/module"
 	modulecreate "github.com/tendermint/starport/starport/templates/module/create"
 	"github.com/tendermint/starport/starport/templates/typed"
 	"github.com/tendermint/starport/starport/templates/typed/dry"
@@ -149,6 +153,13 @@ func (s Scaffolder) AddType(
 		return sm, err
 	}
 
+	// the sample addresses of the tests and the genesis use the prefix of the app.
+	addressPrefix, err := appAddressPrefix(s.path)
+	if err != nil {
+		return sm, err
+	}
+	tFields = tFields.WithAddressPrefix(addressPrefix)
+
 	mfSigner, err := multiformatname.NewName(o.signer)
 	if err != nil {
 		return sm, err
@@ -214,12 +225,16 @@ func (s Scaffolder) AddType(
 	}
 
 	// create the type generator depending on the model
+	var componentKind ComponentKind
 	switch {
 	case o.isList:
+		componentKind = ComponentList
 		g, err = list.NewStargate(tracer, opts)
 	case o.isMap:
+		componentKind = ComponentMap
 		g, err = mapGenerator(tracer, opts, o.indexes)
 	case o.isSingleton:
+		componentKind = ComponentSingleton
 		g, err = singleton.NewStargate(tracer, opts)
 	default:
 		g, err = dry.NewStargate(opts)
@@ -229,13 +244,24 @@ func (s Scaffolder) AddType(
 	}
 
 	// run the generation
-	gens = append(gens, g)
-	sm, err = xgenny.RunWithValidation(tracer, gens...)
+	sm, err = s.runComponentGenerators(tracer, componentKind, moduleName, name, g, gens...)
 	if err != nil {
 		return sm, err
 	}
 
-	return sm, finish(opts.AppPath, s.modpath.RawPath)
+	return sm, s.complete(sm)
+}
+
+// appAddressPrefix returns the account address prefix of the app at appPath
+func appAddressPrefix(appPath string) (string, error) {
+	prefix, err := appanalysis.AddressPrefix(filepath.Join(appPath, module.PathAppModule))
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	if prefix == "" {
+		return datatype.DefaultAddressPrefix, nil
+	}
+	return prefix, nil
 }
 
 // checkForbiddenTypeIndex returns true if the name is forbidden as a field name
@@ -0,0 +1,55 @@
+package datatype
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/tendermint/starport/starport/pkg/multiformatname"
+)
+
+// DefaultAddressPrefix is the account address prefix of the sample address when the
+// prefix of the app is unknown
+const DefaultAddressPrefix = "cosmos"
+
+// sampleAddressBytes are the bytes of the account address used as test and genesis value
+var sampleAddressBytes = []byte("starportsampleaddres")
+
+var (
+	// DataAddress bech32 account address data type definition
+	DataAddress = NewAddress(DefaultAddressPrefix)
+)
+
+// NewAddress returns the bech32 account address data type definition with sample values
+// using the account address prefix of the app
+func NewAddress(prefix string) DataType {
+	sampleAddress := SampleAddress(prefix)
+
+	return DataType{
+		DataType:         func(string) string { return "string" },
+		DefaultTestValue: sampleAddress,
+		ProtoType: func(_, name string, index int) string {
+			return fmt.Sprintf("string %s = %d", name, index)
+		},
+		GenesisArgs: func(name multiformatname.Name, _ int) string {
+			return fmt.Sprintf("%s: \"%s\",\n", name.UpperCamel, sampleAddress)
+		},
+		CLIArgs: func(name multiformatname.Name, _, prefix string, argIndex int) string {
+			return fmt.Sprintf(`%[1]v%[2]v := args[%[3]v]
+					if _, err := sdk.AccAddressFromBech32(%[1]v%[2]v); err != nil {
+						return err
+					}`, prefix, name.UpperCamel, argIndex)
+		},
+		GoCLIImports: []GoImport{{Name: "github.com/cosmos/cosmos-sdk/types", Alias: "sdk"}},
+		NonIndex:     true,
+	}
+}
+
+// SampleAddress returns a valid bech32 account address with the prefix, the address
+// uses DefaultAddressPrefix if prefix is not a valid bech32 prefix
+func SampleAddress(prefix string) string {
+	address, err := bech32.ConvertAndEncode(prefix, sampleAddressBytes)
+	if err != nil {
+		address, _ = bech32.ConvertAndEncode(DefaultAddressPrefix, sampleAddressBytes)
+	}
+	return address
+}
@@ -0,0 +1,217 @@
+package datatype
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tendermint/starport/starport/pkg/multiformatname"
+)
+
+const (
+	// Array represents the array type name of nested fields, used as array.type
+	Array Name = "array"
+	// Map represents the map type name of nested fields, used as map.key.value
+	Map Name = "map"
+	// Optional represents the optional type name of nested fields, used as type?
+	Optional Name = "optional"
+
+	// OptionalSuffix is the suffix of optional field types
+	OptionalSuffix = "?"
+
+	// mapEntrySeparator separates the key and the value of a map entry in CLI arguments
+	mapEntrySeparator = "="
+
+	protoNonNullable = "(gogoproto.nullable) = false"
+	protoNullable    = "(gogoproto.nullable) = true"
+)
+
+var (
+	// MapKeyTypes are the types that can be used as map keys
+	MapKeyTypes = []Name{String, Int, Uint, Bool}
+
+	// wrapperTypes are the proto wrapper types of the optional scalar types
+	wrapperTypes = map[Name]string{
+		String:  "google.protobuf.StringValue",
+		Address: "google.protobuf.StringValue",
+		Bool:    "google.protobuf.BoolValue",
+		Int:     "google.protobuf.Int32Value",
+		Uint:    "google.protobuf.UInt64Value",
+		Bytes:   "google.protobuf.BytesValue",
+	}
+
+	// elemName and keyName are the names of the values parsed for each element of
+	// array and map fields in CLI arguments
+	elemName = multiformatname.Name{UpperCamel: "Value"}
+	keyName  = multiformatname.Name{UpperCamel: "Key"}
+)
+
+// IsRepeated returns true if the data type is a repeated proto field
+func IsRepeated(dt DataType) bool {
+	return strings.HasPrefix(dt.ProtoType("", "", 0), "repeated ")
+}
+
+// CanBeOptional returns true if the data type can be used as an optional field,
+// scalars are wrapped and messages are nullable
+func CanBeOptional(name Name, dt DataType) bool {
+	if IsRepeated(dt) {
+		return false
+	}
+	if _, ok := wrapperTypes[name]; ok || name == Custom {
+		return true
+	}
+	return strings.Contains(dt.ProtoType("", "", 0), protoNonNullable)
+}
+
+// CanBeMapValue returns true if the data type can be used as a map value
+func CanBeMapValue(name Name, dt DataType) bool {
+	switch name {
+	case Decimal, Timestamp, Duration:
+		return false
+	}
+	return !IsRepeated(dt)
+}
+
+// NewArray returns the data type definition of an array of elem
+func NewArray(elem DataType, elemDatatype string) DataType {
+	dataType := func(string) string { return "[]" + elem.DataType(elemDatatype) }
+
+	return DataType{
+		DataType:         dataType,
+		DefaultTestValue: fmt.Sprintf("%[1]v,%[1]v", elem.DefaultTestValue),
+		ProtoType: func(_, name string, index int) string {
+			return "repeated " + elem.ProtoType(elemDatatype, name, index)
+		},
+		GenesisArgs: func(multiformatname.Name, int) string { return "" },
+		CLIArgs: func(name multiformatname.Name, _, prefix string, argIndex int) string {
+			return fmt.Sprintf(`%[1]vCast%[2]v := strings.Split(args[%[3]v], listSeparator)
+					%[1]v%[2]v := make(%[4]v, len(%[1]vCast%[2]v))
+					for i, arg := range %[1]vCast%[2]v {
+						args := []string{arg}
+						%[5]v
+						%[1]v%[2]v[i] = elem%[6]v
+					}`,
+				prefix,
+				name.UpperCamel,
+				argIndex,
+				"[]"+cliDataType(elem, elemDatatype),
+				elem.CLIArgs(elemName, elemDatatype, "elem", 0),
+				elemName.UpperCamel,
+			)
+		},
+		GoTypeImports: elem.GoTypeImports,
+		GoCLIImports:  append([]GoImport{{Name: "strings"}}, elem.GoCLIImports...),
+		ProtoImports:  elem.ProtoImports,
+		NonIndex:      true,
+	}
+}
+
+// NewMap returns the data type definition of a map of key to value
+func NewMap(key, value DataType, valueDatatype string) DataType {
+	dataType := func(string) string {
+		return fmt.Sprintf("map[%s]%s", key.DataType(""), value.DataType(valueDatatype))
+	}
+
+	return DataType{
+		DataType:         dataType,
+		DefaultTestValue: key.DefaultTestValue + mapEntrySeparator + value.DefaultTestValue,
+		ProtoType: func(_, name string, index int) string {
+			keyType, _ := splitProtoType(key, "")
+			valueType, options := splitProtoType(value, valueDatatype)
+			return fmt.Sprintf("map<%s, %s> %s = %d%s", keyType, valueType, name, index, options)
+		},
+		GenesisArgs: func(multiformatname.Name, int) string { return "" },
+		CLIArgs: func(name multiformatname.Name, _, prefix string, argIndex int) string {
+			return fmt.Sprintf(`%[1]v%[2]v := make(%[4]v)
+					for _, entry := range strings.Split(args[%[3]v], listSeparator) {
+						if entry == "" {
+							continue
+						}
+						args := strings.SplitN(entry, "%[5]v", 2)
+						if len(args) != 2 {
+							return fmt.Errorf("invalid map entry %%s, should be key%[5]vvalue", entry)
+						}
+						%[6]v
+						%[7]v
+						%[1]v%[2]v[entry%[8]v] = entry%[9]v
+					}`,
+				prefix,
+				name.UpperCamel,
+				argIndex,
+				fmt.Sprintf("map[%s]%s", key.DataType(""), cliDataType(value, valueDatatype)),
+				mapEntrySeparator,
+				key.CLIArgs(keyName, "", "entry", 0),
+				value.CLIArgs(elemName, valueDatatype, "entry", 1),
+				keyName.UpperCamel,
+				elemName.UpperCamel,
+			)
+		},
+		GoTypeImports: value.GoTypeImports,
+		GoCLIImports: append(
+			append([]GoImport{{Name: "fmt"}, {Name: "strings"}}, key.GoCLIImports...),
+			value.GoCLIImports...,
+		),
+		ProtoImports: value.ProtoImports,
+		NonIndex:     true,
+	}
+}
+
+// NewOptional returns the data type definition of an optional base, the
+// scalar types are wrapped and the messages are nullable
+func NewOptional(name Name, base DataType, baseDatatype string) DataType {
+	dataType := func(string) string { return "*" + base.DataType(baseDatatype) }
+
+	dt := DataType{
+		DataType:         dataType,
+		DefaultTestValue: base.DefaultTestValue,
+		ProtoType: func(_, fieldName string, index int) string {
+			return strings.Replace(base.ProtoType(baseDatatype, fieldName, index), protoNonNullable, protoNullable, 1)
+		},
+		GenesisArgs: func(multiformatname.Name, int) string { return "" },
+		CLIArgs: func(name multiformatname.Name, _, prefix string, argIndex int) string {
+			return fmt.Sprintf(`var %[1]v%[2]v %[4]v
+					if args[%[3]v] != "" {
+						%[5]v
+						%[1]v%[2]v = &optional%[2]v
+					}`,
+				prefix,
+				name.UpperCamel,
+				argIndex,
+				"*"+cliDataType(base, baseDatatype),
+				base.CLIArgs(name, baseDatatype, "optional", argIndex),
+			)
+		},
+		GoTypeImports: base.GoTypeImports,
+		GoCLIImports:  base.GoCLIImports,
+		ProtoImports:  base.ProtoImports,
+		NonIndex:      true,
+	}
+
+	if wrapper, ok := wrapperTypes[name]; ok {
+		dt.ProtoType = func(_, fieldName string, index int) string {
+			return fmt.Sprintf("%s %s = %d [(gogoproto.wktpointer) = true]", wrapper, fieldName, index)
+		}
+		dt.ProtoImports = []string{"gogoproto/gogo.proto", "google/protobuf/wrappers.proto"}
+	}
+
+	return dt
+}
+
+// cliDataType returns the Go type of dt in the CLI package, where the custom
+// types and enums are imported from the types package
+func cliDataType(dt DataType, datatype string) string {
+	if datatype != "" {
+		datatype = "types." + datatype
+	}
+	return dt.DataType(datatype)
+}
+
+// splitProtoType returns the proto type of dt and the options of its fields
+func splitProtoType(dt DataType, datatype string) (protoType, options string) {
+	const name, index = "field", 1
+	def := fmt.Sprintf(" %s = %d", name, index)
+	protoType = dt.ProtoType(datatype, name, index)
+	if i := strings.Index(protoType, def); i >= 0 {
+		return protoType[:i], protoType[i+len(def):]
+	}
+	return protoType, ""
+}
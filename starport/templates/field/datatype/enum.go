@@ -0,0 +1,44 @@
+package datatype
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tendermint/starport/starport/pkg/multiformatname"
+)
+
+var (
+	// DataEnum proto enum data type definition, the enum must be defined
+	// in the proto package of the module
+	DataEnum = DataType{
+		DataType:         func(datatype string) string { return datatype },
+		DefaultTestValue: "0",
+		ProtoType: func(datatype, name string, index int) string {
+			return fmt.Sprintf("%s %s = %d", datatype, name, index)
+		},
+		GenesisArgs: func(multiformatname.Name, int) string { return "" },
+		CLIArgs: func(name multiformatname.Name, datatype, prefix string, argIndex int) string {
+			// the enum value can be provided with its name or its number
+			return fmt.Sprintf(`%[1]v%[2]vValue, ok := types.%[3]v_value[args[%[4]v]]
+					if !ok {
+						%[1]v%[2]vValue, err = cast.ToInt32E(args[%[4]v])
+						if err != nil {
+							return err
+						}
+					}
+					%[1]v%[2]v := types.%[3]v(%[1]v%[2]vValue)`, prefix, name.UpperCamel, datatype, argIndex)
+		},
+		GoCLIImports: []GoImport{{Name: "github.com/spf13/cast"}},
+		NonIndex:     true,
+	}
+)
+
+// EnumName returns the name of the enum referenced by a type name
+// with the enum.Name format
+func EnumName(name Name) (string, bool) {
+	enumName := strings.TrimPrefix(string(name), string(Enum)+".")
+	if enumName == string(name) || enumName == "" {
+		return "", false
+	}
+	return enumName, true
+}
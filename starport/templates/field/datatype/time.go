@@ -0,0 +1,53 @@
+package datatype
+
+import (
+	"fmt"
+
+	"github.com/tendermint/starport/starport/pkg/multiformatname"
+)
+
+var (
+	// DataTimestamp timestamp data type definition
+	DataTimestamp = DataType{
+		DataType:         func(string) string { return "time.Time" },
+		DefaultTestValue: "2021-11-02T10:00:00Z",
+		ProtoType: func(_, name string, index int) string {
+			return fmt.Sprintf("google.protobuf.Timestamp %s = %d [(gogoproto.nullable) = false, (gogoproto.stdtime) = true]",
+				name, index)
+		},
+		GenesisArgs: func(multiformatname.Name, int) string { return "" },
+		CLIArgs: func(name multiformatname.Name, _, prefix string, argIndex int) string {
+			return fmt.Sprintf(`%s%s, err := time.Parse(time.RFC3339, args[%d])
+					if err != nil {
+						return err
+					}`, prefix, name.UpperCamel, argIndex)
+		},
+		GoTypeImports: []GoImport{{Name: "time"}},
+		GoCLIImports:  []GoImport{{Name: "time"}},
+		ProtoImports:  []string{"gogoproto/gogo.proto", "google/protobuf/timestamp.proto"},
+		NonIndex:      true,
+	}
+
+	// DataDuration duration data type definition
+	DataDuration = DataType{
+		DataType:         func(string) string { return "time.Duration" },
+		DefaultTestValue: "10s",
+		ProtoType: func(_, name string, index int) string {
+			return fmt.Sprintf("google.protobuf.Duration %s = %d [(gogoproto.nullable) = false, (gogoproto.stdduration) = true]",
+				name, index)
+		},
+		GenesisArgs: func(name multiformatname.Name, value int) string {
+			return fmt.Sprintf("%s: %d,\n", name.UpperCamel, value)
+		},
+		CLIArgs: func(name multiformatname.Name, _, prefix string, argIndex int) string {
+			return fmt.Sprintf(`%s%s, err := time.ParseDuration(args[%d])
+					if err != nil {
+						return err
+					}`, prefix, name.UpperCamel, argIndex)
+		},
+		GoTypeImports: []GoImport{{Name: "time"}},
+		GoCLIImports:  []GoImport{{Name: "time"}},
+		ProtoImports:  []string{"gogoproto/gogo.proto", "google/protobuf/duration.proto"},
+		NonIndex:      true,
+	}
+)
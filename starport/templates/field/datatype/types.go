@@ -28,6 +28,18 @@ const (
 	Coin Name = "coin"
 	// Coins represents the coin array type name
 	Coins Name = "array.coin"
+	// Bytes represents the bytes type name
+	Bytes Name = "bytes"
+	// Decimal represents the sdk.Dec type name
+	Decimal Name = "decimal"
+	// Address represents the bech32 account address type name
+	Address Name = "address"
+	// Timestamp represents the google.protobuf.Timestamp type name
+	Timestamp Name = "timestamp"
+	// Duration represents the google.protobuf.Duration type name
+	Duration Name = "duration"
+	// Enum represents the proto enum type name, used as enum.Name
+	Enum Name = "enum"
 	// Custom represents the custom type name
 	Custom Name = Name(TypeCustom)
 
@@ -59,6 +71,12 @@ var SupportedTypes = map[Name]DataType{
 	Coin:             DataCoin,
 	Coins:            DataCoinSlice,
 	CoinSliceAlias:   DataCoinSlice,
+	Bytes:            DataBytes,
+	Decimal:          DataDecimal,
+	Address:          DataAddress,
+	Timestamp:        DataTimestamp,
+	Duration:         DataDuration,
+	Enum:             DataEnum,
 	Custom:           DataCustom,
 }
 
@@ -71,6 +89,7 @@ type DataType struct {
 	ProtoType         func(datatype, name string, index int) string
 	GenesisArgs       func(name multiformatname.Name, value int) string
 	ProtoImports      []string
+	GoTypeImports     []GoImport
 	GoCLIImports      []GoImport
 	DefaultTestValue  string
 	ValueLoop         string
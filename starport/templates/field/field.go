@@ -3,6 +3,7 @@ package field
 
 import (
 	"fmt"
+	"html/template"
 
 	"github.com/tendermint/starport/starport/pkg/multiformatname"
 	"github.com/tendermint/starport/starport/templates/field/datatype"
@@ -14,41 +15,89 @@ type Field struct {
 	Name         multiformatname.Name
 	DatatypeName datatype.Name
 	Datatype     string
+
+	// Key is the key type of a map field
+	Key *Field
+	// Elem is the element type of an array field, the value type of a map field
+	// or the type of an optional field
+	Elem *Field
+
+	// addressPrefix is the account address prefix of the sample addresses
+	addressPrefix string
 }
 
-// DataType returns the field Datatype
-func (f Field) DataType() string {
+// WithAddressPrefix returns the field with sample addresses using the account address prefix
+func (f Field) WithAddressPrefix(prefix string) Field {
+	f.addressPrefix = prefix
+	if f.Key != nil {
+		key := f.Key.WithAddressPrefix(prefix)
+		f.Key = &key
+	}
+	if f.Elem != nil {
+		elem := f.Elem.WithAddressPrefix(prefix)
+		f.Elem = &elem
+	}
+	return f
+}
+
+// dataType returns the data type definition of the field
+func (f Field) dataType() datatype.DataType {
+	switch f.DatatypeName {
+	case datatype.Array:
+		return datatype.NewArray(f.Elem.dataType(), f.Elem.Datatype)
+	case datatype.Map:
+		return datatype.NewMap(f.Key.dataType(), f.Elem.dataType(), f.Elem.Datatype)
+	case datatype.Optional:
+		return datatype.NewOptional(f.Elem.DatatypeName, f.Elem.dataType(), f.Elem.Datatype)
+	case datatype.Address:
+		if f.addressPrefix != "" {
+			return datatype.NewAddress(f.addressPrefix)
+		}
+	}
+
 	dt, ok := datatype.SupportedTypes[f.DatatypeName]
 	if !ok {
 		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
 	}
-	return dt.DataType(f.Datatype)
+	return dt
 }
 
-// ProtoType returns the field proto Datatype
-func (f Field) ProtoType(index int) string {
-	dt, ok := datatype.SupportedTypes[f.DatatypeName]
-	if !ok {
-		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
+// isNested returns true if the field is an array, a map or an optional
+func (f Field) isNested() bool {
+	return f.Key != nil || f.Elem != nil
+}
+
+// types returns the type of the field and its nested types
+func (f Field) types() []Field {
+	types := []Field{f}
+	if f.Key != nil {
+		types = append(types, f.Key.types()...)
+	}
+	if f.Elem != nil {
+		types = append(types, f.Elem.types()...)
 	}
-	return dt.ProtoType(f.Datatype, f.Name.Snake, index)
+	return types
+}
+
+// DataType returns the field Datatype
+func (f Field) DataType() string {
+	return f.dataType().DataType(f.Datatype)
+}
+
+// ProtoType returns the field proto Datatype, the definition is returned as HTML
+// to not be escaped by templates
+func (f Field) ProtoType(index int) template.HTML {
+	return template.HTML(f.dataType().ProtoType(f.Datatype, f.Name.Snake, index))
 }
 
 // DefaultTestValue returns the Datatype value default
 func (f Field) DefaultTestValue() string {
-	dt, ok := datatype.SupportedTypes[f.DatatypeName]
-	if !ok {
-		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
-	}
-	return dt.DefaultTestValue
+	return f.dataType().DefaultTestValue
 }
 
 // ValueLoop returns the Datatype value for loop iteration
 func (f Field) ValueLoop() string {
-	dt, ok := datatype.SupportedTypes[f.DatatypeName]
-	if !ok {
-		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
-	}
+	dt := f.dataType()
 	if dt.NonIndex {
 		panic(fmt.Sprintf("non index type %s", f.DatatypeName))
 	}
@@ -57,10 +106,7 @@ func (f Field) ValueLoop() string {
 
 // ValueIndex returns the Datatype value for indexes
 func (f Field) ValueIndex() string {
-	dt, ok := datatype.SupportedTypes[f.DatatypeName]
-	if !ok {
-		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
-	}
+	dt := f.dataType()
 	if dt.NonIndex {
 		panic(fmt.Sprintf("non index type %s", f.DatatypeName))
 	}
@@ -69,10 +115,7 @@ func (f Field) ValueIndex() string {
 
 // ValueInvalidIndex returns the Datatype value for invalid indexes
 func (f Field) ValueInvalidIndex() string {
-	dt, ok := datatype.SupportedTypes[f.DatatypeName]
-	if !ok {
-		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
-	}
+	dt := f.dataType()
 	if dt.NonIndex {
 		panic(fmt.Sprintf("non index type %s", f.DatatypeName))
 	}
@@ -81,28 +124,18 @@ func (f Field) ValueInvalidIndex() string {
 
 // GenesisArgs returns the Datatype genesis args
 func (f Field) GenesisArgs(value int) string {
-	dt, ok := datatype.SupportedTypes[f.DatatypeName]
-	if !ok {
-		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
-	}
-	return dt.GenesisArgs(f.Name, value)
+	return f.dataType().GenesisArgs(f.Name, value)
 }
 
-// CLIArgs returns the Datatype CLI args
-func (f Field) CLIArgs(prefix string, argIndex int) string {
-	dt, ok := datatype.SupportedTypes[f.DatatypeName]
-	if !ok {
-		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
-	}
-	return dt.CLIArgs(f.Name, f.Datatype, prefix, argIndex)
+// CLIArgs returns the Datatype CLI args, the code is returned as HTML
+// to not be escaped by templates
+func (f Field) CLIArgs(prefix string, argIndex int) template.HTML {
+	return template.HTML(f.dataType().CLIArgs(f.Name, f.Datatype, prefix, argIndex))
 }
 
 // ToBytes returns the Datatype byte array cast
 func (f Field) ToBytes(name string) string {
-	dt, ok := datatype.SupportedTypes[f.DatatypeName]
-	if !ok {
-		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
-	}
+	dt := f.dataType()
 	if dt.NonIndex {
 		panic(fmt.Sprintf("non index type %s", f.DatatypeName))
 	}
@@ -111,30 +144,24 @@ func (f Field) ToBytes(name string) string {
 
 // ToString returns the Datatype byte array cast
 func (f Field) ToString(name string) string {
-	dt, ok := datatype.SupportedTypes[f.DatatypeName]
-	if !ok {
-		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
-	}
+	dt := f.dataType()
 	if dt.NonIndex {
 		panic(fmt.Sprintf("non index type %s", f.DatatypeName))
 	}
 	return dt.ToString(name)
 }
 
+// GoTypeImports returns the Datatype imports required by its Go type
+func (f Field) GoTypeImports() []datatype.GoImport {
+	return f.dataType().GoTypeImports
+}
+
 // GoCLIImports returns the Datatype imports for CLI package
 func (f Field) GoCLIImports() []datatype.GoImport {
-	dt, ok := datatype.SupportedTypes[f.DatatypeName]
-	if !ok {
-		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
-	}
-	return dt.GoCLIImports
+	return f.dataType().GoCLIImports
 }
 
 // ProtoImports return the Datatype imports for proto files
 func (f Field) ProtoImports() []string {
-	dt, ok := datatype.SupportedTypes[f.DatatypeName]
-	if !ok {
-		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
-	}
-	return dt.ProtoImports
+	return f.dataType().ProtoImports
 }
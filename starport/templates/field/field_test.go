@@ -0,0 +1,21 @@
+package field
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAddressPrefix(t *testing.T) {
+	fields, err := ParseFields([]string{"owner:address", "members:array.address", "name"}, noCheck)
+	require.NoError(t, err)
+
+	// the sample addresses use the cosmos prefix by default.
+	require.Equal(t, "cosmos1wd6xzunsdae8gumpd4cxcetpv3j8yetnqnjz9a", fields[0].DefaultTestValue())
+
+	fields = fields.WithAddressPrefix("mars")
+	require.Equal(t, "mars1wd6xzunsdae8gumpd4cxcetpv3j8yetnawtmsx", fields[0].DefaultTestValue())
+	require.Equal(t, "Owner: \"mars1wd6xzunsdae8gumpd4cxcetpv3j8yetnawtmsx\",\n", fields[0].GenesisArgs(0))
+	require.Equal(t, "mars1wd6xzunsdae8gumpd4cxcetpv3j8yetnawtmsx,mars1wd6xzunsdae8gumpd4cxcetpv3j8yetnawtmsx", fields[1].DefaultTestValue())
+	require.Equal(t, "xyz", fields[2].DefaultTestValue())
+}
@@ -10,6 +10,31 @@ import (
 // Fields represents a Field slice
 type Fields []Field
 
+// WithAddressPrefix returns the fields with sample addresses using the account address prefix
+func (f Fields) WithAddressPrefix(prefix string) Fields {
+	fields := make(Fields, len(f))
+	for i, field := range f {
+		fields[i] = field.WithAddressPrefix(prefix)
+	}
+	return fields
+}
+
+// GoTypeImports return all go imports required by the field types
+func (f Fields) GoTypeImports() []datatype.GoImport {
+	allImports := make([]datatype.GoImport, 0)
+	exist := make(map[string]struct{})
+	for _, fields := range f {
+		for _, goImport := range fields.GoTypeImports() {
+			if _, ok := exist[goImport.Name]; ok {
+				continue
+			}
+			exist[goImport.Name] = struct{}{}
+			allImports = append(allImports, goImport)
+		}
+	}
+	return allImports
+}
+
 // GoCLIImports return all go CLI imports
 func (f Fields) GoCLIImports() []datatype.GoImport {
 	allImports := make([]datatype.GoImport, 0)
@@ -51,17 +76,44 @@ func (f Fields) String() string {
 	return args
 }
 
-// Custom return a list of custom fields
+// Custom return a list of custom and enum fields
 func (f Fields) Custom() []string {
 	fields := make([]string, 0)
+	exist := make(map[string]struct{})
+	for _, name := range append(f.CustomTypes(), f.Enums()...) {
+		dataType, err := multiformatname.NewName(name)
+		if err != nil {
+			panic(err)
+		}
+		if _, ok := exist[dataType.Snake]; ok {
+			continue
+		}
+		exist[dataType.Snake] = struct{}{}
+		fields = append(fields, dataType.Snake)
+	}
+	return fields
+}
+
+// CustomTypes return the names of the custom types used by the fields
+func (f Fields) CustomTypes() []string {
+	return f.typeNames(datatype.TypeCustom)
+}
+
+// Enums return the names of the enums used by the fields
+func (f Fields) Enums() []string {
+	return f.typeNames(datatype.Enum)
+}
+
+// typeNames return the names of the types of the fields and their nested types
+// with the name datatypeName
+func (f Fields) typeNames(datatypeName datatype.Name) []string {
+	names := make([]string, 0)
 	for _, field := range f {
-		if field.DatatypeName == datatype.TypeCustom {
-			dataType, err := multiformatname.NewName(field.Datatype)
-			if err != nil {
-				panic(err)
+		for _, t := range field.types() {
+			if t.DatatypeName == datatypeName {
+				names = append(names, t.Datatype)
 			}
-			fields = append(fields, dataType.Snake)
 		}
 	}
-	return fields
+	return names
 }
@@ -1,6 +1,7 @@
 package field
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -63,20 +64,89 @@ func ParseFields(
 		}
 		existingFields[name.LowerCamel] = struct{}{}
 
-		// Check if is a static type
-		if _, ok := datatype.SupportedTypes[datatypeName]; ok {
-			parsedFields = append(parsedFields, Field{
-				Name:         name,
-				DatatypeName: datatypeName,
-			})
-			continue
+		parsedField, err := parseType(datatypeName)
+		if err != nil {
+			return parsedFields, fmt.Errorf("invalid type of the field %s: %s", name.Original, err.Error())
 		}
-
-		parsedFields = append(parsedFields, Field{
-			Name:         name,
-			Datatype:     string(datatypeName),
-			DatatypeName: datatype.TypeCustom,
-		})
+		parsedField.Name = name
+		parsedFields = append(parsedFields, parsedField)
 	}
 	return parsedFields, nil
 }
+
+// parseType parses a field type, the types can be nested as array.type and map.key.value
+// and suffixed with ? to be optional
+func parseType(typ datatype.Name) (Field, error) {
+	if base := strings.TrimSuffix(string(typ), datatype.OptionalSuffix); base != string(typ) {
+		elem, err := parseType(datatype.Name(base))
+		if err != nil {
+			return Field{}, err
+		}
+		if elem.isNested() || !datatype.CanBeOptional(elem.DatatypeName, elem.dataType()) {
+			return Field{}, fmt.Errorf("%s can't be optional", base)
+		}
+		if elem.DatatypeName == datatype.TypeCustom {
+			// custom types are already nullable
+			return elem, nil
+		}
+		return Field{DatatypeName: datatype.Optional, Elem: &elem}, nil
+	}
+
+	// Check if is an enum type
+	if enumName, ok := datatype.EnumName(typ); ok {
+		return Field{DatatypeName: datatype.Enum, Datatype: enumName}, nil
+	}
+	if typ == datatype.Enum {
+		return Field{}, errors.New("the enum name is missing, should be 'enum.EnumName'")
+	}
+
+	// Check if is a static type
+	if _, ok := datatype.SupportedTypes[typ]; ok {
+		return Field{DatatypeName: typ}, nil
+	}
+
+	// Check if is an array
+	if elemType := strings.TrimPrefix(string(typ), string(datatype.Array)+"."); elemType != string(typ) {
+		elem, err := parseType(datatype.Name(elemType))
+		if err != nil {
+			return Field{}, err
+		}
+		if elem.isNested() || datatype.IsRepeated(elem.dataType()) {
+			return Field{}, fmt.Errorf("%s can't be an array element", elemType)
+		}
+		return Field{DatatypeName: datatype.Array, Elem: &elem}, nil
+	}
+
+	// Check if is a map
+	if entryType := strings.TrimPrefix(string(typ), string(datatype.Map)+"."); entryType != string(typ) {
+		entrySplit := strings.SplitN(entryType, ".", 2)
+		if len(entrySplit) != 2 {
+			return Field{}, fmt.Errorf("invalid map type %s, should be 'map.key.value'", typ)
+		}
+
+		key := Field{DatatypeName: datatype.Name(entrySplit[0])}
+		if !isMapKey(key.DatatypeName) {
+			return Field{}, fmt.Errorf("%s can't be a map key, should be one of %v", key.DatatypeName, datatype.MapKeyTypes)
+		}
+		elem, err := parseType(datatype.Name(entrySplit[1]))
+		if err != nil {
+			return Field{}, err
+		}
+		if elem.isNested() || !datatype.CanBeMapValue(elem.DatatypeName, elem.dataType()) {
+			return Field{}, fmt.Errorf("%s can't be a map value", entrySplit[1])
+		}
+		return Field{DatatypeName: datatype.Map, Key: &key, Elem: &elem}, nil
+	}
+
+	return Field{DatatypeName: datatype.TypeCustom, Datatype: string(typ)}, nil
+}
+
+// isMapKey returns true if the type can be used as a map key
+func isMapKey(typ datatype.Name) bool {
+	for _, keyType := range datatype.MapKeyTypes {
+		if typ == keyType {
+			return true
+		}
+	}
+	return false
+}
@@ -34,6 +34,25 @@ func TestForbiddenParseFields(t *testing.T) {
 	// invalid format
 	_, err = ParseFields([]string{"foo:int:int"}, alwaysInvalid)
 	require.Error(t, err)
+
+	// enum without name
+	_, err = ParseFields([]string{"foo:enum"}, noCheck)
+	require.Error(t, err)
+
+	// invalid nested types
+	for _, field := range []string{
+		"foo:array.array.string",
+		"foo:array.strings",
+		"foo:map.string",
+		"foo:map.coin.string",
+		"foo:map.string.coins",
+		"foo:map.string.decimal",
+		"foo:array.string?",
+		"foo:enum.Status?",
+	} {
+		_, err = ParseFields([]string{field}, noCheck)
+		require.Error(t, err, field)
+	}
 }
 
 func TestParseFields1(t *testing.T) {
@@ -181,6 +200,84 @@ func TestParseFields1(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "test scalar types",
+			fields: []string{
+				name1.Original + ":bytes",
+				name2.Original + ":decimal",
+				name3.Original + ":address",
+				name4.Original + ":timestamp",
+			},
+			want: Fields{
+				{
+					Name:         name1,
+					DatatypeName: datatype.Bytes,
+				},
+				{
+					Name:         name2,
+					DatatypeName: datatype.Decimal,
+				},
+				{
+					Name:         name3,
+					DatatypeName: datatype.Address,
+				},
+				{
+					Name:         name4,
+					DatatypeName: datatype.Timestamp,
+				},
+			},
+		},
+		{
+			name: "test enum types",
+			fields: []string{
+				name1.Original + ":enum.Status",
+				name2.Original + ":duration",
+			},
+			want: Fields{
+				{
+					Name:         name1,
+					DatatypeName: datatype.Enum,
+					Datatype:     "Status",
+				},
+				{
+					Name:         name2,
+					DatatypeName: datatype.Duration,
+				},
+			},
+		},
+		{
+			name: "test nested types",
+			fields: []string{
+				name1.Original + ":array.address",
+				name2.Original + ":map.string.uint",
+				name3.Original + ":string?",
+				name4.Original + ":map.uint.Bla",
+			},
+			want: Fields{
+				{
+					Name:         name1,
+					DatatypeName: datatype.Array,
+					Elem:         &Field{DatatypeName: datatype.Address},
+				},
+				{
+					Name:         name2,
+					DatatypeName: datatype.Map,
+					Key:          &Field{DatatypeName: datatype.String},
+					Elem:         &Field{DatatypeName: datatype.Uint},
+				},
+				{
+					Name:         name3,
+					DatatypeName: datatype.Optional,
+					Elem:         &Field{DatatypeName: datatype.String},
+				},
+				{
+					Name:         name4,
+					DatatypeName: datatype.Map,
+					Key:          &Field{DatatypeName: datatype.Uint},
+					Elem:         &Field{DatatypeName: datatype.Custom, Datatype: "Bla"},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -194,3 +291,55 @@ func TestParseFields1(t *testing.T) {
 		})
 	}
 }
+
+func TestNestedTypes(t *testing.T) {
+	tests := []struct {
+		field     string
+		dataType  string
+		protoType string
+	}{
+		{
+			field:     "foo:array.address",
+			dataType:  "[]string",
+			protoType: "repeated string foo = 1",
+		},
+		{
+			field:     "foo:array.Bla",
+			dataType:  "[]*Bla",
+			protoType: "repeated Bla foo = 1",
+		},
+		{
+			field:     "foo:map.string.uint",
+			dataType:  "map[string]uint64",
+			protoType: "map<string, uint64> foo = 1",
+		},
+		{
+			field:     "foo:map.bool.coin",
+			dataType:  "map[bool]sdk.Coin",
+			protoType: "map<bool, cosmos.base.v1beta1.Coin> foo = 1 [(gogoproto.nullable) = false]",
+		},
+		{
+			field:     "foo:string?",
+			dataType:  "*string",
+			protoType: "google.protobuf.StringValue foo = 1 [(gogoproto.wktpointer) = true]",
+		},
+		{
+			field:     "foo:coin?",
+			dataType:  "*sdk.Coin",
+			protoType: "cosmos.base.v1beta1.Coin foo = 1 [(gogoproto.nullable) = true]",
+		},
+		{
+			field:     "foo:Bla?",
+			dataType:  "*Bla",
+			protoType: "Bla foo = 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			fields, err := ParseFields([]string{tt.field}, noCheck)
+			require.NoError(t, err)
+			require.Equal(t, tt.dataType, fields[0].DataType())
+			require.EqualValues(t, tt.protoType, fields[0].ProtoType(1))
+		})
+	}
+}
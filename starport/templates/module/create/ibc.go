@@ -11,6 +11,7 @@ import (
 	"github.com/tendermint/starport/starport/pkg/placeholder"
 	"github.com/tendermint/starport/starport/pkg/xgenny"
 	"github.com/tendermint/starport/starport/pkg/xstrings"
+	"github.com/tendermint/starport/starport/services/astutils"
 	"github.com/tendermint/starport/starport/templates/field/plushhelpers"
 	"github.com/tendermint/starport/starport/templates/module"
 	"github.com/tendermint/starport/starport/templates/typed"
@@ -176,40 +177,65 @@ func appIBCModify(replacer placeholder.Replacer, opts *CreateOptions) genny.RunF
 			return err
 		}
 
+		var (
+			moduleTitle = strings.Title(opts.ModuleName)
+			keeperName  = moduleTitle + "Keeper"
+		)
+
 		// Add route to IBC router
-		templateRouter := `ibcRouter.AddRoute(%[2]vmoduletypes.ModuleName, %[2]vModule)
-%[1]v`
-		replacementRouter := fmt.Sprintf(
-			templateRouter,
+		route := fmt.Sprintf("ibcRouter.AddRoute(%[1]vmoduletypes.ModuleName, %[1]vModule)", opts.ModuleName)
+		content := module.Patch(
+			replacer,
+			f.String(),
 			module.PlaceholderIBCAppRouter,
-			opts.ModuleName,
+			route+"\n"+module.PlaceholderIBCAppRouter,
+			astutils.InsertStatementsBefore(module.AppNewFunc, module.AppIBCRouterSet, route),
 		)
-		content := replacer.Replace(f.String(), module.PlaceholderIBCAppRouter, replacementRouter)
 
 		// Scoped keeper declaration for the module
 		templateScopedKeeperDeclaration := `Scoped%[1]vKeeper capabilitykeeper.ScopedKeeper`
-		replacementScopedKeeperDeclaration := fmt.Sprintf(templateScopedKeeperDeclaration, strings.Title(opts.ModuleName))
-		content = replacer.Replace(content, module.PlaceholderIBCAppScopedKeeperDeclaration, replacementScopedKeeperDeclaration)
+		replacementScopedKeeperDeclaration := fmt.Sprintf(templateScopedKeeperDeclaration, moduleTitle)
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderIBCAppScopedKeeperDeclaration,
+			replacementScopedKeeperDeclaration,
+			astutils.AddStructField(module.AppStruct, replacementScopedKeeperDeclaration),
+		)
 
 		// Scoped keeper definition
 		templateScopedKeeperDefinition := `scoped%[1]vKeeper := app.CapabilityKeeper.ScopeToModule(%[2]vmoduletypes.ModuleName)
 app.Scoped%[1]vKeeper = scoped%[1]vKeeper`
 		replacementScopedKeeperDefinition := fmt.Sprintf(
 			templateScopedKeeperDefinition,
-			strings.Title(opts.ModuleName),
+			moduleTitle,
 			opts.ModuleName,
 		)
-		content = replacer.Replace(content, module.PlaceholderIBCAppScopedKeeperDefinition, replacementScopedKeeperDefinition)
-
-		// New argument passed to the module keeper
-		templateKeeperArgument := `app.IBCKeeper.ChannelKeeper,
-&app.IBCKeeper.PortKeeper,
-scoped%[1]vKeeper,`
-		replacementKeeperArgument := fmt.Sprintf(
-			templateKeeperArgument,
-			strings.Title(opts.ModuleName),
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderIBCAppScopedKeeperDefinition,
+			replacementScopedKeeperDefinition,
+			astutils.InsertStatementsBefore(
+				module.AppNewFunc,
+				fmt.Sprintf("app.%s =", keeperName),
+				replacementScopedKeeperDefinition,
+			),
+		)
+
+		// New arguments passed to the module keeper after its params subspace
+		keeperArguments := []string{
+			"app.IBCKeeper.ChannelKeeper",
+			"&app.IBCKeeper.PortKeeper",
+			fmt.Sprintf("scoped%sKeeper", moduleTitle),
+		}
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderIBCAppKeeperArgument,
+			strings.Join(keeperArguments, ",\n")+",",
+			astutils.InsertCallArgs(opts.ModuleName+"modulekeeper.NewKeeper", 4, keeperArguments...),
 		)
-		content = replacer.Replace(content, module.PlaceholderIBCAppKeeperArgument, replacementKeeperArgument)
 
 		newFile := genny.NewFileS(path, content)
 		return r.File(newFile)
@@ -11,6 +11,7 @@ import (
 	"github.com/tendermint/starport/starport/pkg/placeholder"
 	"github.com/tendermint/starport/starport/pkg/xgenny"
 	"github.com/tendermint/starport/starport/pkg/xstrings"
+	"github.com/tendermint/starport/starport/services/astutils"
 	"github.com/tendermint/starport/starport/templates/field/plushhelpers"
 	"github.com/tendermint/starport/starport/templates/module"
 )
@@ -90,19 +91,40 @@ func appModifyStargate(replacer placeholder.Replacer, opts *CreateOptions) genny
 			return err
 		}
 
+		// The app is modified structurally, the placeholders are only used when
+		// the code to modify can't be found
+		var (
+			moduleImport = opts.ModulePath + "/x/" + opts.ModuleName
+			keeperName   = strings.Title(opts.ModuleName) + "Keeper"
+		)
+
 		// Import
 		template := `%[2]vmodule "%[3]v/x/%[2]v"
 		%[2]vmodulekeeper "%[3]v/x/%[2]v/keeper"
 		%[2]vmoduletypes "%[3]v/x/%[2]v/types"
 %[1]v`
 		replacement := fmt.Sprintf(template, module.PlaceholderSgAppModuleImport, opts.ModuleName, opts.ModulePath)
-		content := replacer.Replace(f.String(), module.PlaceholderSgAppModuleImport, replacement)
+		content := module.Patch(
+			replacer,
+			f.String(),
+			module.PlaceholderSgAppModuleImport,
+			replacement,
+			astutils.AddImport(opts.ModuleName+"module", moduleImport),
+			astutils.AddImport(opts.ModuleName+"modulekeeper", moduleImport+"/keeper"),
+			astutils.AddImport(opts.ModuleName+"moduletypes", moduleImport+"/types"),
+		)
 
 		// ModuleBasic
 		template = `%[2]vmodule.AppModuleBasic{},
 %[1]v`
 		replacement = fmt.Sprintf(template, module.PlaceholderSgAppModuleBasic, opts.ModuleName)
-		content = replacer.Replace(content, module.PlaceholderSgAppModuleBasic, replacement)
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppModuleBasic,
+			replacement,
+			astutils.AppendCallArgs(module.AppModuleBasicsCall, opts.ModuleName+"module.AppModuleBasic{}"),
+		)
 
 		// Keeper declaration
 		var scopedKeeperDeclaration string
@@ -112,22 +134,34 @@ func appModifyStargate(replacer placeholder.Replacer, opts *CreateOptions) genny
 			scopedKeeperDeclaration = module.PlaceholderIBCAppScopedKeeperDeclaration
 		}
 		template = `%[3]v
-		%[4]vKeeper %[2]vmodulekeeper.Keeper
+		%[4]v %[2]vmodulekeeper.Keeper
 %[1]v`
 		replacement = fmt.Sprintf(
 			template,
 			module.PlaceholderSgAppKeeperDeclaration,
 			opts.ModuleName,
 			scopedKeeperDeclaration,
-			strings.Title(opts.ModuleName),
+			keeperName,
+		)
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppKeeperDeclaration,
+			replacement,
+			astutils.AddStructField(module.AppStruct, fmt.Sprintf("%s %smodulekeeper.Keeper", keeperName, opts.ModuleName)),
 		)
-		content = replacer.Replace(content, module.PlaceholderSgAppKeeperDeclaration, replacement)
 
 		// Store key
 		template = `%[2]vmoduletypes.StoreKey,
 %[1]v`
 		replacement = fmt.Sprintf(template, module.PlaceholderSgAppStoreKey, opts.ModuleName)
-		content = replacer.Replace(content, module.PlaceholderSgAppStoreKey, replacement)
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppStoreKey,
+			replacement,
+			astutils.AppendCallArgs(module.AppStoreKeysCall, opts.ModuleName+"moduletypes.StoreKey"),
+		)
 
 		// Module dependencies
 		var depArgs string
@@ -136,15 +170,14 @@ func appModifyStargate(replacer placeholder.Replacer, opts *CreateOptions) genny
 
 			// If bank is a dependency, add account permissions to the module
 			if dep.Name == "bank" {
-				template = `%[2]vmoduletypes.ModuleName: {authtypes.Minter, authtypes.Burner, authtypes.Staking},
-%[1]v`
-
-				replacement = fmt.Sprintf(
-					template,
+				perms := opts.ModuleName + "moduletypes.ModuleName: {authtypes.Minter, authtypes.Burner, authtypes.Staking}"
+				content = module.Patch(
+					replacer,
+					content,
 					module.PlaceholderSgAppMaccPerms,
-					opts.ModuleName,
+					perms+",\n"+module.PlaceholderSgAppMaccPerms,
+					astutils.AppendCompositeLit(module.AppMaccPermsVar, perms),
 				)
-				content = replacer.Replace(content, module.PlaceholderSgAppMaccPerms, replacement)
 			}
 		}
 
@@ -155,47 +188,73 @@ func appModifyStargate(replacer placeholder.Replacer, opts *CreateOptions) genny
 			// Scoped keeper definition for IBC module
 			// We set this placeholder so it is modified by the IBC module scaffolder
 			scopedKeeperDefinition = module.PlaceholderIBCAppScopedKeeperDefinition
-			ibcKeeperArgument = module.PlaceholderIBCAppKeeperArgument
+			ibcKeeperArgument = module.PlaceholderIBCAppKeeperArgument + "\n"
 		}
-		template = `%[3]v
-		app.%[5]vKeeper = *%[2]vmodulekeeper.NewKeeper(
+		template = `app.%[3]v = *%[1]vmodulekeeper.NewKeeper(
 			appCodec,
-			keys[%[2]vmoduletypes.StoreKey],
-			keys[%[2]vmoduletypes.MemStoreKey],
-			app.GetSubspace(%[2]vmoduletypes.ModuleName),
-			%[4]v
-			%[6]v)
-		%[2]vModule := %[2]vmodule.NewAppModule(appCodec, app.%[5]vKeeper, app.AccountKeeper, app.BankKeeper)
-
-		%[1]v`
+			keys[%[1]vmoduletypes.StoreKey],
+			keys[%[1]vmoduletypes.MemStoreKey],
+			app.GetSubspace(%[1]vmoduletypes.ModuleName),
+			%[2]v%[4]v)
+		%[1]vModule := %[1]vmodule.NewAppModule(appCodec, app.%[3]v, app.AccountKeeper, app.BankKeeper)`
 		replacement = fmt.Sprintf(
-			template,
-			module.PlaceholderSgAppKeeperDefinition,
-			opts.ModuleName,
+			"%s\n%s\n\n%s",
 			scopedKeeperDefinition,
-			ibcKeeperArgument,
-			strings.Title(opts.ModuleName),
-			depArgs,
+			fmt.Sprintf(template, opts.ModuleName, ibcKeeperArgument, keeperName, depArgs),
+			module.PlaceholderSgAppKeeperDefinition,
+		)
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppKeeperDefinition,
+			replacement,
+			astutils.InsertStatementsBefore(
+				module.AppNewFunc,
+				module.AppIBCRouterDefinition,
+				fmt.Sprintf(template, opts.ModuleName, "", keeperName, depArgs),
+			),
 		)
-		content = replacer.Replace(content, module.PlaceholderSgAppKeeperDefinition, replacement)
 
 		// App Module
-		template = `%[2]vModule,
-%[1]v`
-		replacement = fmt.Sprintf(template, module.PlaceholderSgAppAppModule, opts.ModuleName)
-		content = replacer.ReplaceAll(content, module.PlaceholderSgAppAppModule, replacement)
+		appModule := opts.ModuleName + "Module"
+		patched, err := astutils.Apply(
+			content,
+			astutils.AppendCallArgs(module.AppModuleManagerCall, appModule),
+			astutils.AppendCallArgs(module.AppSimulationManagerCall, appModule),
+		)
+		if err == nil {
+			content = patched
+		} else {
+			replacement = fmt.Sprintf("%s,\n%s", appModule, module.PlaceholderSgAppAppModule)
+			content = replacer.ReplaceAll(content, module.PlaceholderSgAppAppModule, replacement)
+		}
 
 		// Init genesis
 		template = `%[2]vmoduletypes.ModuleName,
 %[1]v`
 		replacement = fmt.Sprintf(template, module.PlaceholderSgAppInitGenesis, opts.ModuleName)
-		content = replacer.Replace(content, module.PlaceholderSgAppInitGenesis, replacement)
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppInitGenesis,
+			replacement,
+			astutils.AppendCallArgs(module.AppInitGenesisCall, opts.ModuleName+"moduletypes.ModuleName"),
+		)
 
 		// Param subspace
 		template = `paramsKeeper.Subspace(%[2]vmoduletypes.ModuleName)
 %[1]v`
 		replacement = fmt.Sprintf(template, module.PlaceholderSgAppParamSubspace, opts.ModuleName)
-		content = replacer.Replace(content, module.PlaceholderSgAppParamSubspace, replacement)
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppParamSubspace,
+			replacement,
+			astutils.InsertStatementsBeforeReturn(
+				module.AppParamsKeeperFunc,
+				fmt.Sprintf("paramsKeeper.Subspace(%smoduletypes.ModuleName)", opts.ModuleName),
+			),
+		)
 
 		newFile := genny.NewFileS(path, content)
 		return r.File(newFile)
@@ -3,10 +3,12 @@ package moduleimport
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/gobuffalo/genny"
 	"github.com/gobuffalo/plush"
 	"github.com/tendermint/starport/starport/pkg/placeholder"
+	"github.com/tendermint/starport/starport/services/astutils"
 	"github.com/tendermint/starport/starport/templates/field/plushhelpers"
 	"github.com/tendermint/starport/starport/templates/module"
 )
@@ -38,7 +40,15 @@ func appModifyStargate(replacer placeholder.Replacer, opts *ImportOptions) genny
 		"github.com/CosmWasm/wasmd/x/wasm"
 		wasmclient "github.com/CosmWasm/wasmd/x/wasm/client"`
 		replacementImport := fmt.Sprintf(templateImport, module.PlaceholderSgAppModuleImport)
-		content := replacer.Replace(f.String(), module.PlaceholderSgAppModuleImport, replacementImport)
+		content := module.Patch(
+			replacer,
+			f.String(),
+			module.PlaceholderSgAppModuleImport,
+			replacementImport,
+			astutils.AddImport("", "github.com/tendermint/spm-extras/wasmcmd"),
+			astutils.AddImport("", "github.com/CosmWasm/wasmd/x/wasm"),
+			astutils.AddImport("wasmclient", "github.com/CosmWasm/wasmd/x/wasm/client"),
+		)
 
 		templateEnabledProposals := `var (
 			// If EnabledSpecificProposals is "", and this is "true", then enable all x/wasm proposals.
@@ -50,44 +60,82 @@ func appModifyStargate(replacer placeholder.Replacer, opts *ImportOptions) genny
 			EnableSpecificProposals = ""
 		)
 		`
-		content = replacer.Replace(content, module.PlaceholderSgWasmAppEnabledProposals, templateEnabledProposals)
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgWasmAppEnabledProposals,
+			templateEnabledProposals,
+			astutils.AddDecl(templateEnabledProposals),
+		)
 
-		templateGovProposalHandlers := `%[1]v
-		govProposalHandlers = wasmclient.ProposalHandlers`
-		replacementProposalHandlers := fmt.Sprintf(templateGovProposalHandlers, module.PlaceholderSgAppGovProposalHandlers)
-		content = replacer.Replace(content, module.PlaceholderSgAppGovProposalHandlers, replacementProposalHandlers)
+		templateGovProposalHandlers := `govProposalHandlers = wasmclient.ProposalHandlers`
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppGovProposalHandlers,
+			module.PlaceholderSgAppGovProposalHandlers+"\n"+templateGovProposalHandlers,
+			astutils.InsertStatementsBefore(
+				module.AppGovProposalHandlersFunc,
+				module.AppGovProposalHandlersAppend,
+				templateGovProposalHandlers,
+			),
+		)
 
 		templateModuleBasic := `%[1]v
 		wasm.AppModuleBasic{},`
 		replacementModuleBasic := fmt.Sprintf(templateModuleBasic, module.PlaceholderSgAppModuleBasic)
-		content = replacer.Replace(content, module.PlaceholderSgAppModuleBasic, replacementModuleBasic)
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppModuleBasic,
+			replacementModuleBasic,
+			astutils.AppendCallArgs(module.AppModuleBasicsCall, "wasm.AppModuleBasic{}"),
+		)
 
 		templateKeeperDeclaration := `%[1]v
 		wasmKeeper       wasm.Keeper
 		scopedWasmKeeper capabilitykeeper.ScopedKeeper
 		`
 		replacementKeeperDeclaration := fmt.Sprintf(templateKeeperDeclaration, module.PlaceholderSgAppKeeperDeclaration)
-		content = replacer.Replace(content, module.PlaceholderSgAppKeeperDeclaration, replacementKeeperDeclaration)
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppKeeperDeclaration,
+			replacementKeeperDeclaration,
+			astutils.AddStructField(module.AppStruct, "wasmKeeper wasm.Keeper"),
+			astutils.AddStructField(module.AppStruct, "scopedWasmKeeper capabilitykeeper.ScopedKeeper"),
+		)
 
-		templateDeclaration := `%[1]v
-		scopedWasmKeeper := app.CapabilityKeeper.ScopeToModule(wasm.ModuleName)
-		`
-		replacementDeclaration := fmt.Sprintf(templateDeclaration, module.PlaceholderSgAppScopedKeeper)
-		content = replacer.Replace(content, module.PlaceholderSgAppScopedKeeper, replacementDeclaration)
+		templateDeclaration := `scopedWasmKeeper := app.CapabilityKeeper.ScopeToModule(wasm.ModuleName)`
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppScopedKeeper,
+			module.PlaceholderSgAppScopedKeeper+"\n"+templateDeclaration,
+			astutils.InsertStatementsBefore(module.AppNewFunc, module.AppIBCRouterDefinition, templateDeclaration),
+		)
 
-		templateDeclaration = `%[1]v
-		app.scopedWasmKeeper = scopedWasmKeeper
-		`
-		replacementDeclaration = fmt.Sprintf(templateDeclaration, module.PlaceholderSgAppBeforeInitReturn)
-		content = replacer.Replace(content, module.PlaceholderSgAppBeforeInitReturn, replacementDeclaration)
+		templateDeclaration = `app.scopedWasmKeeper = scopedWasmKeeper`
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppBeforeInitReturn,
+			module.PlaceholderSgAppBeforeInitReturn+"\n"+templateDeclaration,
+			astutils.InsertStatementsBeforeReturn(module.AppNewFunc, templateDeclaration),
+		)
 
 		templateStoreKey := `%[1]v
 		wasm.StoreKey,`
 		replacementStoreKey := fmt.Sprintf(templateStoreKey, module.PlaceholderSgAppStoreKey)
-		content = replacer.Replace(content, module.PlaceholderSgAppStoreKey, replacementStoreKey)
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppStoreKey,
+			replacementStoreKey,
+			astutils.AppendCallArgs(module.AppStoreKeysCall, "wasm.StoreKey"),
+		)
 
-		templateKeeperDefinition := `%[1]v
-		wasmDir := filepath.Join(homePath, "wasm")
+		templateKeeperDefinition := `wasmDir := filepath.Join(homePath, "wasm")
 	
 		wasmConfig, err := wasm.ReadWasmConfig(appOpts)
 		if err != nil {
@@ -121,23 +169,44 @@ func appModifyStargate(replacer placeholder.Replacer, opts *ImportOptions) genny
 		if len(enabledProposals) != 0 {
 			govRouter.AddRoute(wasm.RouterKey, wasm.NewWasmProposalHandler(app.wasmKeeper, enabledProposals))
 		}`
-		replacementKeeperDefinition := fmt.Sprintf(templateKeeperDefinition, module.PlaceholderSgAppKeeperDefinition)
-		content = replacer.Replace(content, module.PlaceholderSgAppKeeperDefinition, replacementKeeperDefinition)
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppKeeperDefinition,
+			module.PlaceholderSgAppKeeperDefinition+"\n"+templateKeeperDefinition,
+			astutils.InsertStatementsBefore(module.AppNewFunc, module.AppIBCRouterDefinition, templateKeeperDefinition),
+		)
 
 		templateAppModule := `%[1]v
 		wasm.NewAppModule(appCodec, &app.wasmKeeper, app.StakingKeeper),`
 		replacementAppModule := fmt.Sprintf(templateAppModule, module.PlaceholderSgAppAppModule)
-		content = replacer.Replace(content, module.PlaceholderSgAppAppModule, replacementAppModule)
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppAppModule,
+			replacementAppModule,
+			astutils.AppendCallArgs(module.AppModuleManagerCall, "wasm.NewAppModule(appCodec, &app.wasmKeeper, app.StakingKeeper)"),
+		)
 
 		templateInitGenesis := `%[1]v
 		wasm.ModuleName,`
 		replacementInitGenesis := fmt.Sprintf(templateInitGenesis, module.PlaceholderSgAppInitGenesis)
-		content = replacer.Replace(content, module.PlaceholderSgAppInitGenesis, replacementInitGenesis)
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppInitGenesis,
+			replacementInitGenesis,
+			astutils.AppendCallArgs(module.AppInitGenesisCall, "wasm.ModuleName"),
+		)
 
-		templateParamSubspace := `%[1]v
-		paramsKeeper.Subspace(wasm.ModuleName)`
-		replacementParamSubspace := fmt.Sprintf(templateParamSubspace, module.PlaceholderSgAppParamSubspace)
-		content = replacer.Replace(content, module.PlaceholderSgAppParamSubspace, replacementParamSubspace)
+		templateParamSubspace := `paramsKeeper.Subspace(wasm.ModuleName)`
+		content = module.Patch(
+			replacer,
+			content,
+			module.PlaceholderSgAppParamSubspace,
+			module.PlaceholderSgAppParamSubspace+"\n"+templateParamSubspace,
+			astutils.InsertStatementsBeforeReturn(module.AppParamsKeeperFunc, templateParamSubspace),
+		)
 
 		newFile := genny.NewFileS(path, content)
 		return r.File(newFile)
@@ -153,10 +222,22 @@ func cmdModifyStargate(replacer placeholder.Replacer, opts *ImportOptions) genny
 			return err
 		}
 
-		templateArgs := `cosmoscmd.AddSubCmd(wasmcmd.GenesisWasmMsgCmd(app.DefaultNodeHome)),
-cosmoscmd.CustomizeStartCmd(wasmcmd.AddModuleInitFlags),
+		args := []string{
+			"cosmoscmd.AddSubCmd(wasmcmd.GenesisWasmMsgCmd(app.DefaultNodeHome))",
+			"cosmoscmd.CustomizeStartCmd(wasmcmd.AddModuleInitFlags)",
+		}
+		patched, err := astutils.Apply(
+			f.String(),
+			astutils.AddImport("", "github.com/tendermint/spm-extras/wasmcmd"),
+			astutils.AppendCallArgs(module.RootCmdCall, args...),
+		)
+		if err == nil {
+			return r.File(genny.NewFileS(path, patched))
+		}
+
+		templateArgs := `%[2]v,
 		%[1]v`
-		replacementArgs := fmt.Sprintf(templateArgs, module.PlaceholderSgRootArgument)
+		replacementArgs := fmt.Sprintf(templateArgs, module.PlaceholderSgRootArgument, strings.Join(args, ",\n"))
 		content := replacer.Replace(f.String(), module.PlaceholderSgRootArgument, replacementArgs)
 
 		// import spm-extras.
@@ -0,0 +1,40 @@
+package module
+
+import (
+	"github.com/tendermint/starport/starport/pkg/placeholder"
+	"github.com/tendermint/starport/starport/services/astutils"
+)
+
+// Code of Stargate app.go modified structurally when a module is scaffolded or imported
+const (
+	AppStruct                    = "App"
+	AppNewFunc                   = "New"
+	AppParamsKeeperFunc          = "initParamsKeeper"
+	AppGovProposalHandlersFunc   = "getGovProposalHandlers"
+	AppMaccPermsVar              = "maccPerms"
+	AppModuleBasicsCall          = "module.NewBasicManager"
+	AppStoreKeysCall             = "sdk.NewKVStoreKeys"
+	AppModuleManagerCall         = "module.NewManager"
+	AppSimulationManagerCall     = "module.NewSimulationManager"
+	AppInitGenesisCall           = "app.mm.SetOrderInitGenesis"
+	AppGovProposalHandlersAppend = "govProposalHandlers = append("
+	AppIBCRouterDefinition       = "ibcRouter := ibcporttypes.NewRouter()"
+	AppIBCRouterSet              = "app.IBCKeeper.SetRouter(ibcRouter)"
+	RootCmdCall                  = "cosmoscmd.NewRootCmd"
+)
+
+// Patch applies the edits to content, the placeholder is replaced with
+// replacement instead when the edits can't be applied, like when the code to
+// modify has been refactored or the file can't be parsed.
+func Patch(
+	replacer placeholder.Replacer,
+	content,
+	placeholder,
+	replacement string,
+	edits ...astutils.Edit,
+) string {
+	if patched, err := astutils.Apply(content, edits...); err == nil {
+		return patched
+	}
+	return replacer.Replace(content, placeholder, replacement)
+}
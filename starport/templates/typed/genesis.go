@@ -7,11 +7,18 @@ import (
 
 	"github.com/tendermint/starport/starport/pkg/placeholder"
 	"github.com/tendermint/starport/starport/pkg/protoanalysis"
+	"github.com/tendermint/starport/starport/services/astutils"
 )
 
 // ProtoGenesisStateMessage is the name of the proto message that represents the genesis state
 const ProtoGenesisStateMessage = "GenesisState"
 
+// Functions of types/genesis.go modified structurally by the typed generators
+const (
+	GenesisTypesDefaultFunc  = "DefaultGenesis"
+	GenesisTypesValidateFunc = "Validate"
+)
+
 // PatchGenesisTypeImport patches types/genesis.go content from the issue:
 // https://github.com/tendermint/starport/issues/992
 func PatchGenesisTypeImport(replacer placeholder.Replacer, content string) string {
@@ -27,6 +34,16 @@ func PatchGenesisTypeImport(replacer placeholder.Replacer, content string) strin
 	return content
 }
 
+// PatchGenesisTypeFmtImport imports fmt in types/genesis.go content, the import
+// placeholder is used when the file can't be parsed
+func PatchGenesisTypeFmtImport(replacer placeholder.Replacer, content string) string {
+	if patched, err := astutils.Apply(content, astutils.AddImport("", "fmt")); err == nil {
+		return patched
+	}
+	content = PatchGenesisTypeImport(replacer, content)
+	return replacer.ReplaceOnce(content, PlaceholderGenesisTypesImport, `"fmt"`)
+}
+
 // GenesisStateHighestFieldNumber returns the highest field number in the genesis state proto message
 // This allows to determine next the field numbers
 func GenesisStateHighestFieldNumber(path string) (int, error) {
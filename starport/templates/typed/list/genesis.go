@@ -20,8 +20,6 @@ func genesisModify(replacer placeholder.Replacer, opts *typed.Options, g *genny.
 	g.RunFn(genesisTypesTestsModify(replacer, opts))
 }
 
-//as ass
-
 func genesisProtoModify(replacer placeholder.Replacer, opts *typed.Options) genny.RunFn {
 	return func(r *genny.Runner) error {
 		path := filepath.Join(opts.AppPath, "proto", opts.ModuleName, "genesis.proto")
@@ -69,57 +67,48 @@ func genesisProtoModify(replacer placeholder.Replacer, opts *typed.Options) genn
 }
 
 func genesisTypesModify(replacer placeholder.Replacer, opts *typed.Options) genny.RunFn {
-
 	return func(r *genny.Runner) error {
-
 		path := filepath.Join(opts.AppPath, "x", opts.ModuleName, "types/genesis.go")
-
-		astHelper, err := astutils.NewAstHelper(path)
-		defer astHelper.Close()
-
-		if err != nil {
-			return err
-		}
-
-		astHelper.AddImport("fmt")
-		astHelper.Write()
-
 		f, err := r.Disk.Find(path)
 		if err != nil {
 			return err
 		}
 
-		content := typed.PatchGenesisTypeImport(replacer, f.String())
+		content := typed.PatchGenesisTypeFmtImport(replacer, f.String())
 
-		templateTypesDefault := `%[2]vList: []%[2]v{},
-%[1]v`
-		replacementTypesDefault := fmt.Sprintf(
-			templateTypesDefault,
+		typesDefault := fmt.Sprintf("%[1]vList: []%[1]v{}", opts.TypeName.UpperCamel)
+		content = module.Patch(
+			replacer,
+			content,
 			typed.PlaceholderGenesisTypesDefault,
-			opts.TypeName.UpperCamel,
+			typesDefault+",\n"+typed.PlaceholderGenesisTypesDefault,
+			astutils.AppendReturnLit(typed.GenesisTypesDefaultFunc, typesDefault),
 		)
-		content = replacer.Replace(content, typed.PlaceholderGenesisTypesDefault, replacementTypesDefault)
 
-		templateTypesValidate := `// Check for duplicated ID in %[2]v
-%[2]vIdMap := make(map[uint64]bool)
-%[2]vCount := gs.Get%[3]vCount()
-for _, elem := range gs.%[3]vList {
-	if _, ok := %[2]vIdMap[elem.Id]; ok {
-		return fmt.Errorf("duplicated id for %[2]v")
+		templateTypesValidate := `// Check for duplicated ID in %[1]v
+%[1]vIdMap := make(map[uint64]bool)
+%[1]vCount := gs.Get%[2]vCount()
+for _, elem := range gs.%[2]vList {
+	if _, ok := %[1]vIdMap[elem.Id]; ok {
+		return fmt.Errorf("duplicated id for %[1]v")
 	}
-	if elem.Id >= %[2]vCount {
-		return fmt.Errorf("%[2]v id should be lower or equal than the last id")
+	if elem.Id >= %[1]vCount {
+		return fmt.Errorf("%[1]v id should be lower or equal than the last id")
 	}
-	%[2]vIdMap[elem.Id] = true
-}
-%[1]v`
-		replacementTypesValidate := fmt.Sprintf(
+	%[1]vIdMap[elem.Id] = true
+}`
+		typesValidate := fmt.Sprintf(
 			templateTypesValidate,
-			typed.PlaceholderGenesisTypesValidate,
 			opts.TypeName.LowerCamel,
 			opts.TypeName.UpperCamel,
 		)
-		content = replacer.Replace(content, typed.PlaceholderGenesisTypesValidate, replacementTypesValidate)
+		content = module.Patch(
+			replacer,
+			content,
+			typed.PlaceholderGenesisTypesValidate,
+			typesValidate+"\n"+typed.PlaceholderGenesisTypesValidate,
+			astutils.InsertStatementsBeforeReturn(typed.GenesisTypesValidateFunc, typesValidate),
+		)
 
 		newFile := genny.NewFileS(path, content)
 		return r.File(newFile)
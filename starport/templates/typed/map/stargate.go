@@ -9,6 +9,7 @@ import (
 	"github.com/gobuffalo/genny"
 	"github.com/tendermint/starport/starport/pkg/placeholder"
 	"github.com/tendermint/starport/starport/pkg/xgenny"
+	"github.com/tendermint/starport/starport/services/astutils"
 	"github.com/tendermint/starport/starport/templates/field/datatype"
 	"github.com/tendermint/starport/starport/templates/module"
 	"github.com/tendermint/starport/starport/templates/typed"
@@ -291,19 +292,16 @@ func genesisTypesModify(replacer placeholder.Replacer, opts *typed.Options) genn
 			return err
 		}
 
-		content := typed.PatchGenesisTypeImport(replacer, f.String())
+		content := typed.PatchGenesisTypeFmtImport(replacer, f.String())
 
-		templateTypesImport := `"fmt"`
-		content = replacer.ReplaceOnce(content, typed.PlaceholderGenesisTypesImport, templateTypesImport)
-
-		templateTypesDefault := `%[2]vList: []%[2]v{},
-%[1]v`
-		replacementTypesDefault := fmt.Sprintf(
-			templateTypesDefault,
+		typesDefault := fmt.Sprintf("%[1]vList: []%[1]v{}", opts.TypeName.UpperCamel)
+		content = module.Patch(
+			replacer,
+			content,
 			typed.PlaceholderGenesisTypesDefault,
-			opts.TypeName.UpperCamel,
+			typesDefault+",\n"+typed.PlaceholderGenesisTypesDefault,
+			astutils.AppendReturnLit(typed.GenesisTypesDefaultFunc, typesDefault),
 		)
-		content = replacer.Replace(content, typed.PlaceholderGenesisTypesDefault, replacementTypesDefault)
 
 		// lines of code to call the key function with the indexes of the element
 		var indexArgs []string
@@ -312,25 +310,29 @@ func genesisTypesModify(replacer placeholder.Replacer, opts *typed.Options) genn
 		}
 		keyCall := fmt.Sprintf("%sKey(%s)", opts.TypeName.UpperCamel, strings.Join(indexArgs, ","))
 
-		templateTypesValidate := `// Check for duplicated index in %[2]v
-%[2]vIndexMap := make(map[string]struct{})
+		templateTypesValidate := `// Check for duplicated index in %[1]v
+%[1]vIndexMap := make(map[string]struct{})
 
-for _, elem := range gs.%[3]vList {
-	index := %[4]v
-	if _, ok := %[2]vIndexMap[index]; ok {
-		return fmt.Errorf("duplicated index for %[2]v")
+for _, elem := range gs.%[2]vList {
+	index := %[3]v
+	if _, ok := %[1]vIndexMap[index]; ok {
+		return fmt.Errorf("duplicated index for %[1]v")
 	}
-	%[2]vIndexMap[index] = struct{}{}
-}
-%[1]v`
-		replacementTypesValidate := fmt.Sprintf(
+	%[1]vIndexMap[index] = struct{}{}
+}`
+		typesValidate := fmt.Sprintf(
 			templateTypesValidate,
-			typed.PlaceholderGenesisTypesValidate,
 			opts.TypeName.LowerCamel,
 			opts.TypeName.UpperCamel,
 			fmt.Sprintf("string(%s)", keyCall),
 		)
-		content = replacer.Replace(content, typed.PlaceholderGenesisTypesValidate, replacementTypesValidate)
+		content = module.Patch(
+			replacer,
+			content,
+			typed.PlaceholderGenesisTypesValidate,
+			typesValidate+"\n"+typed.PlaceholderGenesisTypesValidate,
+			astutils.InsertStatementsBeforeReturn(typed.GenesisTypesValidateFunc, typesValidate),
+		)
 
 		newFile := genny.NewFileS(path, content)
 		return r.File(newFile)
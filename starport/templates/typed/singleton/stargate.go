@@ -10,6 +10,7 @@ import (
 	"github.com/gobuffalo/genny"
 	"github.com/tendermint/starport/starport/pkg/placeholder"
 	"github.com/tendermint/starport/starport/pkg/xgenny"
+	"github.com/tendermint/starport/starport/services/astutils"
 	"github.com/tendermint/starport/starport/templates/module"
 	"github.com/tendermint/starport/starport/templates/typed"
 )
@@ -223,14 +224,14 @@ func genesisTypesModify(replacer placeholder.Replacer, opts *typed.Options) genn
 
 		content := typed.PatchGenesisTypeImport(replacer, f.String())
 
-		templateTypesDefault := `%[2]v: nil,
-%[1]v`
-		replacementTypesDefault := fmt.Sprintf(
-			templateTypesDefault,
+		typesDefault := opts.TypeName.UpperCamel + ": nil"
+		content = module.Patch(
+			replacer,
+			content,
 			typed.PlaceholderGenesisTypesDefault,
-			opts.TypeName.UpperCamel,
+			typesDefault+",\n"+typed.PlaceholderGenesisTypesDefault,
+			astutils.AppendReturnLit(typed.GenesisTypesDefaultFunc, typesDefault),
 		)
-		content = replacer.Replace(content, typed.PlaceholderGenesisTypesDefault, replacementTypesDefault)
 
 		newFile := genny.NewFileS(path, content)
 		return r.File(newFile)